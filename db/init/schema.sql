@@ -2,6 +2,8 @@
 -- Drop existing tables
 DROP TABLE IF EXISTS payment CASCADE;
 
+DROP TABLE IF EXISTS refunds CASCADE;
+
 DROP TABLE IF EXISTS tickets CASCADE;
 
 DROP TABLE IF EXISTS reservations CASCADE;
@@ -12,6 +14,14 @@ DROP TABLE IF EXISTS permissions CASCADE;
 
 DROP TABLE IF EXISTS user_auth_logs CASCADE;
 
+DROP TABLE IF EXISTS user_sessions CASCADE;
+
+DROP TABLE IF EXISTS token_blacklist CASCADE;
+
+DROP TABLE IF EXISTS user_totp CASCADE;
+
+DROP TABLE IF EXISTS role_change_history CASCADE;
+
 DROP TABLE IF EXISTS users CASCADE;
 
 DROP TABLE IF EXISTS roles CASCADE;
@@ -22,22 +32,34 @@ DROP TABLE IF EXISTS payment_statuses CASCADE;
 
 DROP TABLE IF EXISTS ticket_statuses CASCADE;
 
+DROP TABLE IF EXISTS event_ticket_prices CASCADE;
+
+DROP TABLE IF EXISTS promo_codes CASCADE;
+
 DROP TABLE IF EXISTS ticket_types CASCADE;
 
 DROP TABLE IF EXISTS events CASCADE;
 
+DROP TABLE IF EXISTS event_series CASCADE;
+
+DROP TABLE IF EXISTS event_sections CASCADE;
+
 DROP TABLE IF EXISTS locations CASCADE;
 
 -- Load pgcrypto extension
 CREATE EXTENSION IF NOT EXISTS "pgcrypto";
 
--- Blacklisted tokens for logout
+-- Blacklisted tokens for logout, stored as SHA-256 hashes rather than raw JWTs;
+-- the unique index serves the lookup done on authenticated requests
 CREATE TABLE token_blacklist (
   id SERIAL PRIMARY KEY,
-  token TEXT NOT NULL,
+  token_hash CHAR(64) UNIQUE NOT NULL, -- hex-encoded SHA-256 of the token
   expires_at TIMESTAMP NOT NULL
 );
 
+-- cleanup of expired tokens
+CREATE INDEX idx_token_blacklist_expires_at ON token_blacklist (expires_at);
+
 -- Roles of the user within the system
 CREATE TABLE roles (
   id SERIAL PRIMARY KEY,
@@ -57,6 +79,7 @@ CREATE TABLE users (
   password_hash VARCHAR(255) NOT NULL,
   role_id INT NOT NULL,
   is_active BOOLEAN DEFAULT TRUE,
+  calendar_feed_version INT NOT NULL DEFAULT 0, -- bumped to revoke calendar feed tokens
   CONSTRAINT fk_user_role FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE RESTRICT
 );
 
@@ -71,6 +94,44 @@ CREATE TABLE user_auth_logs (
   CONSTRAINT fk_user_auth_log FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
 );
 
+-- TOTP secrets of two-factor authentication, encrypted with a key derived from TOTP_ENCRYPTION_KEY
+CREATE TABLE user_totp (
+  user_id UUID PRIMARY KEY,
+  secret TEXT NOT NULL, -- base64 of the AES-GCM nonce and ciphertext
+  enabled BOOLEAN NOT NULL DEFAULT FALSE, -- set once a code confirms the enrollment
+  last_used_step BIGINT, -- time step of the last accepted code, so codes are not replayed
+  failed_attempts INT NOT NULL DEFAULT 0, -- invalid codes in a row
+  locked_until TIMESTAMP, -- set once too many invalid codes were given
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_user_totp_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Role changes, to track who granted which permissions and when
+CREATE TABLE role_change_history (
+  id SERIAL PRIMARY KEY,
+  user_id UUID NOT NULL,
+  changed_by UUID, -- admin who changed the role
+  old_role_id INT NOT NULL,
+  new_role_id INT NOT NULL,
+  changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_role_change_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT fk_role_change_changed_by FOREIGN KEY (changed_by) REFERENCES users (id) ON DELETE SET NULL,
+  CONSTRAINT fk_role_change_old_role FOREIGN KEY (old_role_id) REFERENCES roles (id) ON DELETE RESTRICT,
+  CONSTRAINT fk_role_change_new_role FOREIGN KEY (new_role_id) REFERENCES roles (id) ON DELETE RESTRICT
+);
+
+-- Sessions, one per issued token
+CREATE TABLE user_sessions (
+  id SERIAL PRIMARY KEY,
+  user_id UUID NOT NULL,
+  token_hash CHAR(64) NOT NULL, -- hex-encoded SHA-256 of the token, as in token_blacklist
+  issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expires_at TIMESTAMP NOT NULL,
+  ip_address INET,
+  user_agent TEXT,
+  CONSTRAINT fk_user_session FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
 -- permissions for each role
 CREATE TABLE permissions (
   id SERIAL PRIMARY KEY,
@@ -93,20 +154,75 @@ CREATE TABLE locations (
   stadium VARCHAR(150) NOT NULL,
   address VARCHAR(250) NOT NULL,
   country VARCHAR(100) NOT NULL,
-  capacity INT NOT NULL CHECK (capacity > 0)
+  capacity INT NOT NULL CHECK (capacity > 0),
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Recurring event series, generated from a single rule
+CREATE TABLE event_series (
+  id SERIAL PRIMARY KEY,
+  frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('DAILY', 'WEEKLY', 'MONTHLY')),
+  repeat_interval INT NOT NULL CHECK (repeat_interval > 0), -- every n-th day, week or month
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
 -- Event Table
 CREATE TABLE events (
   id SERIAL PRIMARY KEY,
   name VARCHAR(200) NOT NULL,
-  date TIMESTAMP NOT NULL CHECK (date > CURRENT_TIMESTAMP),
+  slug VARCHAR(250) UNIQUE, -- human-readable identifier, derived from name and date
+  date TIMESTAMP NOT NULL, -- must be in the future when set, see trg_events_date
   price DECIMAL(10, 2) NOT NULL CHECK (price >= 0),
   location_id INT NOT NULL,
   available_tickets INT NOT NULL CHECK (available_tickets >= 0),
-  CONSTRAINT fk_event_location FOREIGN KEY (location_id) REFERENCES Locations (id) ON DELETE CASCADE
+  max_tickets_per_reservation INT CHECK (max_tickets_per_reservation > 0), -- overrides the global limit
+  max_reservations_per_user INT CHECK (max_reservations_per_user > 0), -- NULL means unlimited
+  sale_starts_at TIMESTAMP, -- NULL means on sale right away
+  reservation_cutoff_hours INT CHECK (reservation_cutoff_hours >= 0), -- NULL means the global cutoff
+  visibility VARCHAR(10) NOT NULL DEFAULT 'PUBLIC' CHECK (visibility IN ('PUBLIC', 'UNLISTED', 'PRIVATE')),
+  series_id INT, -- set for events generated from a recurrence
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_event_location FOREIGN KEY (location_id) REFERENCES Locations (id) ON DELETE CASCADE,
+  CONSTRAINT fk_event_series FOREIGN KEY (series_id) REFERENCES event_series (id) ON DELETE SET NULL
 );
 
+-- listing of events within a series
+CREATE INDEX idx_events_series_id ON events (series_id);
+
+-- listing of recently created events
+CREATE INDEX idx_events_created_at ON events (created_at);
+
+-- Keep updated_at current on every update, including ticket availability changes
+CREATE OR REPLACE FUNCTION set_updated_at () RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at = CURRENT_TIMESTAMP;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_locations_updated_at BEFORE UPDATE ON locations
+  FOR EACH ROW EXECUTE FUNCTION set_updated_at ();
+
+CREATE TRIGGER trg_events_updated_at BEFORE UPDATE ON events
+  FOR EACH ROW EXECUTE FUNCTION set_updated_at ();
+
+-- Require the date to be in the future only when it is set, unlike a CHECK
+-- constraint, which would reject any later update of events already past
+CREATE OR REPLACE FUNCTION check_event_date () RETURNS TRIGGER AS $$
+BEGIN
+  IF (TG_OP = 'INSERT' OR NEW.date IS DISTINCT FROM OLD.date)
+    AND NEW.date <= CURRENT_TIMESTAMP THEN
+    RAISE EXCEPTION 'Event date must be in the future.'
+      USING ERRCODE = 'check_violation';
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_events_date BEFORE INSERT OR UPDATE OF date ON events
+  FOR EACH ROW EXECUTE FUNCTION check_event_date ();
+
 -- Statuses for reservations
 CREATE TABLE reservation_statuses (
   id SERIAL PRIMARY KEY,
@@ -121,11 +237,20 @@ CREATE TABLE reservations (
   created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
   total_tickets INT NOT NULL CHECK (total_tickets > 0),
   status_id INT NOT NULL,
+  notes TEXT, -- internal notes, visible to admins only
+  confirmed_by UUID, -- admin who confirmed the reservation manually
+  confirmed_at TIMESTAMP,
+  created_by UUID, -- user who made the reservation, differs from the owner on behalf of others
   CONSTRAINT fk_reservation_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
   CONSTRAINT fk_reservation_event FOREIGN KEY (event_id) REFERENCES Events (id) ON DELETE CASCADE,
-  CONSTRAINT fk_reservation_status FOREIGN KEY (status_id) REFERENCES reservation_statuses (id) ON DELETE CASCADE
+  CONSTRAINT fk_reservation_status FOREIGN KEY (status_id) REFERENCES reservation_statuses (id) ON DELETE CASCADE,
+  CONSTRAINT fk_reservation_confirmed_by FOREIGN KEY (confirmed_by) REFERENCES users (id) ON DELETE SET NULL,
+  CONSTRAINT fk_reservation_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
 );
 
+-- keyset pagination of the reservation list
+CREATE INDEX idx_reservations_created_at_id ON reservations (created_at, id);
+
 -- Ticket Types
 CREATE TABLE ticket_types (
   id SERIAL PRIMARY KEY,
@@ -134,6 +259,24 @@ CREATE TABLE ticket_types (
   description VARCHAR(250) NOT NULL
 );
 
+-- Per-event price overrides of ticket types
+CREATE TABLE event_ticket_prices (
+  event_id INT NOT NULL,
+  type_id INT NOT NULL,
+  price DECIMAL(10, 2) NOT NULL CHECK (price >= 0),
+  PRIMARY KEY (event_id, type_id),
+  CONSTRAINT fk_event_ticket_price_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE,
+  CONSTRAINT fk_event_ticket_price_type FOREIGN KEY (type_id) REFERENCES ticket_types (id) ON DELETE CASCADE
+);
+
+-- Sections of an event tickets can be reserved in, e.g. stands of a stadium
+CREATE TABLE event_sections (
+  event_id INT NOT NULL,
+  name VARCHAR(50) NOT NULL,
+  PRIMARY KEY (event_id, name),
+  CONSTRAINT fk_event_section_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE
+);
+
 -- Ticket Statuses
 CREATE TABLE ticket_statuses (
   id SERIAL PRIMARY KEY,
@@ -147,9 +290,13 @@ CREATE TABLE tickets (
   price DECIMAL(10, 2) NOT NULL CHECK (price >= 0),
   type_id INT NOT NULL,
   status_id INT NOT NULL,
+  checked_in_at TIMESTAMP,
+  checked_in_by UUID,
+  section VARCHAR(50), -- preferred section, one of the sections of the event
   CONSTRAINT fk_ticket_reservation_id FOREIGN KEY (reservation_id) REFERENCES reservations (id) ON DELETE CASCADE,
   CONSTRAINT fk_ticket_type FOREIGN KEY (type_id) REFERENCES ticket_types (id) ON DELETE CASCADE,
-  CONSTRAINT fk_ticket_status FOREIGN KEY (status_id) REFERENCES ticket_statuses (id) ON DELETE CASCADE
+  CONSTRAINT fk_ticket_status FOREIGN KEY (status_id) REFERENCES ticket_statuses (id) ON DELETE CASCADE,
+  CONSTRAINT fk_ticket_checked_in_by FOREIGN KEY (checked_in_by) REFERENCES users (id) ON DELETE SET NULL
 );
 
 -- Payment Statuses
@@ -169,6 +316,27 @@ CREATE TABLE payment (
   CONSTRAINT fk_payment_status FOREIGN KEY (status_id) REFERENCES payment_statuses (id) ON DELETE CASCADE
 );
 
+-- Promo Codes (upper-case), discounting the base price of an event by a percentage
+CREATE TABLE promo_codes (
+  code VARCHAR(50) PRIMARY KEY,
+  percent_off DECIMAL(5, 2) NOT NULL CHECK (percent_off > 0 AND percent_off <= 100),
+  expires_at TIMESTAMP,
+  max_uses INT CHECK (max_uses > 0),
+  uses INT NOT NULL DEFAULT 0 CHECK (uses >= 0)
+);
+
+-- Refunds Table, partial refunds keep the difference as a cancellation fee
+CREATE TABLE refunds (
+  id SERIAL PRIMARY KEY,
+  reservation_id UUID NOT NULL,
+  amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0),
+  reason TEXT,
+  refunded_by UUID,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_refund_reservation FOREIGN KEY (reservation_id) REFERENCES reservations (id) ON DELETE CASCADE,
+  CONSTRAINT fk_refund_refunded_by FOREIGN KEY (refunded_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
 COMMENT ON TABLE users IS 'Stores user account information with role-based access';
 
 COMMENT ON TABLE roles IS 'Defines user roles with different access levels';
@@ -191,6 +359,10 @@ VALUES
     'REGISTERED',
     'Standard user with booking capabilities'
   ),
+  (
+    'STAFF',
+    'Venue staff, can view reservations and verify tickets'
+  ),
   ('ADMIN', 'Full system access and management');
 
 -- Initial values for permissions
@@ -211,7 +383,12 @@ VALUES
     'MANAGE_EVENTS',
     'Can create, update, delete events'
   ),
-  ('VIEW_REPORTS', 'Can access system reports');
+  ('VIEW_REPORTS', 'Can access system reports'),
+  (
+    'VIEW_RESERVATIONS',
+    'Can view reservations of all users'
+  ),
+  ('VERIFY_TICKETS', 'Can verify tickets at the gate');
 
 -- Mapping the initial permissions to roles
 INSERT INTO
@@ -235,6 +412,16 @@ WHERE
       'MANAGE_OWN_PROFILE'
     )
   )
+  OR (
+    r.name = 'STAFF'
+    AND p.name IN (
+      'VIEW_EVENTS',
+      'CREATE_RESERVATION',
+      'MANAGE_OWN_PROFILE',
+      'VIEW_RESERVATIONS',
+      'VERIFY_TICKETS'
+    )
+  )
   OR (
     r.name = 'ADMIN'
     AND p.name IN (
@@ -243,7 +430,9 @@ WHERE
       'MANAGE_OWN_PROFILE',
       'MANAGE_USERS',
       'MANAGE_EVENTS',
-      'VIEW_REPORTS'
+      'VIEW_REPORTS',
+      'VIEW_RESERVATIONS',
+      'VERIFY_TICKETS'
     )
   );
 
@@ -261,7 +450,8 @@ INSERT INTO
 VALUES
   ('RESERVED'),
   ('SOLD'),
-  ('CANCELLED');
+  ('CANCELLED'),
+  ('USED');
 
 -- Initial Payment Statuses
 INSERT INTO
@@ -57,6 +57,42 @@ func fetchUUIDIds(ctx context.Context, pool *pgxpool.Pool, table string) []uuid.
 	return ids
 }
 
+// Default roles of the system along with their descriptions.
+var defaultRoles = []struct {
+	Name        string
+	Description string
+}{
+	{"UNREGISTERED", "Limited access, cannot create reservations"},
+	{"REGISTERED", "Standard user with booking capabilities"},
+	{"STAFF", "Venue staff, can view reservations and verify tickets"},
+	{"ADMIN", "Full system access and management"},
+}
+
+// Ensure the default roles exist, filling in missing descriptions.
+func SeedRoles(pool *pgxpool.Pool) error {
+	batch := &pgx.Batch{}
+	for _, role := range defaultRoles {
+		batch.Queue(
+			`INSERT INTO roles (name, description)
+			VALUES ($1, $2)
+			ON CONFLICT (name) DO UPDATE
+			SET description = COALESCE(roles.description, EXCLUDED.description)`,
+			role.Name,
+			role.Description,
+		)
+	}
+
+	br := pool.SendBatch(context.Background(), batch)
+	defer br.Close()
+
+	for range defaultRoles {
+		if _, err := br.Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Add an admin user to the database.
 func AddAdminUser(fake *gofakeit.Faker, pool *pgxpool.Pool) error {
 	// get the root user credentials from env
@@ -210,7 +246,7 @@ func populateLocations(ctx context.Context, pool *pgxpool.Pool) error {
 		locations[i] = models.CreateLocationRequest{
 			Stadium:  strings.ToUpper(string(fake_stadium[0])) + fake_stadium[1:],
 			Address:  fake.Address().Address,
-			Country:  fake.Country(),
+			Country:  models.Countries[fake.Number(0, len(models.Countries)-1)].Name,
 			Capacity: fake.Number(5000, 80000),
 		}
 
@@ -275,6 +311,14 @@ func populateEvents(ctx context.Context, pool *pgxpool.Pool) error {
 		)
 	}
 
+	// generated events get slugs suffixed with their id, so they never collide
+	batch.Queue(
+		`UPDATE events
+		SET slug = TRIM(BOTH '-' FROM LOWER(REGEXP_REPLACE(name, '[^a-zA-Z0-9]+', '-', 'g')))
+			|| '-' || TO_CHAR(date, 'YYYY-MM-DD') || '-' || id
+		WHERE slug IS NULL`,
+	)
+
 	// send the batch
 	br := pool.SendBatch(ctx, batch)
 	defer br.Close()
@@ -325,8 +369,8 @@ func populateReservations(ctx context.Context, pool *pgxpool.Pool) error {
 
 		// fill the batch with requests
 		batch.Queue(
-			`INSERT INTO Reservations (user_id, event_id, created_at, total_tickets, status_id)
-        VALUES ($1, $2, $3, $4, $5)`,
+			`INSERT INTO Reservations (user_id, event_id, created_at, total_tickets, status_id, created_by)
+        VALUES ($1, $2, $3, $4, $5, $1)`,
 			reservations[i].UserID,
 			reservations[i].EventID,
 			reservations[i].CreatedAt,
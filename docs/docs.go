@@ -15,72 +15,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/events": {
-            "get": {
-                "description": "Retrieve a list of all events with their details and locations.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "events"
-                ],
-                "summary": "Get all events",
-                "operationId": "api.getEvents",
-                "responses": {
-                    "200": {
-                        "description": "List of events",
-                        "schema": {
-                            "$ref": "#/definitions/models.EventsResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "put": {
+        "/admin/impersonate/{userId}": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Parse the payload and create a new event with provided dataset.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Issue a short-lived token carrying the ID and role of the target user, flagged with the ID of the admin in the impersonated_by claim. Every request made with it is recorded in the audit log. Impersonation tokens cannot be used to impersonate further.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "admin"
                 ],
-                "summary": "Create a new event (admin only).",
-                "operationId": "api.createEvent",
+                "summary": "Impersonate a user (admin only).",
+                "operationId": "api.impersonateUser",
                 "parameters": [
                     {
-                        "description": "Payload to create an event",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateEventRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event created successfully",
+                        "description": "Impersonation token",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponseCreate"
+                            "$ref": "#/definitions/models.ImpersonationResponse"
                         }
                     },
                     "400": {
@@ -95,6 +59,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -104,35 +80,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{id}": {
-            "get": {
-                "description": "Retrieve an event with its details and location.",
+        "/auth/2fa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new TOTP secret for the current user, returned along with an otpauth URI to be rendered as a QR code for authenticator apps. Two-factor authentication is enabled only once a code is confirmed; enrolling again replaces a secret not confirmed yet.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "events"
-                ],
-                "summary": "Get an event by ID",
-                "operationId": "api.getEventByID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Enroll in two-factor authentication (admin only).",
+                "operationId": "api.enrollTwoFactor",
                 "responses": {
                     "200": {
-                        "description": "Event details",
+                        "description": "Secret to add to an authenticator app",
                         "schema": {
-                            "$ref": "#/definitions/models.EventResponse"
+                            "$ref": "#/definitions/models.TwoFactorEnrollResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -142,16 +120,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/2fa/enroll/verify": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update event details based on the provided payload.",
+                "description": "Verify a code generated from the enrolled secret, enabling two-factor authentication. From then on, logging in requires a code as well.",
                 "consumes": [
                     "application/json"
                 ],
@@ -159,31 +145,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "auth"
                 ],
-                "summary": "Update an existing event (admin only).",
-                "operationId": "api.updateEvent",
+                "summary": "Confirm two-factor enrollment (admin only).",
+                "operationId": "api.confirmTwoFactor",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Payload to update an event",
+                        "description": "Code from the authenticator app",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateEventRequest"
+                            "$ref": "#/definitions/models.TwoFactorCodeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event updated successfully",
+                        "description": "Two-factor authentication enabled",
                         "schema": {
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
@@ -194,14 +173,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -211,38 +208,46 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            }
+        },
+        "/auth/2fa/verify": {
+            "post": {
+                "description": "Answer the challenge returned by the login with a code from the authenticator app, getting a JWT token. Each code is accepted once, and the challenge expires after a few minutes. After several invalid codes in a row, the second factor is locked for a while.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Delete event by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "auth"
                 ],
-                "summary": "Delete an existing event (admin only).",
-                "operationId": "api.deleteEvent",
+                "summary": "Complete a login with a two-factor code.",
+                "operationId": "api.verifyTwoFactor",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Challenge and code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorVerifyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event deleted successfully",
+                        "description": "Successfully logged in",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponseCreate"
+                            "$ref": "#/definitions/models.LoginResponse"
                         }
                     },
                     "400": {
@@ -251,35 +256,64 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/locations": {
+        "/auth/sessions": {
             "get": {
-                "description": "Retrieve a list of all locations.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve non-expired and non-revoked sessions, along with the time they were issued and the client they were issued to.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "auth"
                 ],
-                "summary": "Get all locations.",
-                "operationId": "api.getLocations",
+                "summary": "List active sessions of the current user.",
+                "operationId": "api.getSessions",
                 "responses": {
                     "200": {
-                        "description": "List of locations",
+                        "description": "List of sessions",
                         "schema": {
-                            "$ref": "#/definitions/models.LocationsResponse"
+                            "$ref": "#/definitions/models.SessionsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -291,41 +325,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/sessions/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Parse the payload and create a new location with provided dataset.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Blacklist the token of the session with provided ID, logging the client out.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "auth"
                 ],
-                "summary": "Create a new location (admin only).",
-                "operationId": "api.createLocation",
+                "summary": "Revoke a session of the current user.",
+                "operationId": "api.deleteSession",
                 "parameters": [
                     {
-                        "description": "Payload to create a location",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateLocationRequest"
-                        }
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Location created successfully",
+                        "description": "Session revoked",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponseCreate"
+                            "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
                     "400": {
@@ -334,8 +365,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -349,35 +386,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/locations/{id}": {
+        "/auth/whoami": {
             "get": {
-                "description": "Retrieve a single location by ID.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the ID, username and role of the current user, along with the permissions granted to the role. Impersonation tokens also carry the ID of the admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
-                ],
-                "summary": "Retrieve a location.",
-                "operationId": "api.getLocationByID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Location ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Identity of the current user.",
+                "operationId": "api.whoami",
                 "responses": {
                     "200": {
-                        "description": "Location details",
+                        "description": "Current user",
                         "schema": {
-                            "$ref": "#/definitions/models.LocationResponse"
+                            "$ref": "#/definitions/models.WhoAmIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -389,14 +422,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/batch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update location details based on the provided payload.",
+                "description": "Run an array of GET sub-requests against the API, returning their responses in the same order. Each sub-request is authorized with the token of the batch, as if it was sent on its own.",
                 "consumes": [
                     "application/json"
                 ],
@@ -404,33 +439,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "batch"
                 ],
-                "summary": "Update an existing location (admin only).",
-                "operationId": "api.updateLocation",
+                "summary": "Execute read requests in a batch.",
+                "operationId": "api.batch",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Location ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Payload to update a location",
+                        "description": "Sub-requests",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateLocationRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BatchRequestItem"
+                            }
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event updated successfully",
+                        "description": "Responses of the sub-requests",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.BatchResponse"
                         }
                     },
                     "400": {
@@ -439,59 +470,50 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a location by its ID.",
+            }
+        },
+        "/calendar/{token}.ics": {
+            "get": {
+                "description": "Retrieve an iCalendar (RFC 5545) feed of upcoming events, for the user the feed token was issued to. Tokens are revoked by rotating them, changing the password or anonymizing the user.",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "locations"
+                    "reservations"
                 ],
-                "summary": "Delete an existing location (admin only).",
-                "operationId": "api.deleteLocation",
+                "summary": "Calendar feed, authenticated by a signed token.",
+                "operationId": "api.getCalendarFeed",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Location ID",
-                        "name": "id",
+                        "description": "Calendar feed token",
+                        "name": "token",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event deleted successfully",
+                        "description": "iCalendar feed",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponseCreate"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -505,128 +527,100 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Pass username and password to authenticate and get a JWT token.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/config": {
+            "get": {
+                "description": "Retrieve limits and settings which affect clients, such as the time pending reservations hold their tickets.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Login to the API.",
-                "operationId": "api.login",
-                "parameters": [
-                    {
-                        "description": "Login credentials",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
-                        }
-                    }
+                    "config"
                 ],
+                "summary": "Get the public configuration.",
+                "operationId": "api.getConfig",
                 "responses": {
                     "200": {
-                        "description": "Successfully logged in",
-                        "schema": {
-                            "$ref": "#/definitions/models.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Configuration",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ConfigResponse"
                         }
                     }
                 }
             }
         },
-        "/logout": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Invalidate currently used JWT token.",
+        "/countries": {
+            "get": {
+                "description": "Retrieve the ISO 3166-1 countries locations can be placed in, ordered by name. Locations accept the alpha-2 code, alpha-3 code, name or a common alias such as USA or UK, and store the name.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "locations"
                 ],
-                "summary": "Logout from the API (admin or registered user)",
-                "operationId": "api.logout",
+                "summary": "Get accepted countries.",
+                "operationId": "api.getCountries",
                 "responses": {
                     "200": {
-                        "description": "Successfully logged out",
-                        "schema": {
-                            "$ref": "#/definitions/models.LoginResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Accepted countries",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.CountriesResponse"
                         }
                     }
                 }
             }
         },
-        "/reservations": {
+        "/events": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a list of all reservations, including their details and tickets they reserve.",
+                "description": "Retrieve a list of all public events with their details and locations. Unlisted and private events are left out.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
+                ],
+                "summary": "Get all events",
+                "operationId": "api.getEvents",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only events with (true) or without (false) available tickets",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated top-level fields to return, e.g. id,name,date",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of events to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of returned dates, e.g. America/New_York (default UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all reservations (admin only).",
-                "operationId": "api.getReservations",
                 "responses": {
                     "200": {
-                        "description": "List of reservations",
-                        "schema": {
-                            "$ref": "#/definitions/models.ReservationsResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                        "description": "List of events",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.EventsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -645,31 +639,40 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Parse provided payload and create reservation and tickets within the database.",
+                "description": "Parse the payload and create a new event with provided dataset. Visibility defaults to PUBLIC; UNLISTED events are reachable by link only, PRIVATE ones by invitation.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
                 ],
-                "summary": "Create a reservation (owner/admin only).",
-                "operationId": "api.createReservation",
+                "summary": "Create a new event (admin only).",
+                "operationId": "api.createEvent",
                 "parameters": [
                     {
-                        "description": "Payload to create a reservation",
+                        "description": "Payload to create an event",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateReservationPayload"
+                            "$ref": "#/definitions/models.CreateEventRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Reservation created successfully",
+                        "description": "Event created successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponseCreateUUID"
+                            "$ref": "#/definitions/models.SuccessResponseCreate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "403": {
@@ -678,8 +681,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -693,27 +696,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations/user": {
+        "/events/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of current user's reservations along with details and tickets they reserve.",
+                "description": "Stream all events with their locations and availability, ordered by date. Accepts the same filters as the event list, without pagination, and includes unlisted and private events. Returned as CSV when requested with format=csv or an Accept header of text/csv.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
+                ],
+                "summary": "Export events (admin only).",
+                "operationId": "api.exportEvents",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Filter by availability of tickets",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format, json or csv (default json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of returned dates, e.g. America/New_York (default UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List user reservations for currently logged in user.",
-                "operationId": "api.getReservationsForCurrentUser",
                 "responses": {
                     "200": {
-                        "description": "List of reservations for the user",
+                        "description": "All matching events",
                         "schema": {
-                            "$ref": "#/definitions/models.ReservationsResponse"
+                            "$ref": "#/definitions/models.EventsResponse"
                         }
                     },
                     "400": {
@@ -722,8 +746,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -737,36 +761,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations/user/tickets": {
+        "/events/recent": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a list of current user's tickets.",
+                "description": "Retrieve upcoming events created within the given number of days, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
                 ],
-                "summary": "List user tickets for currently logged in user.",
-                "operationId": "api.getReservationTicketsForCurrentUser",
+                "summary": "Get recently created events",
+                "operationId": "api.getRecentEvents",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days back to look (default 7, max 90)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of events to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "IANA timezone of returned dates, e.g. America/New_York (default UTC)",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of tickets belonging to the user",
+                        "description": "List of events",
                         "schema": {
-                            "$ref": "#/definitions/models.UserTicketsResponse"
+                            "$ref": "#/definitions/models.EventsResponse"
                         }
                     },
                     "400": {
@@ -775,14 +811,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -790,36 +820,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations/user/{id}": {
-            "get": {
+        "/events/series": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of all reservations made by a specific user, including their details and tickets they reserve.",
+                "description": "Create the base event and its recurrences in one transaction, linked by a series ID. The rule repeats the event every interval days, weeks or months, either count times or until the given date (inclusive). The number of generated events is capped.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
                 ],
-                "summary": "List user reservations (admin/owner only)",
-                "operationId": "api.getReservationsForUserByID",
+                "summary": "Create a recurring event series (admin only).",
+                "operationId": "api.createEventSeries",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Base event and recurrence rule",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateEventSeriesRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of reservations for the user",
+                    "201": {
+                        "description": "Event series created successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.ReservationsResponse"
+                            "$ref": "#/definitions/models.CreateEventSeriesResponse"
                         }
                     },
                     "400": {
@@ -828,8 +863,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -843,36 +884,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations/user/{id}/tickets": {
+        "/events/series/{seriesId}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a list of user's tickets.",
+                "description": "Retrieve the recurrence of a series along with its events, ordered by date. Events removed from the series are not listed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
                 ],
-                "summary": "List user tickets (admin/owner only).",
-                "operationId": "api.getReservationTicketsForUserByID",
+                "summary": "Get an event series.",
+                "operationId": "api.getEventSeries",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Series ID",
+                        "name": "seriesId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of returned dates, e.g. America/New_York (default UTC)",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of tickets belonging to the user",
+                        "description": "Event series",
                         "schema": {
-                            "$ref": "#/definitions/models.UserTicketsResponse"
+                            "$ref": "#/definitions/models.EventSeriesResponse"
                         }
                     },
                     "400": {
@@ -896,42 +938,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations/{id}": {
+        "/events/slug/{slug}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a single reservation, including their details and tickets they reserve.",
+                "description": "Retrieve an event with its details and location, using its human-readable identifier. Private events are only shown to admins, staff and users holding a reservation for them.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
                 ],
-                "summary": "Get a reservation by ID (admin/owner only).",
-                "operationId": "api.getReservationsByID",
+                "summary": "Get an event by slug",
+                "operationId": "api.getEventBySlug",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Reservation ID",
-                        "name": "id",
+                        "description": "Event slug",
+                        "name": "slug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of returned dates, e.g. America/New_York (default UTC)",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Reservation details",
-                        "schema": {
-                            "$ref": "#/definitions/models.ReservationResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                        "description": "Event details",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.EventResponse"
                         }
                     },
                     "404": {
@@ -947,40 +984,58 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a single reservation along with its tickets from the database.",
+            }
+        },
+        "/events/{id}": {
+            "get": {
+                "description": "Retrieve an event with its details and location. Private events are only shown to admins, staff and users holding a reservation for them; anyone else gets 404.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
                 ],
-                "summary": "Delete a reservation by ID (admin only).",
-                "operationId": "api.deleteReservation",
+                "summary": "Get an event by ID",
+                "operationId": "api.getEventByID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Reservation ID",
+                        "description": "Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated top-level fields to return, e.g. id,name,date",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of returned dates, e.g. America/New_York (default UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time of the cached copy, answered with 304 if unchanged",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Reservation deleted successfully",
+                        "description": "Event details",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.EventResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -998,38 +1053,60 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/reservations/{id}/cancel": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Set statuses of reservation and its tickets to cancelled.",
+                "description": "Update event details based on the provided payload. Changing the date of an event with active reservations requires notify=true. The resulting event is validated as a whole, listing every violation.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
                 ],
-                "summary": "Cancel a reservation (owner/admin only).",
-                "operationId": "api.cancelReservation",
+                "summary": "Update an existing event (admin only).",
+                "operationId": "api.updateEvent",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Reservation ID",
+                        "description": "Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Acknowledge rescheduling an event with active reservations",
+                        "name": "notify",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Payload to update an event",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateEventRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Reservation canceled successfully",
+                        "description": "Event updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.UpdateEventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "403": {
@@ -1044,6 +1121,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1051,28 +1146,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/reservations/{id}/tickets": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all tickets associated with a specific reservation by its ID.",
+                "description": "Delete event by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "events"
                 ],
-                "summary": "List tickets attributed to given reservation (owner/admin only).",
-                "operationId": "api.getReservationTicketsByID",
+                "summary": "Delete an existing event (admin only).",
+                "operationId": "api.deleteEvent",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Reservation ID",
+                        "type": "string",
+                        "description": "Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1080,9 +1173,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of tickets for the reservation",
+                        "description": "Event deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.ReservationTicketsResponse"
+                            "$ref": "#/definitions/models.SuccessResponseCreate"
                         }
                     },
                     "400": {
@@ -1091,12 +1184,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1106,27 +1193,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/events/{id}/attendees": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of all users, including their details and roles.",
+                "description": "Retrieve users holding SOLD or USED (checked in) tickets for the event, one entry per user with the number of tickets of each type, ordered by surname. Returned as CSV when requested with format=csv or an Accept header of text/csv, e.g. for check-in sheets.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "users"
+                    "events"
+                ],
+                "summary": "Get attendees of an event (admin/staff only).",
+                "operationId": "api.getEventAttendees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format, json or csv (default json)",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all users (admin only).",
-                "operationId": "api.getUsers",
                 "responses": {
                     "200": {
-                        "description": "List of users",
+                        "description": "Attendees of the event",
                         "schema": {
-                            "$ref": "#/definitions/models.UsersResponse"
+                            "$ref": "#/definitions/models.EventAttendeesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "403": {
@@ -1148,31 +1257,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/events/{id}/clone": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a user with registered role based on provided payload. Request to create an admin user made by user without already admin status will be rejected.",
+                "description": "Copy the event, its location, limits and per-type prices into a new event. Availability is reset to the seats of the source event, counting the tickets it has already given out. Name and date can be overridden; the date must be in the future.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "events"
+                ],
+                "summary": "Clone an event (admin only).",
+                "operationId": "api.cloneEvent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Overrides of the copied fields",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CloneEventRequest"
+                        }
+                    }
                 ],
-                "summary": "Create a new user (open to create a user with registered role).",
-                "operationId": "api.createUser",
                 "responses": {
-                    "200": {
-                        "description": "User details",
+                    "201": {
+                        "description": "Event cloned successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponseCreateUUID"
+                            "$ref": "#/definitions/models.SuccessResponseCreate"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1183,6 +1320,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1192,36 +1335,94 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/events/{id}/prices": {
             "get": {
+                "description": "Retrieve the price of each ticket type for the event, taking per-event overrides into account.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get ticket prices of an event.",
+                "operationId": "api.getEventTicketPrices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket prices of the event",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventTicketPricesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a user, including its details and roles.",
+                "description": "Override prices of given ticket types for the event. Types not listed keep their current price.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "events"
                 ],
-                "summary": "Get a user by ID (admin only).",
-                "operationId": "api.getUsersByID",
+                "summary": "Set ticket prices of an event (admin only).",
+                "operationId": "api.setEventTicketPrices",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Prices per ticket type",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetEventTicketPricesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User details",
+                        "description": "Prices updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "403": {
@@ -1236,6 +1437,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1243,31 +1450,43 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/events/{id}/prices/{type}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user details (only owner/admin).",
+                "description": "Remove the price override, so the ticket type falls back to the discounted base price.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "users"
+                    "events"
                 ],
-                "summary": "Update user.",
-                "operationId": "api.updateUser",
+                "summary": "Remove ticket price override of an event (admin only).",
+                "operationId": "api.deleteEventTicketPrice",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
+                        "description": "Price override removed successfully",
                         "schema": {
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
@@ -1291,32 +1510,56 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/events/{id}/reprice": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes user from the database.",
+                "description": "Set the base price of the event and optional price overrides of ticket types, then reprice RESERVED tickets accordingly. SOLD tickets keep the price they were paid for.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "users"
+                    "events"
                 ],
-                "summary": "Delete user (admin/owner only).",
+                "summary": "Reprice an event (admin only).",
+                "operationId": "api.repriceEvent",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New base price and ticket type overrides",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RepriceEventRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User details",
+                        "description": "Event repriced successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.RepriceEventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "403": {
@@ -1331,6 +1574,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1339,305 +1588,5709 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.CreateEventRequest": {
-            "type": "object",
-            "properties": {
-                "available_tickets": {
-                    "type": "integer",
-                    "example": 20000
-                },
-                "date": {
-                    "type": "string",
-                    "example": "2024-12-31T20:00:00Z"
-                },
-                "location": {
-                    "$ref": "#/definitions/models.CreateLocationRequest"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Champions League Final"
-                },
-                "price": {
-                    "type": "number",
-                    "example": 99.99
-                }
-            }
-        },
-        "models.CreateLocationRequest": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string",
-                    "example": "123 Main St"
-                },
-                "capacity": {
-                    "type": "integer",
-                    "example": 50000
-                },
-                "country": {
-                    "type": "string",
-                    "example": "USA"
-                },
-                "stadium": {
-                    "type": "string",
-                    "example": "National Stadium"
-                }
-            }
         },
-        "models.CreateReservationPayload": {
-            "type": "object",
-            "properties": {
-                "event_id": {
-                    "type": "integer",
-                    "example": 101
-                },
-                "tickets": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "type": {
-                                "type": "string",
-                                "example": "STANDARD"
-                            }
+        "/events/{id}/sections": {
+            "get": {
+                "description": "Retrieve the seating sections tickets of the event can be reserved in, ordered by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get sections of an event.",
+                "operationId": "api.getEventSections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sections of the event",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventSectionsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the seating sections of the event with the given list. An empty list removes all sections, after which tickets can only be reserved without a section. Tickets already reserved keep their section.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Set sections of an event (admin only).",
+                "operationId": "api.setEventSections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sections of the event",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetEventSectionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sections updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/similar": {
+            "get": {
+                "description": "Retrieve upcoming events held at the same venue or in the same country, excluding sold-out ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get events similar to given event.",
+                "operationId": "api.getSimilarEvents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events (default 5, max 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of returned dates, e.g. America/New_York (default UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of similar events",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "description": "Retrieve a list of all locations.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get all locations.",
+                "operationId": "api.getLocations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of locations",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of locations to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of locations",
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Parse the payload and create a new location with provided dataset.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Create a new location (admin only).",
+                "operationId": "api.createLocation",
+                "parameters": [
+                    {
+                        "description": "Payload to create a location",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponseCreate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Repoint events of the merged locations to the kept one, then delete the merged locations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Merge duplicate locations (admin only).",
+                "operationId": "api.mergeLocations",
+                "parameters": [
+                    {
+                        "description": "Kept and merged location IDs",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeLocationsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Locations merged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeLocationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve each location with its event count, tickets sold and gross revenue of sold tickets. Locations without activity are reported with zeros.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get sales statistics per location (admin only).",
+                "operationId": "api.getLocationsStats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only reservations created at or after (YYYY-MM-DD HH:MM or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations created before (YYYY-MM-DD HH:MM or RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statistics per location",
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationsStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}": {
+            "get": {
+                "description": "Retrieve a single location by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Retrieve a location.",
+                "operationId": "api.getLocationByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time of the cached copy, answered with 304 if unchanged",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location details",
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update location details based on the provided payload.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Update an existing location (admin only).",
+                "operationId": "api.updateLocation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload to update a location",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a location by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Delete an existing location (admin only).",
+                "operationId": "api.deleteLocation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponseCreate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/conflicts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve events at the location, whose available tickets along with issued tickets that were not cancelled exceed its current capacity, e.g. after the capacity was reduced. Empty if the location is consistent.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get capacity conflicts of a location (admin only).",
+                "operationId": "api.getLocationConflicts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events exceeding the capacity",
+                        "schema": {
+                            "$ref": "#/definitions/models.CapacityConflictsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/events": {
+            "get": {
+                "description": "Retrieve the schedule of a location, ordered by date. Optionally only upcoming events.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get events at a location.",
+                "operationId": "api.getLocationEvents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only events that have not taken place yet",
+                        "name": "upcoming",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of returned dates, e.g. America/New_York (default UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of events at the location",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Pass username and password to authenticate and get a JWT token. Users with two-factor authentication enabled get a challenge instead, to be answered with a code at /auth/2fa/verify.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login to the API.",
+                "operationId": "api.login",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully logged in",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Two-factor code required",
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invalidate currently used JWT token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout from the API (admin or registered user)",
+                "operationId": "api.logout",
+                "responses": {
+                    "200": {
+                        "description": "Successfully logged out",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promo-codes/{code}/validate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check whether the promo code can be applied, returning its percent off and the discounted base price of the event. Invalid codes return the reason (not_found, expired, exhausted). The code is not used up.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promo-codes"
+                ],
+                "summary": "Validate a promo code.",
+                "operationId": "api.validatePromoCode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promo code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Validation result",
+                        "schema": {
+                            "$ref": "#/definitions/models.PromoCodeValidationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of all reservations, including their details and tickets they reserve. When sorted by created_at, full pages carry a cursor of the next one, which stays consistent under concurrent inserts, unlike the offset.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List all reservations (admin or staff).",
+                "operationId": "api.getReservations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by reservation status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by reservations having a ticket in this status",
+                        "name": "ticket_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by username of the buyer; * matches any characters, case-insensitively",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations created at or after (YYYY-MM-DD HH:MM or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations created before (YYYY-MM-DD HH:MM or RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by created_at, total_tickets or event_date",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order, asc or desc (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of reservations",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of reservations to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, taken from next_cursor (requires sorting by created_at)",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the tickets of each reservation (default true)",
+                        "name": "include_tickets",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of reservations",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Parse provided payload and create reservation and tickets within the database. Each ticket entry may specify a quantity (defaults to 1).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Create a reservation (owner/admin only).",
+                "operationId": "api.createReservation",
+                "parameters": [
+                    {
+                        "description": "Payload to create a reservation",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateReservationPayload"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and price the reservation without creating it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create the reservation even if the user already holds one for the event",
+                        "name": "allow_duplicate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reserve before sales of the event start (admin only)",
+                        "name": "ignore_sale_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reserve after online sales of the event closed (admin only)",
+                        "name": "ignore_cutoff",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price preview (dry run)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationQuoteResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Reservation created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.DuplicateReservationResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/created-by-me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve reservations the current user made, either on behalf of other users or through impersonation, along with details and tickets they reserve, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List reservations created by currently logged in admin or staff.",
+                "operationId": "api.getReservationsCreatedByCurrentUser",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of reservations",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of reservations to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the tickets of each reservation (default true)",
+                        "name": "include_tickets",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of reservations created by the user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/expiring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve pending reservations, whose hold expires within the given number of minutes, soonest first. Already expired holds awaiting the sweeper are included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List reservations with expiring holds (admin/staff only).",
+                "operationId": "api.getExpiringReservations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Minutes ahead to look (defaults to the configured window)",
+                        "name": "within",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the tickets of each reservation (default true)",
+                        "name": "include_tickets",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of expiring reservations",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream reservations matching the filters as CSV, one row per reservation. Accepts the same filters and sorting as the reservation list, without pagination.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Export reservations as CSV (admin only).",
+                "operationId": "api.exportReservations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by reservation status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by reservations having a ticket in this status",
+                        "name": "ticket_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by username of the buyer; * matches any characters, case-insensitively",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations created at or after (YYYY-MM-DD HH:MM or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations created before (YYYY-MM-DD HH:MM or RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by created_at, total_tickets or event_date",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order, asc or desc (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/for/{userId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a reservation owned by the given active user, e.g. for a walk-up customer at the box office, recording the staff member who made it. Accepts the same payload and options as creating a reservation for oneself.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Create a reservation for another user (admin/staff only).",
+                "operationId": "api.createReservationForUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the user owning the reservation",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload to create a reservation",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateReservationPayload"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and price the reservation without creating it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create the reservation even if the user already holds one for the event",
+                        "name": "allow_duplicate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reserve before sales of the event start (admin only)",
+                        "name": "ignore_sale_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reserve after online sales of the event closed (admin only)",
+                        "name": "ignore_cutoff",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price preview (dry run)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationQuoteResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Reservation created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.DuplicateReservationResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/user": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of current user's reservations along with details and tickets they reserve, ordered by the event date. Optionally only those for past or upcoming events.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List user reservations for currently logged in user.",
+                "operationId": "api.getReservationsForCurrentUser",
+                "parameters": [
+                    {
+                        "enum": [
+                            "past",
+                            "upcoming",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "all",
+                        "description": "Filter by the event date relative to now",
+                        "name": "when",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the tickets of each reservation (default true)",
+                        "name": "include_tickets",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of reservations for the user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/user/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve an iCalendar (RFC 5545) feed of upcoming events the current user holds active reservations for.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Calendar of events reserved by the current user.",
+                "operationId": "api.getCurrentUserCalendar",
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/user/calendar/token": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a signed token, which lets calendar applications subscribe to the feed of the current user without sending the Authorization header. The token stays valid until it is rotated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Calendar feed token of the current user.",
+                "operationId": "api.getCalendarFeedToken",
+                "responses": {
+                    "200": {
+                        "description": "Calendar feed token",
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarFeedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a new calendar feed token, revoking the previous ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Rotate the calendar feed token of the current user.",
+                "operationId": "api.rotateCalendarFeedToken",
+                "responses": {
+                    "200": {
+                        "description": "New calendar feed token",
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarFeedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/user/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve counts of current user's reservations by status, along with the number of tickets held in reservations that were not cancelled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Summary of reservations for currently logged in user.",
+                "operationId": "api.getReservationSummaryForCurrentUser",
+                "responses": {
+                    "200": {
+                        "description": "Summary of the user's reservations",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationSummaryResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/user/tickets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of current user's tickets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List user tickets for currently logged in user.",
+                "operationId": "api.getReservationTicketsForCurrentUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of tickets belonging to the user",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserTicketsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of all reservations made by a specific user, including their details and tickets they reserve.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List user reservations (admin/owner only)",
+                "operationId": "api.getReservationsForUserByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the tickets of each reservation (default true)",
+                        "name": "include_tickets",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of reservations for the user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/user/{id}/tickets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of user's tickets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List user tickets (admin/owner only).",
+                "operationId": "api.getReservationTicketsForUserByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of tickets belonging to the user",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserTicketsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single reservation, including their details and tickets they reserve.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get a reservation by ID (admin/owner only).",
+                "operationId": "api.getReservationsByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include cancelled tickets",
+                        "name": "include_cancelled",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation details",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a single reservation along with its tickets from the database.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Delete a reservation by ID (admin only).",
+                "operationId": "api.deleteReservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set statuses of reservation and its tickets to cancelled, returning the seats of reserved and sold tickets to the event. Paid tickets are refunded in full, unless an admin provides a smaller refund_amount, e.g. to deduct a cancellation fee.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Cancel a reservation (owner/admin only).",
+                "operationId": "api.cancelReservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Partial refund (admin only)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CancelReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation canceled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.CancelReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/cancel-preview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve what cancelling the reservation would do, without changing anything: the number of tickets cancelled, the seats returned to the event and the refund of the paid amount. Admins may still refund less when cancelling.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Preview cancelling a reservation (owner/admin only).",
+                "operationId": "api.getCancelPreview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Effects of the cancellation",
+                        "schema": {
+                            "$ref": "#/definitions/models.CancelPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the reservation to confirmed and its tickets to sold, e.g. when the payment notification was lost. The admin confirming it is recorded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Confirm a pending reservation (admin only).",
+                "operationId": "api.confirmReservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation confirmed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/notes": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set or clear (with null) the internal notes attached to a reservation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Update reservation notes (admin only).",
+                "operationId": "api.updateReservationNotes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notes to set",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateReservationNotesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notes updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the payments recorded for the reservation with their status and amount, oldest first. Unpaid reservations have none.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List payments of a reservation (owner/admin only).",
+                "operationId": "api.getReservationPayments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payments of the reservation",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationPaymentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/reissue": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the tickets of a reservation with provided set, priced anew. Available tickets of the event are adjusted by the difference in count. Cancelled reservations and those with checked in tickets are refused.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Reissue tickets of a reservation (admin only).",
+                "operationId": "api.reissueReservationTickets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Corrected tickets",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReissueTicketsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reissued tickets",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationTicketsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/resend-confirmation": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the confirmation message of a confirmed reservation to its buyer again. Limited to one send per reservation within the configured interval.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Resend a reservation confirmation (owner/admin only).",
+                "operationId": "api.resendConfirmation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Confirmation sent",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/share": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a signed, time-limited token giving read-only access to a redacted view of the reservation, e.g. for a companion. Anyone holding the link can see the event, status and ticket types, along with the first name of the buyer.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Share a reservation (owner only).",
+                "operationId": "api.getReservationShareLink",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shareable link",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShareReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/tickets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all tickets associated with a specific reservation by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List tickets attributed to given reservation (owner/admin only).",
+                "operationId": "api.getReservationTicketsByID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include cancelled tickets",
+                        "name": "include_cancelled",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of tickets for the reservation",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationTicketsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of roles with their descriptions, granted permissions and number of users.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "List all roles (admin only).",
+                "operationId": "api.getRoles",
+                "responses": {
+                    "200": {
+                        "description": "List of roles",
+                        "schema": {
+                            "$ref": "#/definitions/models.RolesResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shared/reservation": {
+            "get": {
+                "description": "Retrieve the reservation the share token was signed for, without personal data beyond the first name of the buyer. Expired and invalid tokens are treated as missing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "View a shared reservation.",
+                "operationId": "api.getSharedReservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shared reservation",
+                        "schema": {
+                            "$ref": "#/definitions/models.SharedReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/availability": {
+            "get": {
+                "description": "Retrieve the number of seats available across upcoming events, along with the number of such events. Cached briefly.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get seat availability.",
+                "operationId": "api.getAvailabilityStats",
+                "responses": {
+                    "200": {
+                        "description": "Seat availability",
+                        "schema": {
+                            "$ref": "#/definitions/models.AvailabilityStatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/sales-timeseries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the number of sold (including used) tickets and their revenue per day or week, grouped by the reservation creation time in UTC. Buckets without sales are reported with zeros, so the series is continuous. The range defaults to the last 30 days and may span at most 366 buckets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get sales time series (admin only).",
+                "operationId": "api.getSalesTimeseries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the range (YYYY-MM-DD HH:MM or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, exclusive (YYYY-MM-DD HH:MM or RFC3339, default now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Bucket length",
+                        "name": "bucket",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sales per bucket",
+                        "schema": {
+                            "$ref": "#/definitions/models.SalesTimeseriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket-statuses": {
+            "get": {
+                "description": "Retrieve the statuses tickets can be in, for rendering filters and labels.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "List ticket statuses.",
+                "operationId": "api.getTicketStatuses",
+                "responses": {
+                    "200": {
+                        "description": "List of ticket statuses",
+                        "schema": {
+                            "$ref": "#/definitions/models.StatusesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket-types/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve each ticket type with the number of sold (including used) tickets, their revenue and average price. Types without sales are reported with zeros.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ticket-types"
+                ],
+                "summary": "Get sales statistics per ticket type (admin only).",
+                "operationId": "api.getTicketTypesStats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only reservations created at or after (YYYY-MM-DD HH:MM or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations created before (YYYY-MM-DD HH:MM or RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tickets of the event",
+                        "name": "event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statistics per ticket type",
+                        "schema": {
+                            "$ref": "#/definitions/models.TicketTypesStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket-types/{id}/preview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compute the price of a ticket type against the base price of an event, as the discounted base price, along with the savings. Per-event price overrides are not applied.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ticket-types"
+                ],
+                "summary": "Preview the discount of a ticket type (admin only).",
+                "operationId": "api.getTicketTypePreview",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price preview",
+                        "schema": {
+                            "$ref": "#/definitions/models.TicketTypePreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/verify-batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Verify tokens scanned at the gate, reporting for each one whether it admits entry, along with the ticket and its status. Only SOLD tickets are valid; tickets are not checked in. Malformed or forged tokens are rejected without a lookup. The number of tokens per batch is capped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Verify ticket tokens in a batch (admin or staff).",
+                "operationId": "api.verifyTicketsBatch",
+                "parameters": [
+                    {
+                        "description": "Scanned ticket tokens",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VerifyTicketsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Outcome per token, in the same order",
+                        "schema": {
+                            "$ref": "#/definitions/models.TicketVerificationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{id}/check-in": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Transition a SOLD ticket to USED, recording who checked it in and when. Tickets in any other status are rejected, preventing re-entry.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Check in a ticket (admin or staff).",
+                "operationId": "api.checkInTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checked in ticket",
+                        "schema": {
+                            "$ref": "#/definitions/models.TicketCheckInResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{id}/pricing": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve how the price of the ticket came about: the base price of the event, the discount of the ticket type and the price set for the type on the event, which takes precedence over the discount. The breakdown reflects the current prices, while price is what the ticket was charged at.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Get the pricing of a ticket (owner or admin).",
+                "operationId": "api.getTicketPricing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pricing of the ticket",
+                        "schema": {
+                            "$ref": "#/definitions/models.TicketPricingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{id}/reservation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the reservation the ticket belongs to, including its details and tickets, e.g. for gate staff after scanning a ticket.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Get the reservation of a ticket (owner, admin or staff).",
+                "operationId": "api.getTicketReservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include cancelled tickets",
+                        "name": "include_cancelled",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation of the ticket",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the status of the ticket, e.g. to restore a mistakenly cancelled ticket to SOLD. Cancelling a ticket returns its seat to the event and removes it from the reservation's total, restoring a cancelled one takes a seat again. Tickets of cancelled reservations cannot be restored (409). Moving a used ticket back to SOLD clears its check-in. Transitions going backwards, such as SOLD to RESERVED, are rejected with 422.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Update the status of a ticket (admin only).",
+                "operationId": "api.updateTicketStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateTicketStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated ticket",
+                        "schema": {
+                            "$ref": "#/definitions/models.TicketStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{id}/token": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the signed token identifying the ticket, meant to be encoded in its QR code and verified at the gate.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Get the token of a ticket (owner, admin or staff).",
+                "operationId": "api.getTicketToken",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token of the ticket",
+                        "schema": {
+                            "$ref": "#/definitions/models.TicketTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of all users, including their details and roles. Staff get the reduced view (models.UserSummariesResponse), without email and last login.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List all users (admin/staff only).",
+                "operationId": "api.getUsers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of users",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "$ref": "#/definitions/models.UsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a user with registered role based on provided payload. Request to create an admin user made by user without already admin status will be rejected.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user (open to create a user with registered role).",
+                "operationId": "api.createUser",
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponseCreateUUID"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/bulk": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create users from an array of payloads, validating each one separately. Returns the ID of every created user, or the reason it was rejected, by its index in the payload. With atomic set, a single failure rolls back the whole import.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Import users in bulk (admin only).",
+                "operationId": "api.bulkCreateUsers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole import if any user fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Users to create",
+                        "name": "users",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CreateUserRequest"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result of every user",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkCreateUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Atomic import rolled back",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkCreateUsersResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/by-username/{username}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a user, including its details and roles, by the username. Usernames are matched exactly, as on login.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user by username (admin only).",
+                "operationId": "api.getUserByUsername",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download a single JSON document with the profile, reservations and tickets of the current user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export data of the currently logged in user.",
+                "operationId": "api.exportCurrentUserData",
+                "responses": {
+                    "200": {
+                        "description": "User data export",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserExportResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a user, including its details and roles. Staff get the reduced view (models.UserSummaryResponse), without email and last login.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user by ID (admin/staff only).",
+                "operationId": "api.getUsersByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user details (only owner/admin). The role is changed with POST /users/{id}/role, so the change is recorded in the role history.",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user.",
+                "operationId": "api.updateUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes user from the database. With anonymize=true (admin only) personal data is scrubbed instead, keeping the reservations intact, and the sessions of the user are revoked.",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user (admin/owner only).",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Anonymize instead of deleting (admin only)",
+                        "name": "anonymize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/cancel-reservations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel all pending and confirmed reservations of the user in one transaction, e.g. when closing an account or handling a dispute. Their tickets are cancelled and the seats returned to the events; paid reservations are refunded in full.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Cancel all reservations of a user (admin only).",
+                "operationId": "api.cancelUserReservations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason of the refunds",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CancelUserReservationsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservations cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/models.CancelUserReservationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the role of the user and record the change in the role history. With revoke_sessions=true active sessions of the user are revoked, so the new permissions take effect immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Change the role of a user (admin only).",
+                "operationId": "api.changeUserRole",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Revoke active sessions of the user",
+                        "name": "revoke_sessions",
+                        "in": "query"
+                    },
+                    {
+                        "description": "New role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangeUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated user",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the version and commit the service was built from, the Go version and the version of the connected PostgreSQL server. The database version is left out if the server does not answer in time.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the deployed versions (admin only).",
+                "operationId": "api.getVersion",
+                "responses": {
+                    "200": {
+                        "description": "Versions",
+                        "schema": {
+                            "$ref": "#/definitions/models.VersionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AttendeeResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "surname": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AttendeeTicketsResponse"
+                    }
+                },
+                "total_tickets": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "models.AttendeeTicketsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "type": {
+                    "type": "string",
+                    "example": "STANDARD"
+                }
+            }
+        },
+        "models.AvailabilityStatsResponse": {
+            "type": "object",
+            "properties": {
+                "available_seats": {
+                    "type": "integer",
+                    "example": 125000
+                },
+                "events": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.BatchRequestItem": {
+            "type": "object",
+            "properties": {
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/reservations/user/summary"
+                }
+            }
+        },
+        "models.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "responses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchResponseItem"
+                    }
+                }
+            }
+        },
+        "models.BatchResponseItem": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "object"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/reservations/user/summary"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.BulkCreateUsersResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkUserResult"
+                    }
+                }
+            }
+        },
+        "models.BulkUserResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Username 'johndoe' is already taken."
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "models.CalendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.0.5d41402abc4b2a76b9719d911017c592"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/calendar/3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.0.5d41402abc4b2a76b9719d911017c592.ics"
+                }
+            }
+        },
+        "models.CancelPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "refund_amount": {
+                    "type": "number",
+                    "example": 150
+                },
+                "reservation_id": {
+                    "type": "string",
+                    "example": "0b9f8e7d-6c5b-4a39-8281-7f6e5d4c3b2a"
+                },
+                "seats_released": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "tickets_cancelled": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.CancelReservationRequest": {
+            "type": "object",
+            "properties": {
+                "refund_amount": {
+                    "type": "number",
+                    "example": 40
+                },
+                "refund_reason": {
+                    "type": "string",
+                    "example": "Cancellation fee deducted."
+                }
+            }
+        },
+        "models.CancelReservationResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Reservation canceled successfully."
+                },
+                "refund": {
+                    "$ref": "#/definitions/models.RefundResponse"
+                }
+            }
+        },
+        "models.CancelUserReservationsRequest": {
+            "type": "object",
+            "properties": {
+                "refund_reason": {
+                    "type": "string",
+                    "example": "Account closed."
+                }
+            }
+        },
+        "models.CancelUserReservationsResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Reservations cancelled successfully."
+                },
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RefundResponse"
+                    }
+                }
+            }
+        },
+        "models.CapacityConflictResponse": {
+            "type": "object",
+            "properties": {
+                "available_tickets": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-01T20:00:00Z"
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "excess": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Concert"
+                },
+                "seats": {
+                    "type": "integer",
+                    "example": 50300
+                },
+                "tickets_issued": {
+                    "type": "integer",
+                    "example": 49800
+                }
+            }
+        },
+        "models.CapacityConflictsResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CapacityConflictResponse"
+                    }
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ChangeUserRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role_name": {
+                    "type": "string",
+                    "example": "staff"
+                }
+            }
+        },
+        "models.CloneEventRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-12-31T20:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Champions League Final 2025"
+                }
+            }
+        },
+        "models.ConfigResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "default_page_size": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "list_envelope": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_page_size": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "max_tickets_per_reservation": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "registration_open": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "reservation_cutoff_hours": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "reservation_hold_minutes": {
+                    "type": "integer",
+                    "example": 15
+                }
+            }
+        },
+        "models.CountriesResponse": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CountryResponse"
+                    }
+                }
+            }
+        },
+        "models.CountryResponse": {
+            "type": "object",
+            "properties": {
+                "alpha3": {
+                    "type": "string",
+                    "example": "GBR"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "GB"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "United Kingdom"
+                }
+            }
+        },
+        "models.CreateEventRequest": {
+            "type": "object",
+            "properties": {
+                "available_tickets": {
+                    "type": "integer",
+                    "example": 20000
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-12-31T20:00:00Z"
+                },
+                "location": {
+                    "$ref": "#/definitions/models.CreateLocationRequest"
+                },
+                "max_reservations_per_user": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_tickets_per_reservation": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Champions League Final"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 99.99
+                },
+                "reservation_cutoff_hours": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sale_starts_at": {
+                    "type": "string",
+                    "example": "2024-10-01T10:00:00Z"
+                },
+                "visibility": {
+                    "type": "string",
+                    "example": "UNLISTED"
+                }
+            }
+        },
+        "models.CreateEventSeriesRequest": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/models.CreateEventRequest"
+                },
+                "recurrence": {
+                    "$ref": "#/definitions/models.EventRecurrenceRequest"
+                }
+            }
+        },
+        "models.CreateEventSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "event_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12,
+                        13,
+                        14
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Event series created successfully."
+                },
+                "series_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CreateLocationRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "123 Main St"
+                },
+                "capacity": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "country": {
+                    "type": "string",
+                    "example": "US"
+                },
+                "stadium": {
+                    "type": "string",
+                    "example": "National Stadium"
+                }
+            }
+        },
+        "models.CreateReservationPayload": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "integer",
+                    "example": 101
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReservationTicketRequest"
+                    }
+                }
+            }
+        },
+        "models.CreateReservationResponse": {
+            "type": "object",
+            "properties": {
+                "hold_expires_at": {
+                    "type": "string",
+                    "example": "2024-12-01T15:45:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Reservation created successfully."
+                },
+                "status": {
+                    "type": "string",
+                    "example": "PENDING"
+                }
+            }
+        },
+        "models.CreateUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "johndoe@example.com"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "strongpassword"
+                },
+                "role_name": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "surname": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "models.DuplicateReservationResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Active reservation for the event already exists."
+                },
+                "reservation_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-abcd-ef1234567890"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "An error occurred"
+                }
+            }
+        },
+        "models.EventAttendeesResponse": {
+            "type": "object",
+            "properties": {
+                "attendees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AttendeeResponse"
+                    }
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.EventRecurrenceRequest": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "weekly"
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "until": {
+                    "type": "string",
+                    "example": "2025-03-31T20:00:00Z"
+                }
+            }
+        },
+        "models.EventResponse": {
+            "type": "object",
+            "properties": {
+                "available_tickets": {
+                    "type": "integer",
+                    "example": 15000
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-12-31T20:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "location": {
+                    "$ref": "#/definitions/models.LocationResponse"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Champions League Final"
+                },
+                "on_sale": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "price": {
+                    "type": "number",
+                    "example": 99.99
+                },
+                "reservation_cutoff_hours": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sale_starts_at": {
+                    "type": "string",
+                    "example": "2024-10-01T10:00:00Z"
+                },
+                "sales_open": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "champions-league-final-2024-12-31"
+                },
+                "visibility": {
+                    "type": "string",
+                    "example": "PUBLIC"
+                }
+            }
+        },
+        "models.EventSectionsResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "North Stand",
+                        "South Stand"
+                    ]
+                }
+            }
+        },
+        "models.EventSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventResponse"
+                    }
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "WEEKLY"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.EventTicketPriceRequest": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "number",
+                    "example": 59.99
+                },
+                "type": {
+                    "type": "string",
+                    "example": "STUDENT"
+                }
+            }
+        },
+        "models.EventTicketPriceResponse": {
+            "type": "object",
+            "properties": {
+                "override": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "price": {
+                    "type": "number",
+                    "example": 59.99
+                },
+                "type": {
+                    "type": "string",
+                    "example": "STUDENT"
+                }
+            }
+        },
+        "models.EventTicketPricesResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventTicketPriceResponse"
+                    }
+                }
+            }
+        },
+        "models.EventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventResponse"
+                    }
+                }
+            }
+        },
+        "models.ImpersonationResponse": {
+            "type": "object",
+            "properties": {
+                "exp": {
+                    "type": "integer",
+                    "example": 1683649261
+                },
+                "impersonated": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "impersonated_by": {
+                    "type": "string",
+                    "example": "8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "jwt-token-string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.UserUsernameID"
+                }
+            }
+        },
+        "models.LocationResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Wembley Park, London"
+                },
+                "capacity": {
+                    "type": "integer",
+                    "example": 90000
+                },
+                "country": {
+                    "type": "string",
+                    "example": "United Kingdom"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 101
+                },
+                "stadium": {
+                    "type": "string",
+                    "example": "Wembley Stadium"
+                }
+            }
+        },
+        "models.LocationStatsResponse": {
+            "type": "object",
+            "properties": {
+                "event_count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "location": {
+                    "$ref": "#/definitions/models.LocationResponse"
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 124987.5
+                },
+                "tickets_sold": {
+                    "type": "integer",
+                    "example": 1250
+                }
+            }
+        },
+        "models.LocationsResponse": {
+            "type": "object",
+            "properties": {
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LocationResponse"
+                    }
+                }
+            }
+        },
+        "models.LocationsStatsResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LocationStatsResponse"
+                    }
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "securepassword"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "user123"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "exp": {
+                    "type": "integer",
+                    "example": 1683649261
+                },
+                "token": {
+                    "type": "string",
+                    "example": "jwt-token-string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.UserUsernameID"
+                }
+            }
+        },
+        "models.MergeLocationsRequest": {
+            "type": "object",
+            "properties": {
+                "keep_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "merge_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
+                }
+            }
+        },
+        "models.MergeLocationsResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Locations merged successfully."
+                },
+                "repointed_events": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "models.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 150
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "2024-12-01T15:30:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "COMPLETED"
+                }
+            }
+        },
+        "models.PromoCodeValidationResponse": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "type": "number",
+                    "example": 99.99
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SUMMER20"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "discounted_price": {
+                    "type": "number",
+                    "example": 79.99
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "percent_off": {
+                    "type": "number",
+                    "example": 20
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "expired"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.RefundResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 40
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-01T15:30:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Cancellation fee deducted."
+                },
+                "reservation_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "models.ReissueTicketsRequest": {
+            "type": "object",
+            "properties": {
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReservationTicketRequest"
+                    }
+                }
+            }
+        },
+        "models.RepriceEventRequest": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "number",
+                    "example": 99.99
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventTicketPriceRequest"
+                    }
+                }
+            }
+        },
+        "models.RepriceEventResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Event repriced successfully."
+                },
+                "repriced_tickets": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "unchanged_tickets": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "models.ReservationPaymentsResponse": {
+            "type": "object",
+            "properties": {
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentResponse"
+                    }
+                },
+                "reservation_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "models.ReservationQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TicketQuoteResponse"
+                    }
+                },
+                "total": {
+                    "type": "number",
+                    "example": 159.98
+                }
+            }
+        },
+        "models.ReservationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-01T15:30:00Z"
+                },
+                "event": {
+                    "$ref": "#/definitions/models.EventResponse"
+                },
+                "hold_expires_at": {
+                    "type": "string",
+                    "example": "2024-12-01T15:45:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "res123"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Customer called about refund."
+                },
+                "status": {
+                    "type": "string",
+                    "example": "CONFIRMED"
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TicketResponse"
+                    }
+                },
+                "total_tickets": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "user": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "models.ReservationSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "by_status": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "tickets_held": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.ReservationTicketRequest": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "section": {
+                    "type": "string",
+                    "example": "North Stand"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "STANDARD"
+                }
+            }
+        },
+        "models.ReservationTicketsResponse": {
+            "type": "object",
+            "properties": {
+                "reservation_id": {
+                    "type": "string",
+                    "example": "res123"
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TicketResponse"
+                    }
+                },
+                "user": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "models.ReservationsResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string",
+                    "example": "MjAyNC0xMi0wMVQxNTozMDowMFp8cmVzMTIz"
+                },
+                "reservations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReservationResponse"
+                    }
+                }
+            }
+        },
+        "models.RoleResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Full system access and management"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ADMIN"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "VIEW_EVENTS",
+                        "MANAGE_USERS"
+                    ]
+                },
+                "user_count": {
+                    "type": "integer",
+                    "example": 2
+                }
             }
         },
-        "models.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "An error occurred"
-                }
-            }
-        },
-        "models.EventResponse": {
-            "type": "object",
-            "properties": {
-                "available_tickets": {
-                    "type": "integer",
-                    "example": 15000
-                },
-                "date": {
-                    "type": "string",
-                    "example": "2024-12-31T20:00:00Z"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "location": {
-                    "$ref": "#/definitions/models.LocationResponse"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Champions League Final"
-                },
-                "price": {
-                    "type": "number",
-                    "example": 99.99
-                }
-            }
-        },
-        "models.EventsResponse": {
+        "models.RolesResponse": {
             "type": "object",
             "properties": {
-                "events": {
+                "roles": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.EventResponse"
+                        "$ref": "#/definitions/models.RoleResponse"
+                    }
+                }
+            }
+        },
+        "models.SalesBucketResponse": {
+            "type": "object",
+            "properties": {
+                "revenue": {
+                    "type": "number",
+                    "example": 3150
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2025-01-06T00:00:00Z"
+                },
+                "tickets_sold": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.SalesTimeseriesResponse": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string",
+                    "example": "day"
+                },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SalesBucketResponse"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-01-31T00:00:00Z"
+                }
+            }
+        },
+        "models.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-12-02T15:30:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "192.168.0.10"
+                },
+                "issued_at": {
+                    "type": "string",
+                    "example": "2024-12-01T15:30:00Z"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                }
+            }
+        },
+        "models.SessionsResponse": {
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SessionResponse"
+                    }
+                }
+            }
+        },
+        "models.SetEventSectionsRequest": {
+            "type": "object",
+            "properties": {
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "North Stand",
+                        "South Stand"
+                    ]
+                }
+            }
+        },
+        "models.SetEventTicketPricesRequest": {
+            "type": "object",
+            "properties": {
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventTicketPriceRequest"
+                    }
+                }
+            }
+        },
+        "models.ShareReservationResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-12-31T20:00:00Z"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.1735689600.5d41402abc4b2a76b9719d911017c592"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/shared/reservation?token=3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.1735689600.5d41402abc4b2a76b9719d911017c592"
+                }
+            }
+        },
+        "models.SharedReservationResponse": {
+            "type": "object",
+            "properties": {
+                "buyer_name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "event": {
+                    "$ref": "#/definitions/models.EventResponse"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "CONFIRMED"
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SharedTicketResponse"
+                    }
+                },
+                "total_tickets": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.SharedTicketResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "SOLD"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "STANDARD"
+                }
+            }
+        },
+        "models.StatusResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "SOLD"
+                }
+            }
+        },
+        "models.StatusesResponse": {
+            "type": "object",
+            "properties": {
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StatusResponse"
                     }
                 }
             }
         },
-        "models.LocationResponse": {
+        "models.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Operation successful"
+                }
+            }
+        },
+        "models.SuccessResponseCreate": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Object created successfully"
+                }
+            }
+        },
+        "models.SuccessResponseCreateUUID": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Object created successfully"
+                }
+            }
+        },
+        "models.TicketCheckInResponse": {
+            "type": "object",
+            "properties": {
+                "checked_in_at": {
+                    "type": "string",
+                    "example": "2024-12-31T19:15:00Z"
+                },
+                "checked_in_by": {
+                    "type": "string",
+                    "example": "8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "USED"
+                }
+            }
+        },
+        "models.TicketPricingResponse": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "type": "number",
+                    "example": 99.99
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "discount": {
+                    "type": "number",
+                    "example": 0.4
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "event_type_price": {
+                    "type": "number",
+                    "example": 55
+                },
+                "price": {
+                    "type": "number",
+                    "example": 55
+                },
+                "ticket_id": {
+                    "type": "string",
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "STUDENT"
+                }
+            }
+        },
+        "models.TicketQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "number",
+                    "example": 59.99
+                },
+                "type": {
+                    "type": "string",
+                    "example": "STUDENT"
+                }
+            }
+        },
+        "models.TicketResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "abc123"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 150
+                },
+                "section": {
+                    "type": "string",
+                    "example": "North Stand"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "available"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "STANDARD"
+                }
+            }
+        },
+        "models.TicketStatusResponse": {
             "type": "object",
             "properties": {
-                "address": {
+                "id": {
                     "type": "string",
-                    "example": "Wembley Park, London"
-                },
-                "capacity": {
-                    "type": "integer",
-                    "example": 90000
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"
                 },
-                "country": {
+                "previous_status": {
                     "type": "string",
-                    "example": "England"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 101
+                    "example": "CANCELLED"
                 },
-                "stadium": {
+                "status": {
                     "type": "string",
-                    "example": "Wembley Stadium"
+                    "example": "SOLD"
                 }
             }
         },
-        "models.LocationsResponse": {
+        "models.TicketTokenResponse": {
             "type": "object",
             "properties": {
-                "locations": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.LocationResponse"
-                    }
+                "ticket_id": {
+                    "type": "string",
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.5d41402abc4b2a76"
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.TicketTypePreviewResponse": {
             "type": "object",
             "properties": {
-                "password": {
+                "base_price": {
+                    "type": "number",
+                    "example": 99.99
+                },
+                "currency": {
                     "type": "string",
-                    "example": "securepassword"
+                    "example": "USD"
                 },
-                "username": {
+                "discount": {
+                    "type": "number",
+                    "example": 0.4
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price": {
+                    "type": "number",
+                    "example": 59.99
+                },
+                "savings": {
+                    "type": "number",
+                    "example": 40
+                },
+                "type": {
                     "type": "string",
-                    "example": "user123"
+                    "example": "STUDENT"
+                },
+                "type_id": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "models.LoginResponse": {
+        "models.TicketTypeStatsResponse": {
             "type": "object",
             "properties": {
-                "exp": {
+                "average_price": {
+                    "type": "number",
+                    "example": 79.99
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 25596.8
+                },
+                "tickets_sold": {
                     "type": "integer",
-                    "example": 1683649261
+                    "example": 320
                 },
-                "token": {
+                "type": {
                     "type": "string",
-                    "example": "jwt-token-string"
+                    "example": "STUDENT"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.UserUsernameID"
+                "type_id": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "models.ReservationResponse": {
+        "models.TicketTypesStatsResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-12-01T15:30:00Z"
-                },
-                "event": {
-                    "$ref": "#/definitions/models.EventResponse"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "res123"
-                },
-                "status": {
+                "currency": {
                     "type": "string",
-                    "example": "CONFIRMED"
+                    "example": "USD"
                 },
-                "tickets": {
+                "types": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.TicketResponse"
+                        "$ref": "#/definitions/models.TicketTypeStatsResponse"
                     }
-                },
-                "total_tickets": {
-                    "type": "integer",
-                    "example": 5
-                },
-                "user": {
-                    "type": "string",
-                    "example": "johndoe"
                 }
             }
         },
-        "models.ReservationTicketsResponse": {
+        "models.TicketVerificationResponse": {
             "type": "object",
             "properties": {
-                "reservation_id": {
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
                     "type": "string",
-                    "example": "res123"
+                    "example": "Ticket has already been checked in."
                 },
-                "tickets": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.TicketResponse"
-                    }
+                "status": {
+                    "type": "string",
+                    "example": "USED"
                 },
-                "user": {
+                "ticket_id": {
                     "type": "string",
-                    "example": "johndoe"
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.5d41402abc4b2a76"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
-        "models.ReservationsResponse": {
+        "models.TicketVerificationsResponse": {
             "type": "object",
             "properties": {
-                "reservations": {
+                "results": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.ReservationResponse"
+                        "$ref": "#/definitions/models.TicketVerificationResponse"
                     }
                 }
             }
         },
-        "models.SuccessResponse": {
+        "models.TwoFactorChallengeResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "challenge": {
                     "type": "string",
-                    "example": "Operation successful"
+                    "example": "8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f.1735689600.5d41402abc4b2a76"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-12-31T20:05:00Z"
+                },
+                "two_factor_required": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.SuccessResponseCreate": {
+        "models.TwoFactorCodeRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "message": {
+                "code": {
                     "type": "string",
-                    "example": "Object created successfully"
+                    "example": "123456"
                 }
             }
         },
-        "models.SuccessResponseCreateUUID": {
+        "models.TwoFactorEnrollResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "otpauth_url": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "otpauth://totp/Event%20Reservation%20API:admin?issuer=Event+Reservation+API\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
                 },
-                "message": {
+                "secret": {
                     "type": "string",
-                    "example": "Object created successfully"
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
                 }
             }
         },
-        "models.TicketResponse": {
+        "models.TwoFactorVerifyRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "abc123"
-                },
-                "price": {
-                    "type": "number",
-                    "example": 150
-                },
-                "status": {
+                "challenge": {
                     "type": "string",
-                    "example": "available"
+                    "example": "8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f.1735689600.5d41402abc4b2a76"
                 },
-                "type": {
+                "code": {
                     "type": "string",
-                    "example": "STANDARD"
+                    "example": "123456"
                 }
             }
         },
@@ -1655,6 +7308,14 @@ const docTemplate = `{
                 "location": {
                     "$ref": "#/definitions/models.UpdateLocationRequest"
                 },
+                "max_reservations_per_user": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_tickets_per_reservation": {
+                    "type": "integer",
+                    "example": 50
+                },
                 "name": {
                     "type": "string",
                     "example": "Christmas Special"
@@ -1662,6 +7323,31 @@ const docTemplate = `{
                 "price": {
                     "type": "number",
                     "example": 49.99
+                },
+                "reservation_cutoff_hours": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sale_starts_at": {
+                    "type": "string",
+                    "example": "2024-10-01T10:00:00Z"
+                },
+                "visibility": {
+                    "type": "string",
+                    "example": "PRIVATE"
+                }
+            }
+        },
+        "models.UpdateEventResponse": {
+            "type": "object",
+            "properties": {
+                "affected_reservations": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Event updated successfully."
                 }
             }
         },
@@ -1686,6 +7372,48 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateReservationNotesRequest": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Customer called about refund."
+                }
+            }
+        },
+        "models.UpdateTicketStatusRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "SOLD"
+                }
+            }
+        },
+        "models.UserExportResponse": {
+            "type": "object",
+            "properties": {
+                "exported_at": {
+                    "type": "string",
+                    "example": "2024-12-01T15:30:00Z"
+                },
+                "reservations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReservationResponse"
+                    }
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserTicketResponse"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/models.UserResponse"
+                }
+            }
+        },
         "models.UserResponse": {
             "type": "object",
             "properties": {
@@ -1730,6 +7458,10 @@ const docTemplate = `{
         "models.UserTicketResponse": {
             "type": "object",
             "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
                 "event": {
                     "$ref": "#/definitions/models.EventResponse"
                 },
@@ -1745,6 +7477,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "res123"
                 },
+                "section": {
+                    "type": "string",
+                    "example": "North Stand"
+                },
                 "status": {
                     "type": "string",
                     "example": "SOLD"
@@ -1793,6 +7529,89 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "models.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Updated event would be invalid."
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Date must be in the future."
+                    ]
+                }
+            }
+        },
+        "models.VerifyTicketsRequest": {
+            "type": "object",
+            "properties": {
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.5d41402abc4b2a76"
+                    ]
+                }
+            }
+        },
+        "models.VersionResponse": {
+            "type": "object",
+            "properties": {
+                "commit": {
+                    "type": "string",
+                    "example": "3f9c2a1"
+                },
+                "database_version": {
+                    "type": "string",
+                    "example": "PostgreSQL 16.3 on x86_64-pc-linux-gnu"
+                },
+                "go_version": {
+                    "type": "string",
+                    "example": "go1.22.5"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.4.0"
+                }
+            }
+        },
+        "models.WhoAmIResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "impersonated_by": {
+                    "type": "string",
+                    "example": "8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "CREATE_RESERVATION"
+                    ]
+                },
+                "role": {
+                    "type": "string",
+                    "example": "REGISTERED"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
         }
     },
     "securityDefinitions": {
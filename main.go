@@ -9,16 +9,28 @@ import (
 	"os"
 	"time"
 
-	"github.com/gorilla/handlers"
+	gorillaHandlers "github.com/gorilla/handlers"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"event-reservation-api/db"
 	"event-reservation-api/middlewares"
 	"event-reservation-api/routes"
+	"event-reservation-api/routes/handlers"
+)
+
+// Build details, set with -ldflags "-X main.version=... -X main.commit=...".
+var (
+	version = "dev"
+	commit  = "unknown"
 )
 
 // Populate the database with initial data if the populate flag is set.
 func populateDatabase(populateFlag *bool, pool *pgxpool.Pool) {
+	// roles are required regardless of the flag
+	if err := db.SeedRoles(pool); err != nil {
+		log.Fatalf("Failed to seed the roles: %v\n", err)
+	}
+
 	if *populateFlag {
 		// if the flag is provided...
 		fmt.Println("Populating the database with fake data and adding admin user...")
@@ -53,6 +65,19 @@ func main() {
 	// Initialize the JWT secret.
 	jwtSecret := middlewares.InitJWTSecret()
 
+	// Read the handler configuration.
+	handlers.InitConfig()
+
+	// Load the reservation confirmation template, failing fast if it is invalid.
+	if err := handlers.InitConfirmationTemplate(); err != nil {
+		log.Fatalf("Invalid confirmation template: %v\n", err)
+	}
+
+	// Load the usernames reserved for admins.
+	if err := handlers.InitReservedUsernames(); err != nil {
+		log.Fatalf("Invalid reserved usernames: %v\n", err)
+	}
+
 	// Parse the command line flags.
 	populateFlag := flag.Bool("populate", false, "Populate the database with initial data.")
 	flag.Parse()
@@ -67,8 +92,14 @@ func main() {
 	// Populate the database if the flag is set.
 	populateDatabase(populateFlag, pool)
 
+	// Revoked tokens are kept in Postgres.
+	blacklist := middlewares.NewPostgresBlacklist(pool)
+
 	// Set up the API routes.
-	r := routes.SetupRoutes(pool, jwtSecret)
+	r := routes.SetupRoutes(
+		pool, blacklist, jwtSecret,
+		handlers.BuildInfo{Version: version, Commit: commit},
+	)
 
 	// Start the server (defaults to port 8080).
 	port := os.Getenv("API_PORT")
@@ -77,16 +108,22 @@ func main() {
 	}
 
 	// Enable CORS.
-	cors := handlers.CORS(
-		handlers.AllowedOrigins([]string{"*"}),
-		handlers.AllowedMethods([]string{"GET", "POST", "PUT", "DELETE"}),
-		handlers.AllowedHeaders([]string{"Content-Type", "Authorization"}),
+	cors := gorillaHandlers.CORS(
+		gorillaHandlers.AllowedOrigins([]string{"*"}),
+		gorillaHandlers.AllowedMethods([]string{"GET", "POST", "PUT", "PATCH", "DELETE"}),
+		gorillaHandlers.AllowedHeaders([]string{"Content-Type", "Authorization"}),
 	)
 
+	// Compress larger responses.
+	gzip := middlewares.Gzip(middlewares.InitGzipThreshold())
+
 	// Start goroutine to clean up expired tokens.
-	middlewares.StartTokenCleanupTask(pool, time.Hour)
+	middlewares.StartTokenCleanupTask(pool, blacklist, time.Hour)
+
+	// Start goroutine to cancel pending reservations past their hold.
+	handlers.StartReservationHoldSweeper(pool, time.Minute)
 
 	// Log the server start.
 	fmt.Printf("Server running on port %s\n", port)
-	log.Fatal(http.ListenAndServe(":"+port, cors(r)))
+	log.Fatal(http.ListenAndServe(":"+port, cors(gzip(r))))
 }
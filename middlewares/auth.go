@@ -19,11 +19,15 @@ type ContextKey string
 const UserClaimsKey ContextKey = "userClaims"
 
 // Routine for token cleanup.
-func StartTokenCleanupTask(pool *pgxpool.Pool, interval time.Duration) {
+func StartTokenCleanupTask(
+	pool *pgxpool.Pool,
+	blacklist TokenBlacklist,
+	interval time.Duration,
+) {
 	go func() {
 		for {
 			time.Sleep(interval)
-			if err := DeleteExpiredTokens(pool); err != nil {
+			if err := DeleteExpiredTokens(pool, blacklist); err != nil {
 				fmt.Printf("Error deleting expired tokens: %v\n", err)
 			}
 		}
@@ -31,18 +35,22 @@ func StartTokenCleanupTask(pool *pgxpool.Pool, interval time.Duration) {
 }
 
 // Delete expire tokens from the blacklist.
-func DeleteExpiredTokens(pool *pgxpool.Pool) error {
+func DeleteExpiredTokens(pool *pgxpool.Pool, blacklist TokenBlacklist) error {
 	log.Println("Deleting expired tokens...")
-	query := `DELETE FROM token_blacklist WHERE expires_at < $1`
-	_, err := pool.Exec(context.Background(), query, time.Now())
-	if err != nil {
+	if err := blacklist.DeleteExpired(context.Background()); err != nil {
+		return err
+	}
+
+	// sessions of expired tokens are of no use either
+	query := `DELETE FROM user_sessions WHERE expires_at < $1`
+	if _, err := pool.Exec(context.Background(), query, time.Now()); err != nil {
 		return err
 	}
 	return nil
 }
 
 func TokenValidation(
-	pool *pgxpool.Pool,
+	blacklist TokenBlacklist,
 	jwtSecret string,
 ) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -54,16 +62,7 @@ func TokenValidation(
 				return
 			}
 
-			// check if the token is in the blacklist.
-			var exists bool
-			query := `SELECT EXISTS (SELECT 1 FROM token_blacklist WHERE token = $1)`
-			err = pool.QueryRow(context.Background(), query, tokenString).Scan(&exists)
-			if err != nil || exists {
-				http.Error(w, "Token is invalid", http.StatusUnauthorized)
-				return
-			}
-
-			// validate the token.
+			// validate the token first, so forged ones never reach the blacklist
 			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 					return nil, http.ErrAbortHandler
@@ -75,6 +74,13 @@ func TokenValidation(
 				return
 			}
 
+			// check if the token is in the blacklist.
+			blacklisted, err := blacklist.IsBlacklisted(r.Context(), tokenString)
+			if err != nil || blacklisted {
+				http.Error(w, "Token is invalid", http.StatusUnauthorized)
+				return
+			}
+
 			// pass the request to the next handler.
 			next.ServeHTTP(w, r)
 		})
@@ -99,6 +105,17 @@ func RequireAuth(jwtSecret string) func(next http.Handler) http.Handler {
 				return
 			}
 
+			// actions taken on behalf of another user are audited separately
+			if impersonator, ok := claims["impersonated_by"].(string); ok {
+				log.Printf(
+					"AUDIT impersonation: admin %s acting as user %v: %s %s",
+					impersonator,
+					claims["userID"],
+					r.Method,
+					r.URL.RequestURI(),
+				)
+			}
+
 			// add claims to the request context
 			ctx := context.WithValue(r.Context(), UserClaimsKey, claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -106,6 +123,38 @@ func RequireAuth(jwtSecret string) func(next http.Handler) http.Handler {
 	}
 }
 
+// Add the claims of a valid token to the request context, if one is provided.
+// Requests without a token, or with an invalid or revoked one, pass through
+// anonymously, so routes using it stay public.
+func OptionalAuth(blacklist TokenBlacklist, jwtSecret string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString, err := ExtractToken(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := GetValidatedClaims(tokenString, jwtSecret)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// only tokens signed by us reach the blacklist, so arbitrary
+			// strings neither query the database nor fill the cache
+			blacklisted, err := blacklist.IsBlacklisted(r.Context(), tokenString)
+			if err != nil || blacklisted {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserClaimsKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 // Extract the JWT token from the Authorization header.
 func ExtractToken(r *http.Request) (string, error) {
 	// extract the authentication header
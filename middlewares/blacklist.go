@@ -0,0 +1,189 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Storage of revoked tokens, checked on every authenticated request.
+type TokenBlacklist interface {
+	// Add the token to the blacklist, until it expires.
+	Add(ctx context.Context, tokenString string, expiresAt time.Time) error
+
+	// Add the token known only by its hash, e.g. that of a stored session.
+	AddHash(ctx context.Context, tokenHash string, expiresAt time.Time) error
+
+	// Check if the token is blacklisted.
+	IsBlacklisted(ctx context.Context, tokenString string) (bool, error)
+
+	// Check if the token with given hash is blacklisted.
+	IsHashBlacklisted(ctx context.Context, tokenHash string) (bool, error)
+
+	// Remove tokens which expired, and so are rejected regardless.
+	DeleteExpired(ctx context.Context) error
+}
+
+// Default time (in seconds) a token found not to be revoked is trusted for,
+// before it is looked up again.
+const defaultBlacklistCacheSeconds = 30
+
+// Outcome of looking up a token, keyed by its hash in the cache.
+type blacklistEntry struct {
+	revoked   bool
+	expiresAt time.Time // set for revoked tokens
+	checkedAt time.Time
+}
+
+// In-memory record of looked up tokens. Revoked tokens are kept until they
+// expire, while the others are looked up again once their entry is older
+// than the TTL. Logouts are written through, so the cache only misses tokens
+// revoked by other instances, until their next lookup.
+type blacklistCache struct {
+	mu     sync.RWMutex
+	tokens map[string]blacklistEntry
+	ttl    time.Duration
+}
+
+// Token blacklist stored in the token_blacklist table, with an in-memory cache
+// in front of it. Only SHA-256 hashes of the tokens are stored, which bounds
+// the size of a row and keeps raw JWTs out of the database.
+type PostgresBlacklist struct {
+	pool  *pgxpool.Pool
+	cache *blacklistCache
+}
+
+// Create the Postgres backed blacklist, reading the cache TTL from the
+// environment.
+func NewPostgresBlacklist(pool *pgxpool.Pool) *PostgresBlacklist {
+	return &PostgresBlacklist{
+		pool: pool,
+		cache: &blacklistCache{
+			tokens: map[string]blacklistEntry{},
+			ttl:    blacklistCacheTTL(),
+		},
+	}
+}
+
+// Hash the token, which is what gets stored in place of the raw JWT.
+func HashToken(tokenString string) string {
+	sum := sha256.Sum256([]byte(tokenString))
+	return hex.EncodeToString(sum[:])
+}
+
+// Read the cache TTL from the environment.
+func blacklistCacheTTL() time.Duration {
+	seconds, err := getEnvAsInt("BLACKLIST_CACHE_SECONDS", defaultBlacklistCacheSeconds)
+	if err != nil || seconds < 0 {
+		log.Printf(
+			"Invalid BLACKLIST_CACHE_SECONDS, defaulting to %d seconds: %v",
+			defaultBlacklistCacheSeconds,
+			err,
+		)
+		seconds = defaultBlacklistCacheSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Add the token to the blacklist, writing it through to the cache, so the
+// revocation takes effect immediately. Revoking a token twice is a no-op.
+func (b *PostgresBlacklist) Add(
+	ctx context.Context,
+	tokenString string,
+	expiresAt time.Time,
+) error {
+	return b.AddHash(ctx, HashToken(tokenString), expiresAt)
+}
+
+// Add the token with given hash to the blacklist, as Add does.
+func (b *PostgresBlacklist) AddHash(
+	ctx context.Context,
+	hash string,
+	expiresAt time.Time,
+) error {
+	query := `
+		INSERT INTO token_blacklist (token_hash, expires_at) VALUES ($1, $2)
+		ON CONFLICT (token_hash) DO NOTHING
+	`
+	if _, err := b.pool.Exec(ctx, query, hash, expiresAt); err != nil {
+		return err
+	}
+
+	b.cache.mu.Lock()
+	defer b.cache.mu.Unlock()
+	b.cache.tokens[hash] = blacklistEntry{
+		revoked:   true,
+		expiresAt: expiresAt,
+		checkedAt: time.Now(),
+	}
+	return nil
+}
+
+// Check if the token is blacklisted. The database is queried only for tokens
+// without a fresh cache entry, by a lookup of the unique token hash.
+func (b *PostgresBlacklist) IsBlacklisted(
+	ctx context.Context,
+	tokenString string,
+) (bool, error) {
+	return b.IsHashBlacklisted(ctx, HashToken(tokenString))
+}
+
+// Check if the token with given hash is blacklisted, as IsBlacklisted does.
+func (b *PostgresBlacklist) IsHashBlacklisted(
+	ctx context.Context,
+	hash string,
+) (bool, error) {
+	b.cache.mu.RLock()
+	entry, cached := b.cache.tokens[hash]
+	b.cache.mu.RUnlock()
+
+	if cached && (entry.revoked || time.Since(entry.checkedAt) < b.cache.ttl) {
+		return entry.revoked, nil
+	}
+
+	entry = blacklistEntry{checkedAt: time.Now()}
+	query := `SELECT expires_at FROM token_blacklist WHERE token_hash = $1`
+	err := b.pool.QueryRow(ctx, query, hash).Scan(&entry.expiresAt)
+	switch err {
+	case nil:
+		entry.revoked = true
+	case pgx.ErrNoRows:
+	default:
+		return false, err
+	}
+
+	b.cache.mu.Lock()
+	defer b.cache.mu.Unlock()
+	b.cache.tokens[hash] = entry
+	return entry.revoked, nil
+}
+
+// Delete expired tokens from the table and the cache.
+func (b *PostgresBlacklist) DeleteExpired(ctx context.Context) error {
+	query := `DELETE FROM token_blacklist WHERE expires_at < $1`
+	if _, err := b.pool.Exec(ctx, query, time.Now()); err != nil {
+		return err
+	}
+	b.pruneCache()
+	return nil
+}
+
+// Drop expired tokens and stale lookups from the cache.
+func (b *PostgresBlacklist) pruneCache() {
+	b.cache.mu.Lock()
+	defer b.cache.mu.Unlock()
+
+	now := time.Now()
+	for hash, entry := range b.cache.tokens {
+		if (entry.revoked && entry.expiresAt.Before(now)) ||
+			(!entry.revoked && now.Sub(entry.checkedAt) >= b.cache.ttl) {
+			delete(b.cache.tokens, hash)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const testSecret = "test-secret-of-at-least-32-bytes!"
+
+// In-memory blacklist, standing in for the Postgres one.
+type memoryBlacklist struct {
+	mu      sync.Mutex
+	hashes  map[string]time.Time
+	lookups int
+}
+
+func newMemoryBlacklist() *memoryBlacklist {
+	return &memoryBlacklist{hashes: map[string]time.Time{}}
+}
+
+func (b *memoryBlacklist) Add(ctx context.Context, tokenString string, expiresAt time.Time) error {
+	return b.AddHash(ctx, HashToken(tokenString), expiresAt)
+}
+
+func (b *memoryBlacklist) AddHash(ctx context.Context, hash string, expiresAt time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.hashes[hash] = expiresAt
+	return nil
+}
+
+func (b *memoryBlacklist) IsBlacklisted(ctx context.Context, tokenString string) (bool, error) {
+	return b.IsHashBlacklisted(ctx, HashToken(tokenString))
+}
+
+func (b *memoryBlacklist) IsHashBlacklisted(ctx context.Context, hash string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lookups++
+	_, ok := b.hashes[hash]
+	return ok, nil
+}
+
+func (b *memoryBlacklist) DeleteExpired(ctx context.Context) error {
+	return nil
+}
+
+// Send a request with the token through the authentication chain of protected routes.
+func serveWithToken(blacklist TokenBlacklist, token string) int {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	chain := RequireAuth(testSecret)(TokenValidation(blacklist, testSecret)(ok))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	chain.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestLoggedOutTokenIsRejected(t *testing.T) {
+	blacklist := newMemoryBlacklist()
+	token, exp, err := GenerateJWT("00000000-0000-0000-0000-000000000001", "REGISTERED", testSecret)
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+
+	if status := serveWithToken(blacklist, token); status != http.StatusOK {
+		t.Fatalf("status before logout = %d, want %d", status, http.StatusOK)
+	}
+
+	if err := blacklist.Add(context.Background(), token, time.Unix(exp, 0)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if status := serveWithToken(blacklist, token); status != http.StatusUnauthorized {
+		t.Fatalf("status after logout = %d, want %d", status, http.StatusUnauthorized)
+	}
+}
+
+func TestOptionalAuthChecksSignatureFirst(t *testing.T) {
+	blacklist := newMemoryBlacklist()
+	var authenticated bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := GetClaimsFromContext(r.Context())
+		authenticated = err == nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/events/1", nil)
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	OptionalAuth(blacklist, testSecret)(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	if authenticated {
+		t.Fatal("request with an unsigned token was authenticated")
+	}
+	if blacklist.lookups != 0 {
+		t.Fatalf("unsigned token was looked up in the blacklist %d times", blacklist.lookups)
+	}
+}
+
+func TestTokenValidationChecksSignatureFirst(t *testing.T) {
+	blacklist := newMemoryBlacklist()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// mounted on its own, without RequireAuth rejecting the token before it
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/whoami", nil)
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	rec := httptest.NewRecorder()
+	TokenValidation(blacklist, testSecret)(next).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if blacklist.lookups != 0 {
+		t.Fatalf("unsigned token was looked up in the blacklist %d times", blacklist.lookups)
+	}
+}
+
+func TestPostgresBlacklist(t *testing.T) {
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	pool, err := pgxpool.New(context.Background(), dbURL)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	token, exp, err := GenerateJWT("00000000-0000-0000-0000-000000000002", "REGISTERED", testSecret)
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM token_blacklist WHERE token_hash = $1`, HashToken(token))
+
+	blacklist := NewPostgresBlacklist(pool)
+	if status := serveWithToken(blacklist, token); status != http.StatusOK {
+		t.Fatalf("status before logout = %d, want %d", status, http.StatusOK)
+	}
+	if err := blacklist.Add(ctx, token, time.Unix(exp, 0)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if status := serveWithToken(blacklist, token); status != http.StatusUnauthorized {
+		t.Fatalf("status after logout = %d, want %d", status, http.StatusUnauthorized)
+	}
+
+	// another instance, with a cold cache, finds the token in the table
+	if status := serveWithToken(NewPostgresBlacklist(pool), token); status != http.StatusUnauthorized {
+		t.Fatalf("status on another instance = %d, want %d", status, http.StatusUnauthorized)
+	}
+}
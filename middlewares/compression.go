@@ -0,0 +1,204 @@
+package middlewares
+
+import (
+	"bytes"
+	"compress/gzip"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Default size (in bytes) above which responses are compressed.
+const DefaultGzipThreshold = 1024
+
+// Pool of gzip writers, to avoid allocating one per response.
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// ResponseWriter buffering the body until the threshold is reached, then
+// deciding whether the response should be compressed or sent as is.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	threshold   int
+	status      int
+	buf         bytes.Buffer
+	gz          *gzip.Writer
+	wroteHeader bool
+	passthrough bool
+}
+
+// Record the status code; the header is sent once the encoding is decided.
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+
+	// responses without a body or already encoded ones are not touched
+	if status == http.StatusNotModified || status == http.StatusNoContent ||
+		status < http.StatusOK || w.Header().Get("Content-Encoding") != "" {
+		w.passthrough = true
+		w.flushHeader()
+	}
+}
+
+// Buffer the body until the threshold is reached, then start compressing.
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+
+	w.buf.Write(data)
+	if w.buf.Len() < w.threshold {
+		return len(data), nil
+	}
+
+	// threshold reached, switch to compressed output
+	if err := w.startGzip(); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// Switch to compressed output, writing out the buffered body.
+func (w *gzipResponseWriter) startGzip() error {
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Del("Content-Length")
+	w.flushHeader()
+
+	w.gz = gzipWriterPool.Get().(*gzip.Writer)
+	w.gz.Reset(w.ResponseWriter)
+	if _, err := w.gz.Write(w.buf.Bytes()); err != nil {
+		return err
+	}
+	w.buf.Reset()
+	return nil
+}
+
+// Send what has been written so far to the client. A streamed response
+// flushing before the threshold is reached is compressed from then on.
+func (w *gzipResponseWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.passthrough {
+		if w.gz == nil {
+			if err := w.startGzip(); err != nil {
+				return
+			}
+		}
+		if err := w.gz.Flush(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Send the status line and headers to the underlying writer.
+func (w *gzipResponseWriter) flushHeader() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// Finish the response, either closing the gzip stream or writing the
+// buffered (small) body uncompressed.
+func (w *gzipResponseWriter) close() {
+	if w.gz != nil {
+		_ = w.gz.Close()
+		gzipWriterPool.Put(w.gz)
+		w.gz = nil
+		return
+	}
+	if w.status == 0 {
+		// handler did not write anything
+		return
+	}
+	w.flushHeader()
+	if w.buf.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+}
+
+// Read the compression threshold from the environment, otherwise use the default.
+func InitGzipThreshold() int {
+	threshold, err := getEnvAsInt("GZIP_MIN_SIZE", DefaultGzipThreshold)
+	if err != nil || threshold <= 0 {
+		log.Printf("Invalid GZIP_MIN_SIZE, defaulting to %d bytes: %v", DefaultGzipThreshold, err)
+		return DefaultGzipThreshold
+	}
+	return threshold
+}
+
+// Check if the client accepts gzip encoded responses. An encoding with a
+// quality of 0 is refused by the client; gzip listed by name takes
+// precedence over the * wildcard.
+func acceptsGzip(r *http.Request) bool {
+	wildcard := false
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, accepted := parseEncoding(encoding)
+		switch {
+		case strings.EqualFold(name, "gzip"):
+			return accepted
+		case name == "*":
+			wildcard = accepted
+		}
+	}
+	return wildcard
+}
+
+// Parse an entry of the Accept-Encoding header, returning the name of the
+// encoding and whether its quality allows it.
+func parseEncoding(encoding string) (string, bool) {
+	params := strings.Split(encoding, ";")
+	name := strings.TrimSpace(params[0])
+	for _, param := range params[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if !strings.EqualFold(strings.TrimSpace(key), "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || q <= 0 {
+			return name, false
+		}
+	}
+	return name, true
+}
+
+// Compress responses larger than the threshold with gzip, if the client
+// advertises support for it via the Accept-Encoding header.
+func Gzip(threshold int) func(http.Handler) http.Handler {
+	if threshold <= 0 {
+		threshold = DefaultGzipThreshold
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// response depends on the Accept-Encoding header
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			if r.Method == http.MethodHead || !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			gw := &gzipResponseWriter{ResponseWriter: w, threshold: threshold}
+			defer gw.close()
+			next.ServeHTTP(gw, r)
+		})
+	}
+}
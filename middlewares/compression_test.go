@@ -0,0 +1,79 @@
+package middlewares
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, GZIP", true},
+		{"gzip;q=0.5", true},
+		{"gzip;q=0", false},
+		{"gzip; q=0.0, deflate", false},
+		{"br, identity", false},
+		{"*", true},
+		{"br, *;q=0", false},
+		{"*;q=0, gzip", true},
+		{"gzip;q=0, *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/events", nil)
+			r.Header.Set("Accept-Encoding", tt.header)
+			if got := acceptsGzip(r); got != tt.want {
+				t.Fatalf("acceptsGzip(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGzipFlushesStreamedResponses(t *testing.T) {
+	flushed := make(chan struct{})
+	stream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(flushed)
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Error("response writer does not implement http.Flusher")
+			return
+		}
+		_, _ = w.Write([]byte("id,name\n"))
+		flusher.Flush()
+		_, _ = w.Write([]byte("1,Test Event\n"))
+	})
+	handler := Gzip(DefaultGzipThreshold)(stream)
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", resp.Header.Get("Content-Encoding"))
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the gzip stream: %v", err)
+	}
+	body, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("failed to read the body: %v", err)
+	}
+	if string(body) != "id,name\n1,Test Event\n" {
+		t.Fatalf("body = %q", body)
+	}
+}
@@ -51,6 +51,7 @@ func GenerateJWT(userID string, role string, secret string) (string, int64, erro
 		"userID": userID,
 		"role":   role,
 		"exp":    expirationTime,
+		"iat":    time.Now().Unix(),
 	}
 
 	// create the JWT token
@@ -63,6 +64,40 @@ func GenerateJWT(userID string, role string, secret string) (string, int64, erro
 	return tokenString, expirationTime, nil
 }
 
+// Creates a short-lived JWT token, letting an admin act as another user.
+// The token carries the ID of the admin in the impersonated_by claim.
+func GenerateImpersonationJWT(
+	userID string,
+	role string,
+	impersonatorID string,
+	secret string,
+) (string, int64, error) {
+	// get the token validity duration from env variable, otherwise 15 minutes
+	validMinutes, err := getEnvAsInt("IMPERSONATION_VALID_MINUTES", 15)
+	if err != nil || validMinutes <= 0 {
+		log.Printf("Invalid IMPERSONATION_VALID_MINUTES, defaulting to 15 minutes: %v", err)
+		validMinutes = 15
+	}
+
+	expirationTime := time.Now().Add(time.Minute * time.Duration(validMinutes)).Unix()
+
+	claims := jwt.MapClaims{
+		"userID":          userID,
+		"role":            role,
+		"impersonated_by": impersonatorID,
+		"exp":             expirationTime,
+		"iat":             time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return tokenString, expirationTime, nil
+}
+
 // Validate the JWT token from the Authorization header
 func ValidateJWT(tokenString string, secret string) (*jwt.Token, error) {
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -74,22 +109,43 @@ func ValidateJWT(tokenString string, secret string) (*jwt.Token, error) {
 	})
 }
 
-// Initialize JWT secret from the environment or generate one as fallback
+// Shortest JWT secret (in bytes) accepted in production.
+const minJWTSecretLength = 32
+
+// Initialize JWT secret from the environment or generate one as fallback.
+// In production a missing or short secret is fatal instead.
 func InitJWTSecret() string {
-	// check if the secret is set in the environment
-	jwtSecret := os.Getenv("JWT_SECRET")
+	jwtSecret, err := loadJWTSecret(
+		os.Getenv("ENVIRONMENT") == "production",
+		os.Getenv("JWT_SECRET"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return jwtSecret
+}
+
+// Check the configured secret, generating a random one if none is set
+// outside of production.
+func loadJWTSecret(production bool, jwtSecret string) (string, error) {
+	if production && len(jwtSecret) < minJWTSecretLength {
+		return "", fmt.Errorf(
+			"JWT_SECRET must be set to at least %d bytes in production.",
+			minJWTSecretLength,
+		)
+	}
 	if jwtSecret != "" {
-		return jwtSecret
+		return jwtSecret, nil
 	}
 
 	// generate a random secret if none is set
 	randomSecret, err := generateRandomSecret()
 	if err != nil {
-		log.Fatalf("Failed to generate random JWT secret: %v", err)
+		return "", fmt.Errorf("Failed to generate random JWT secret: %v", err)
 	}
 
 	log.Println(
 		"WARNING: JWT_SECRET not set. Generating a random secret. Tokens will not be consistent across restarts.",
 	)
-	return randomSecret
+	return randomSecret, nil
 }
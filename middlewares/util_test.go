@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadJWTSecret(t *testing.T) {
+	strong := strings.Repeat("s", minJWTSecretLength)
+	weak := strings.Repeat("s", minJWTSecretLength-1)
+
+	tests := []struct {
+		name       string
+		production bool
+		secret     string
+		wantErr    bool
+	}{
+		{"production without secret", true, "", true},
+		{"production with weak secret", true, weak, true},
+		{"production with strong secret", true, strong, false},
+		{"development without secret", false, "", false},
+		{"development with weak secret", false, weak, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := loadJWTSecret(tt.production, tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadJWTSecret() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.secret != "" && secret != tt.secret {
+				t.Fatalf("loadJWTSecret() = %q, want the configured secret", secret)
+			}
+			if tt.secret == "" && secret == "" {
+				t.Fatal("loadJWTSecret() did not generate a secret")
+			}
+		})
+	}
+}
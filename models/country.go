@@ -0,0 +1,337 @@
+package models
+
+import "strings"
+
+// Countries accepted on locations, following ISO 3166-1. The name is what gets
+// stored, so filtering and grouping by country is not fragmented by spelling.
+var Countries = []CountryResponse{
+	{Code: "AF", Alpha3: "AFG", Name: "Afghanistan"},
+	{Code: "AX", Alpha3: "ALA", Name: "Åland Islands"},
+	{Code: "AL", Alpha3: "ALB", Name: "Albania"},
+	{Code: "DZ", Alpha3: "DZA", Name: "Algeria"},
+	{Code: "AS", Alpha3: "ASM", Name: "American Samoa"},
+	{Code: "AD", Alpha3: "AND", Name: "Andorra"},
+	{Code: "AO", Alpha3: "AGO", Name: "Angola"},
+	{Code: "AI", Alpha3: "AIA", Name: "Anguilla"},
+	{Code: "AQ", Alpha3: "ATA", Name: "Antarctica"},
+	{Code: "AG", Alpha3: "ATG", Name: "Antigua and Barbuda"},
+	{Code: "AR", Alpha3: "ARG", Name: "Argentina"},
+	{Code: "AM", Alpha3: "ARM", Name: "Armenia"},
+	{Code: "AW", Alpha3: "ABW", Name: "Aruba"},
+	{Code: "AU", Alpha3: "AUS", Name: "Australia"},
+	{Code: "AT", Alpha3: "AUT", Name: "Austria"},
+	{Code: "AZ", Alpha3: "AZE", Name: "Azerbaijan"},
+	{Code: "BS", Alpha3: "BHS", Name: "Bahamas"},
+	{Code: "BH", Alpha3: "BHR", Name: "Bahrain"},
+	{Code: "BD", Alpha3: "BGD", Name: "Bangladesh"},
+	{Code: "BB", Alpha3: "BRB", Name: "Barbados"},
+	{Code: "BY", Alpha3: "BLR", Name: "Belarus"},
+	{Code: "BE", Alpha3: "BEL", Name: "Belgium"},
+	{Code: "BZ", Alpha3: "BLZ", Name: "Belize"},
+	{Code: "BJ", Alpha3: "BEN", Name: "Benin"},
+	{Code: "BM", Alpha3: "BMU", Name: "Bermuda"},
+	{Code: "BT", Alpha3: "BTN", Name: "Bhutan"},
+	{Code: "BO", Alpha3: "BOL", Name: "Bolivia"},
+	{Code: "BQ", Alpha3: "BES", Name: "Bonaire, Sint Eustatius and Saba"},
+	{Code: "BA", Alpha3: "BIH", Name: "Bosnia and Herzegovina"},
+	{Code: "BW", Alpha3: "BWA", Name: "Botswana"},
+	{Code: "BV", Alpha3: "BVT", Name: "Bouvet Island"},
+	{Code: "BR", Alpha3: "BRA", Name: "Brazil"},
+	{Code: "IO", Alpha3: "IOT", Name: "British Indian Ocean Territory"},
+	{Code: "VG", Alpha3: "VGB", Name: "British Virgin Islands"},
+	{Code: "BN", Alpha3: "BRN", Name: "Brunei"},
+	{Code: "BG", Alpha3: "BGR", Name: "Bulgaria"},
+	{Code: "BF", Alpha3: "BFA", Name: "Burkina Faso"},
+	{Code: "BI", Alpha3: "BDI", Name: "Burundi"},
+	{Code: "CV", Alpha3: "CPV", Name: "Cabo Verde"},
+	{Code: "KH", Alpha3: "KHM", Name: "Cambodia"},
+	{Code: "CM", Alpha3: "CMR", Name: "Cameroon"},
+	{Code: "CA", Alpha3: "CAN", Name: "Canada"},
+	{Code: "KY", Alpha3: "CYM", Name: "Cayman Islands"},
+	{Code: "CF", Alpha3: "CAF", Name: "Central African Republic"},
+	{Code: "TD", Alpha3: "TCD", Name: "Chad"},
+	{Code: "CL", Alpha3: "CHL", Name: "Chile"},
+	{Code: "CN", Alpha3: "CHN", Name: "China"},
+	{Code: "CX", Alpha3: "CXR", Name: "Christmas Island"},
+	{Code: "CC", Alpha3: "CCK", Name: "Cocos (Keeling) Islands"},
+	{Code: "CO", Alpha3: "COL", Name: "Colombia"},
+	{Code: "KM", Alpha3: "COM", Name: "Comoros"},
+	{Code: "CG", Alpha3: "COG", Name: "Congo"},
+	{Code: "CK", Alpha3: "COK", Name: "Cook Islands"},
+	{Code: "CR", Alpha3: "CRI", Name: "Costa Rica"},
+	{Code: "CI", Alpha3: "CIV", Name: "Côte d'Ivoire"},
+	{Code: "HR", Alpha3: "HRV", Name: "Croatia"},
+	{Code: "CU", Alpha3: "CUB", Name: "Cuba"},
+	{Code: "CW", Alpha3: "CUW", Name: "Curaçao"},
+	{Code: "CY", Alpha3: "CYP", Name: "Cyprus"},
+	{Code: "CZ", Alpha3: "CZE", Name: "Czechia"},
+	{Code: "CD", Alpha3: "COD", Name: "Democratic Republic of the Congo"},
+	{Code: "DK", Alpha3: "DNK", Name: "Denmark"},
+	{Code: "DJ", Alpha3: "DJI", Name: "Djibouti"},
+	{Code: "DM", Alpha3: "DMA", Name: "Dominica"},
+	{Code: "DO", Alpha3: "DOM", Name: "Dominican Republic"},
+	{Code: "EC", Alpha3: "ECU", Name: "Ecuador"},
+	{Code: "EG", Alpha3: "EGY", Name: "Egypt"},
+	{Code: "SV", Alpha3: "SLV", Name: "El Salvador"},
+	{Code: "GQ", Alpha3: "GNQ", Name: "Equatorial Guinea"},
+	{Code: "ER", Alpha3: "ERI", Name: "Eritrea"},
+	{Code: "EE", Alpha3: "EST", Name: "Estonia"},
+	{Code: "SZ", Alpha3: "SWZ", Name: "Eswatini"},
+	{Code: "ET", Alpha3: "ETH", Name: "Ethiopia"},
+	{Code: "FK", Alpha3: "FLK", Name: "Falkland Islands"},
+	{Code: "FO", Alpha3: "FRO", Name: "Faroe Islands"},
+	{Code: "FJ", Alpha3: "FJI", Name: "Fiji"},
+	{Code: "FI", Alpha3: "FIN", Name: "Finland"},
+	{Code: "FR", Alpha3: "FRA", Name: "France"},
+	{Code: "GF", Alpha3: "GUF", Name: "French Guiana"},
+	{Code: "PF", Alpha3: "PYF", Name: "French Polynesia"},
+	{Code: "TF", Alpha3: "ATF", Name: "French Southern Territories"},
+	{Code: "GA", Alpha3: "GAB", Name: "Gabon"},
+	{Code: "GM", Alpha3: "GMB", Name: "Gambia"},
+	{Code: "GE", Alpha3: "GEO", Name: "Georgia"},
+	{Code: "DE", Alpha3: "DEU", Name: "Germany"},
+	{Code: "GH", Alpha3: "GHA", Name: "Ghana"},
+	{Code: "GI", Alpha3: "GIB", Name: "Gibraltar"},
+	{Code: "GR", Alpha3: "GRC", Name: "Greece"},
+	{Code: "GL", Alpha3: "GRL", Name: "Greenland"},
+	{Code: "GD", Alpha3: "GRD", Name: "Grenada"},
+	{Code: "GP", Alpha3: "GLP", Name: "Guadeloupe"},
+	{Code: "GU", Alpha3: "GUM", Name: "Guam"},
+	{Code: "GT", Alpha3: "GTM", Name: "Guatemala"},
+	{Code: "GG", Alpha3: "GGY", Name: "Guernsey"},
+	{Code: "GN", Alpha3: "GIN", Name: "Guinea"},
+	{Code: "GW", Alpha3: "GNB", Name: "Guinea-Bissau"},
+	{Code: "GY", Alpha3: "GUY", Name: "Guyana"},
+	{Code: "HT", Alpha3: "HTI", Name: "Haiti"},
+	{Code: "HM", Alpha3: "HMD", Name: "Heard Island and McDonald Islands"},
+	{Code: "VA", Alpha3: "VAT", Name: "Holy See"},
+	{Code: "HN", Alpha3: "HND", Name: "Honduras"},
+	{Code: "HK", Alpha3: "HKG", Name: "Hong Kong"},
+	{Code: "HU", Alpha3: "HUN", Name: "Hungary"},
+	{Code: "IS", Alpha3: "ISL", Name: "Iceland"},
+	{Code: "IN", Alpha3: "IND", Name: "India"},
+	{Code: "ID", Alpha3: "IDN", Name: "Indonesia"},
+	{Code: "IR", Alpha3: "IRN", Name: "Iran"},
+	{Code: "IQ", Alpha3: "IRQ", Name: "Iraq"},
+	{Code: "IE", Alpha3: "IRL", Name: "Ireland"},
+	{Code: "IM", Alpha3: "IMN", Name: "Isle of Man"},
+	{Code: "IL", Alpha3: "ISR", Name: "Israel"},
+	{Code: "IT", Alpha3: "ITA", Name: "Italy"},
+	{Code: "JM", Alpha3: "JAM", Name: "Jamaica"},
+	{Code: "JP", Alpha3: "JPN", Name: "Japan"},
+	{Code: "JE", Alpha3: "JEY", Name: "Jersey"},
+	{Code: "JO", Alpha3: "JOR", Name: "Jordan"},
+	{Code: "KZ", Alpha3: "KAZ", Name: "Kazakhstan"},
+	{Code: "KE", Alpha3: "KEN", Name: "Kenya"},
+	{Code: "KI", Alpha3: "KIR", Name: "Kiribati"},
+	{Code: "KW", Alpha3: "KWT", Name: "Kuwait"},
+	{Code: "KG", Alpha3: "KGZ", Name: "Kyrgyzstan"},
+	{Code: "LA", Alpha3: "LAO", Name: "Laos"},
+	{Code: "LV", Alpha3: "LVA", Name: "Latvia"},
+	{Code: "LB", Alpha3: "LBN", Name: "Lebanon"},
+	{Code: "LS", Alpha3: "LSO", Name: "Lesotho"},
+	{Code: "LR", Alpha3: "LBR", Name: "Liberia"},
+	{Code: "LY", Alpha3: "LBY", Name: "Libya"},
+	{Code: "LI", Alpha3: "LIE", Name: "Liechtenstein"},
+	{Code: "LT", Alpha3: "LTU", Name: "Lithuania"},
+	{Code: "LU", Alpha3: "LUX", Name: "Luxembourg"},
+	{Code: "MO", Alpha3: "MAC", Name: "Macao"},
+	{Code: "MG", Alpha3: "MDG", Name: "Madagascar"},
+	{Code: "MW", Alpha3: "MWI", Name: "Malawi"},
+	{Code: "MY", Alpha3: "MYS", Name: "Malaysia"},
+	{Code: "MV", Alpha3: "MDV", Name: "Maldives"},
+	{Code: "ML", Alpha3: "MLI", Name: "Mali"},
+	{Code: "MT", Alpha3: "MLT", Name: "Malta"},
+	{Code: "MH", Alpha3: "MHL", Name: "Marshall Islands"},
+	{Code: "MQ", Alpha3: "MTQ", Name: "Martinique"},
+	{Code: "MR", Alpha3: "MRT", Name: "Mauritania"},
+	{Code: "MU", Alpha3: "MUS", Name: "Mauritius"},
+	{Code: "YT", Alpha3: "MYT", Name: "Mayotte"},
+	{Code: "MX", Alpha3: "MEX", Name: "Mexico"},
+	{Code: "FM", Alpha3: "FSM", Name: "Micronesia"},
+	{Code: "MD", Alpha3: "MDA", Name: "Moldova"},
+	{Code: "MC", Alpha3: "MCO", Name: "Monaco"},
+	{Code: "MN", Alpha3: "MNG", Name: "Mongolia"},
+	{Code: "ME", Alpha3: "MNE", Name: "Montenegro"},
+	{Code: "MS", Alpha3: "MSR", Name: "Montserrat"},
+	{Code: "MA", Alpha3: "MAR", Name: "Morocco"},
+	{Code: "MZ", Alpha3: "MOZ", Name: "Mozambique"},
+	{Code: "MM", Alpha3: "MMR", Name: "Myanmar"},
+	{Code: "NA", Alpha3: "NAM", Name: "Namibia"},
+	{Code: "NR", Alpha3: "NRU", Name: "Nauru"},
+	{Code: "NP", Alpha3: "NPL", Name: "Nepal"},
+	{Code: "NL", Alpha3: "NLD", Name: "Netherlands"},
+	{Code: "NC", Alpha3: "NCL", Name: "New Caledonia"},
+	{Code: "NZ", Alpha3: "NZL", Name: "New Zealand"},
+	{Code: "NI", Alpha3: "NIC", Name: "Nicaragua"},
+	{Code: "NE", Alpha3: "NER", Name: "Niger"},
+	{Code: "NG", Alpha3: "NGA", Name: "Nigeria"},
+	{Code: "NU", Alpha3: "NIU", Name: "Niue"},
+	{Code: "NF", Alpha3: "NFK", Name: "Norfolk Island"},
+	{Code: "KP", Alpha3: "PRK", Name: "North Korea"},
+	{Code: "MK", Alpha3: "MKD", Name: "North Macedonia"},
+	{Code: "MP", Alpha3: "MNP", Name: "Northern Mariana Islands"},
+	{Code: "NO", Alpha3: "NOR", Name: "Norway"},
+	{Code: "OM", Alpha3: "OMN", Name: "Oman"},
+	{Code: "PK", Alpha3: "PAK", Name: "Pakistan"},
+	{Code: "PW", Alpha3: "PLW", Name: "Palau"},
+	{Code: "PS", Alpha3: "PSE", Name: "Palestine"},
+	{Code: "PA", Alpha3: "PAN", Name: "Panama"},
+	{Code: "PG", Alpha3: "PNG", Name: "Papua New Guinea"},
+	{Code: "PY", Alpha3: "PRY", Name: "Paraguay"},
+	{Code: "PE", Alpha3: "PER", Name: "Peru"},
+	{Code: "PH", Alpha3: "PHL", Name: "Philippines"},
+	{Code: "PN", Alpha3: "PCN", Name: "Pitcairn"},
+	{Code: "PL", Alpha3: "POL", Name: "Poland"},
+	{Code: "PT", Alpha3: "PRT", Name: "Portugal"},
+	{Code: "PR", Alpha3: "PRI", Name: "Puerto Rico"},
+	{Code: "QA", Alpha3: "QAT", Name: "Qatar"},
+	{Code: "RE", Alpha3: "REU", Name: "Réunion"},
+	{Code: "RO", Alpha3: "ROU", Name: "Romania"},
+	{Code: "RU", Alpha3: "RUS", Name: "Russia"},
+	{Code: "RW", Alpha3: "RWA", Name: "Rwanda"},
+	{Code: "BL", Alpha3: "BLM", Name: "Saint Barthélemy"},
+	{Code: "SH", Alpha3: "SHN", Name: "Saint Helena, Ascension and Tristan da Cunha"},
+	{Code: "KN", Alpha3: "KNA", Name: "Saint Kitts and Nevis"},
+	{Code: "LC", Alpha3: "LCA", Name: "Saint Lucia"},
+	{Code: "MF", Alpha3: "MAF", Name: "Saint Martin"},
+	{Code: "PM", Alpha3: "SPM", Name: "Saint Pierre and Miquelon"},
+	{Code: "VC", Alpha3: "VCT", Name: "Saint Vincent and the Grenadines"},
+	{Code: "WS", Alpha3: "WSM", Name: "Samoa"},
+	{Code: "SM", Alpha3: "SMR", Name: "San Marino"},
+	{Code: "ST", Alpha3: "STP", Name: "Sao Tome and Principe"},
+	{Code: "SA", Alpha3: "SAU", Name: "Saudi Arabia"},
+	{Code: "SN", Alpha3: "SEN", Name: "Senegal"},
+	{Code: "RS", Alpha3: "SRB", Name: "Serbia"},
+	{Code: "SC", Alpha3: "SYC", Name: "Seychelles"},
+	{Code: "SL", Alpha3: "SLE", Name: "Sierra Leone"},
+	{Code: "SG", Alpha3: "SGP", Name: "Singapore"},
+	{Code: "SX", Alpha3: "SXM", Name: "Sint Maarten"},
+	{Code: "SK", Alpha3: "SVK", Name: "Slovakia"},
+	{Code: "SI", Alpha3: "SVN", Name: "Slovenia"},
+	{Code: "SB", Alpha3: "SLB", Name: "Solomon Islands"},
+	{Code: "SO", Alpha3: "SOM", Name: "Somalia"},
+	{Code: "ZA", Alpha3: "ZAF", Name: "South Africa"},
+	{Code: "GS", Alpha3: "SGS", Name: "South Georgia and the South Sandwich Islands"},
+	{Code: "KR", Alpha3: "KOR", Name: "South Korea"},
+	{Code: "SS", Alpha3: "SSD", Name: "South Sudan"},
+	{Code: "ES", Alpha3: "ESP", Name: "Spain"},
+	{Code: "LK", Alpha3: "LKA", Name: "Sri Lanka"},
+	{Code: "SD", Alpha3: "SDN", Name: "Sudan"},
+	{Code: "SR", Alpha3: "SUR", Name: "Suriname"},
+	{Code: "SJ", Alpha3: "SJM", Name: "Svalbard and Jan Mayen"},
+	{Code: "SE", Alpha3: "SWE", Name: "Sweden"},
+	{Code: "CH", Alpha3: "CHE", Name: "Switzerland"},
+	{Code: "SY", Alpha3: "SYR", Name: "Syria"},
+	{Code: "TW", Alpha3: "TWN", Name: "Taiwan"},
+	{Code: "TJ", Alpha3: "TJK", Name: "Tajikistan"},
+	{Code: "TZ", Alpha3: "TZA", Name: "Tanzania"},
+	{Code: "TH", Alpha3: "THA", Name: "Thailand"},
+	{Code: "TL", Alpha3: "TLS", Name: "Timor-Leste"},
+	{Code: "TG", Alpha3: "TGO", Name: "Togo"},
+	{Code: "TK", Alpha3: "TKL", Name: "Tokelau"},
+	{Code: "TO", Alpha3: "TON", Name: "Tonga"},
+	{Code: "TT", Alpha3: "TTO", Name: "Trinidad and Tobago"},
+	{Code: "TN", Alpha3: "TUN", Name: "Tunisia"},
+	{Code: "TR", Alpha3: "TUR", Name: "Türkiye"},
+	{Code: "TM", Alpha3: "TKM", Name: "Turkmenistan"},
+	{Code: "TC", Alpha3: "TCA", Name: "Turks and Caicos Islands"},
+	{Code: "TV", Alpha3: "TUV", Name: "Tuvalu"},
+	{Code: "VI", Alpha3: "VIR", Name: "U.S. Virgin Islands"},
+	{Code: "UG", Alpha3: "UGA", Name: "Uganda"},
+	{Code: "UA", Alpha3: "UKR", Name: "Ukraine"},
+	{Code: "AE", Alpha3: "ARE", Name: "United Arab Emirates"},
+	{Code: "GB", Alpha3: "GBR", Name: "United Kingdom"},
+	{Code: "US", Alpha3: "USA", Name: "United States"},
+	{Code: "UM", Alpha3: "UMI", Name: "United States Minor Outlying Islands"},
+	{Code: "UY", Alpha3: "URY", Name: "Uruguay"},
+	{Code: "UZ", Alpha3: "UZB", Name: "Uzbekistan"},
+	{Code: "VU", Alpha3: "VUT", Name: "Vanuatu"},
+	{Code: "VE", Alpha3: "VEN", Name: "Venezuela"},
+	{Code: "VN", Alpha3: "VNM", Name: "Vietnam"},
+	{Code: "WF", Alpha3: "WLF", Name: "Wallis and Futuna"},
+	{Code: "EH", Alpha3: "ESH", Name: "Western Sahara"},
+	{Code: "YE", Alpha3: "YEM", Name: "Yemen"},
+	{Code: "ZM", Alpha3: "ZMB", Name: "Zambia"},
+	{Code: "ZW", Alpha3: "ZWE", Name: "Zimbabwe"},
+}
+
+// Common spellings of countries other than their codes and names, mapped to
+// the alpha-2 code.
+var countryAliases = map[string]string{
+	"america":                               "US",
+	"united states of america":              "US",
+	"u.s.":                                  "US",
+	"u.s.a.":                                "US",
+	"uk":                                    "GB",
+	"u.k.":                                  "GB",
+	"great britain":                         "GB",
+	"britain":                               "GB",
+	"england":                               "GB",
+	"scotland":                              "GB",
+	"wales":                                 "GB",
+	"northern ireland":                      "GB",
+	"russian federation":                    "RU",
+	"korea":                                 "KR",
+	"republic of korea":                     "KR",
+	"korea, republic of":                    "KR",
+	"democratic people's republic of korea": "KP",
+	"czech republic":                        "CZ",
+	"holland":                               "NL",
+	"the netherlands":                       "NL",
+	"viet nam":                              "VN",
+	"iran, islamic republic of":             "IR",
+	"syrian arab republic":                  "SY",
+	"bolivia, plurinational state of":       "BO",
+	"venezuela, bolivarian republic of":     "VE",
+	"tanzania, united republic of":          "TZ",
+	"lao people's democratic republic":      "LA",
+	"moldova, republic of":                  "MD",
+	"ivory coast":                           "CI",
+	"cote d'ivoire":                         "CI",
+	"turkey":                                "TR",
+	"turkiye":                               "TR",
+	"macedonia":                             "MK",
+	"uae":                                   "AE",
+	"swaziland":                             "SZ",
+	"burma":                                 "MM",
+	"cape verde":                            "CV",
+	"vatican":                               "VA",
+	"vatican city":                          "VA",
+	"dr congo":                              "CD",
+	"drc":                                   "CD",
+	"republic of the congo":                 "CG",
+	"east timor":                            "TL",
+	"brunei darussalam":                     "BN",
+	"macau":                                 "MO",
+	"curacao":                               "CW",
+	"reunion":                               "RE",
+	"aland islands":                         "AX",
+	"sao tome and principe":                 "ST",
+	"the bahamas":                           "BS",
+	"the gambia":                            "GM",
+	"state of palestine":                    "PS",
+	"micronesia, federated states of":       "FM",
+}
+
+// Lookup of countries by lowercase alpha-2 code, alpha-3 code, name or alias.
+var countryLookup = func() map[string]CountryResponse {
+	lookup := map[string]CountryResponse{}
+	for _, country := range Countries {
+		lookup[strings.ToLower(country.Code)] = country
+		lookup[strings.ToLower(country.Alpha3)] = country
+		lookup[strings.ToLower(country.Name)] = country
+	}
+	for alias, code := range countryAliases {
+		lookup[alias] = lookup[strings.ToLower(code)]
+	}
+	return lookup
+}()
+
+// Resolve the country by its code, name or a common alias, ignoring case and
+// surrounding whitespace, returning the canonical name.
+func NormalizeCountry(value string) (string, bool) {
+	key := strings.ToLower(strings.Join(strings.Fields(value), " "))
+	country, ok := countryLookup[key]
+	return country.Name, ok
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"math"
+	"strconv"
+)
+
+// Monetary amount, serialized as a number rounded to two decimals, so the
+// floating point representation does not leak into responses.
+type Money float64
+
+// Marshal the amount with exactly two decimals.
+func (m Money) MarshalJSON() ([]byte, error) {
+	rounded := math.Round(float64(m)*100) / 100
+	return []byte(strconv.FormatFloat(rounded, 'f', 2, 64)), nil
+}
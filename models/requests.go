@@ -10,17 +10,42 @@ type LoginRequest struct {
 type CreateLocationRequest struct {
 	Address  string `json:"address"            example:"123 Main St"`
 	Capacity int    `json:"capacity,omitempty" example:"50000"`
-	Country  string `json:"country,omitempty"  example:"USA"`
+	Country  string `json:"country,omitempty"  example:"US"`
 	Stadium  string `json:"stadium"            example:"National Stadium"`
 }
 
 // Expected create event payload.
 type CreateEventRequest struct {
-	Name             string                `json:"name"              example:"Champions League Final"`
-	Date             string                `json:"date"              example:"2024-12-31T20:00:00Z"`
-	AvailableTickets int                   `json:"available_tickets" example:"20000"`
-	Price            float64               `json:"price"             example:"99.99"`
-	Location         CreateLocationRequest `json:"location"`
+	Name                     string                `json:"name"                                  example:"Champions League Final"`
+	Date                     string                `json:"date"                                  example:"2024-12-31T20:00:00Z"`
+	AvailableTickets         int                   `json:"available_tickets"                     example:"20000"`
+	Price                    float64               `json:"price"                                 example:"99.99"`
+	MaxTicketsPerReservation *int                  `json:"max_tickets_per_reservation,omitempty" example:"50"`
+	MaxReservationsPerUser   *int                  `json:"max_reservations_per_user,omitempty"   example:"1"`
+	SaleStartsAt             *string               `json:"sale_starts_at,omitempty"              example:"2024-10-01T10:00:00Z"`
+	Visibility               *string               `json:"visibility,omitempty"                  example:"UNLISTED"`
+	ReservationCutoffHours   *int                  `json:"reservation_cutoff_hours,omitempty"    example:"2"`
+	Location                 CreateLocationRequest `json:"location"`
+}
+
+// Rule generating the dates of an event series; either count or until is required.
+type EventRecurrenceRequest struct {
+	Frequency string  `json:"frequency"       example:"weekly"`
+	Interval  int     `json:"interval"        example:"1"`
+	Count     *int    `json:"count,omitempty" example:"10"`
+	Until     *string `json:"until,omitempty" example:"2025-03-31T20:00:00Z"`
+}
+
+// Expected create event series payload; the base event is the first of the series.
+type CreateEventSeriesRequest struct {
+	Event      CreateEventRequest     `json:"event"`
+	Recurrence EventRecurrenceRequest `json:"recurrence"`
+}
+
+// Optional clone event payload; omitted fields are copied from the source event.
+type CloneEventRequest struct {
+	Name *string `json:"name,omitempty" example:"Champions League Final 2025"`
+	Date *string `json:"date,omitempty" example:"2025-12-31T20:00:00Z"`
 }
 
 // Expected create user payload.
@@ -34,17 +59,29 @@ type CreateUserRequest struct {
 	IsActive bool   `json:"is_active" example:"true"`
 }
 
+// Single entry of the tickets within reservation payload.
+// Quantity is optional and defaults to a single ticket.
+type ReservationTicketRequest struct {
+	Type     string  `json:"type"               example:"STANDARD"`
+	Quantity int     `json:"quantity,omitempty" example:"2"`
+	Section  *string `json:"section,omitempty"  example:"North Stand"`
+}
+
 // Structure of a valid payload to create a reservation.
 type CreateReservationPayload struct {
-	EventID int `json:"event_id" example:"101"`
-	Tickets []struct {
-		Type string `json:"type" example:"STANDARD"`
-	} `json:"tickets"`
+	EventID int                        `json:"event_id" example:"101"`
+	Tickets []ReservationTicketRequest `json:"tickets"`
+}
+
+// Corrected set of tickets, replacing the current tickets of a reservation.
+type ReissueTicketsRequest struct {
+	Tickets []ReservationTicketRequest `json:"tickets"`
 }
 
 // Structure of a valid request to the database.
 type ReservationRequest struct {
 	UserID       string `json:"user_id"       example:"123e4567-e89b-12d3-a456-426614174000"`
+	CreatedBy    string `json:"created_by"    example:"123e4567-e89b-12d3-a456-426614174000"`
 	EventID      int    `json:"event_id"      example:"101"`
 	TotalTickets int    `json:"total_tickets" example:"3"`
 	StatusID     int    `json:"status_id"     example:"1"`
@@ -63,11 +100,16 @@ type UpdateLocationRequest struct {
 
 // Expected update event payload.
 type UpdateEventRequest struct {
-	AvailableTickets *int                   `json:"available_tickets,omitempty" example:"15000"`
-	Date             *string                `json:"date,omitempty"              example:"2024-12-25T18:00:00Z"`
-	Name             *string                `json:"name,omitempty"              example:"Christmas Special"`
-	Price            *float64               `json:"price,omitempty"             example:"49.99"`
-	Location         *UpdateLocationRequest `json:"location,omitempty"`
+	AvailableTickets         *int                   `json:"available_tickets,omitempty"           example:"15000"`
+	Date                     *string                `json:"date,omitempty"                        example:"2024-12-25T18:00:00Z"`
+	Name                     *string                `json:"name,omitempty"                        example:"Christmas Special"`
+	Price                    *float64               `json:"price,omitempty"                       example:"49.99"`
+	MaxTicketsPerReservation *int                   `json:"max_tickets_per_reservation,omitempty" example:"50"`
+	MaxReservationsPerUser   *int                   `json:"max_reservations_per_user,omitempty"   example:"1"`
+	SaleStartsAt             *string                `json:"sale_starts_at,omitempty"              example:"2024-10-01T10:00:00Z"`
+	Visibility               *string                `json:"visibility,omitempty"                  example:"PRIVATE"`
+	ReservationCutoffHours   *int                   `json:"reservation_cutoff_hours,omitempty"    example:"2"`
+	Location                 *UpdateLocationRequest `json:"location,omitempty"`
 }
 
 // Expected update user payload.
@@ -80,3 +122,79 @@ type UpdateUserRequest struct {
 	RoleName *string `json:"role_name,omitempty" example:"admin"`
 	IsActive *bool   `json:"is_active,omitempty" example:"false"`
 }
+
+// Expected change role payload.
+type ChangeUserRoleRequest struct {
+	RoleName string `json:"role_name" example:"staff"`
+}
+
+// Optional reason recorded with the refunds of bulk-cancelled reservations.
+type CancelUserReservationsRequest struct {
+	RefundReason *string `json:"refund_reason" example:"Account closed."`
+}
+
+// Expected update reservation notes payload; null clears the notes.
+type UpdateReservationNotesRequest struct {
+	Notes *string `json:"notes" example:"Customer called about refund."`
+}
+
+// Optional cancel reservation payload; refund fields are accepted from admins only.
+type CancelReservationRequest struct {
+	RefundAmount *float64 `json:"refund_amount" example:"40.00"`
+	RefundReason *string  `json:"refund_reason" example:"Cancellation fee deducted."`
+}
+
+// Expected set event sections payload, replacing the current sections.
+type SetEventSectionsRequest struct {
+	Sections []string `json:"sections" example:"North Stand,South Stand"`
+}
+
+// Expected merge locations payload; merged locations are folded into the kept one.
+type MergeLocationsRequest struct {
+	KeepID   int   `json:"keep_id"   example:"1"`
+	MergeIDs []int `json:"merge_ids" example:"2,3"`
+}
+
+// Price override of a single ticket type.
+type EventTicketPriceRequest struct {
+	Type  string  `json:"type"  example:"STUDENT"`
+	Price float64 `json:"price" example:"59.99"`
+}
+
+// Expected set event ticket prices payload.
+type SetEventTicketPricesRequest struct {
+	Prices []EventTicketPriceRequest `json:"prices"`
+}
+
+// Expected reprice event payload; prices override the given ticket types.
+type RepriceEventRequest struct {
+	Price  float64                   `json:"price"  example:"99.99"`
+	Prices []EventTicketPriceRequest `json:"prices"`
+}
+
+// Status a ticket is corrected to by an admin.
+type UpdateTicketStatusRequest struct {
+	Status string `json:"status" example:"SOLD"`
+}
+
+// Code generated by an authenticator app.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+// Answer to the challenge of a login requiring a second factor.
+type TwoFactorVerifyRequest struct {
+	Challenge string `json:"challenge" example:"8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f.1735689600.5d41402abc4b2a76"`
+	Code      string `json:"code"      example:"123456"`
+}
+
+// Ticket tokens scanned at the gate, verified together.
+type VerifyTicketsRequest struct {
+	Tokens []string `json:"tokens" example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.5d41402abc4b2a76"`
+}
+
+// Single read request within a batch.
+type BatchRequestItem struct {
+	Method string `json:"method" example:"GET"`
+	Path   string `json:"path"   example:"/api/reservations/user/summary"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +12,18 @@ type ErrorResponse struct {
 	Message string `json:"message" example:"An error occurred"`
 }
 
+// Error response listing every violated rule of the request.
+type ValidationErrorResponse struct {
+	Message    string   `json:"message"    example:"Updated event would be invalid."`
+	Violations []string `json:"violations" example:"Date must be in the future."`
+}
+
+// Error response pointing at the reservation the request would duplicate.
+type DuplicateReservationResponse struct {
+	Message       string `json:"message"        example:"Active reservation for the event already exists."`
+	ReservationID string `json:"reservation_id" example:"a1b2c3d4-e5f6-7890-abcd-ef1234567890"`
+}
+
 // Standardized response for successful operations.
 type SuccessResponse struct {
 	Message string `json:"message" example:"Operation successful"`
@@ -41,14 +54,80 @@ type LoginResponse struct {
 	User    UserUsernameID `json:"user"`
 }
 
+// Token issued to an admin, letting them act as another user.
+type ImpersonationResponse struct {
+	Token          string         `json:"token"           example:"jwt-token-string"`
+	Expires        int64          `json:"exp"             example:"1683649261"`
+	User           UserUsernameID `json:"user"`
+	Impersonated   bool           `json:"impersonated"    example:"true"`
+	ImpersonatedBy string         `json:"impersonated_by" example:"8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f"`
+}
+
 // Event, as it's returned to the user.
 type EventResponse struct {
-	ID               int              `json:"id"                example:"1"`
-	Name             string           `json:"name"              example:"Champions League Final"`
-	Price            float64          `json:"price"             example:"99.99"`
-	AvailableTickets int              `json:"available_tickets" example:"15000"`
-	Date             time.Time        `json:"date"              example:"2024-12-31T20:00:00Z"`
-	Location         LocationResponse `json:"location"`
+	ID                     int              `json:"id"                                 example:"1"`
+	Name                   string           `json:"name"                               example:"Champions League Final"`
+	Slug                   string           `json:"slug,omitempty"                     example:"champions-league-final-2024-12-31"`
+	Price                  Money            `json:"price"                              example:"99.99"`
+	Currency               string           `json:"currency,omitempty"                 example:"USD"`
+	AvailableTickets       int              `json:"available_tickets"                  example:"15000"`
+	Date                   time.Time        `json:"date"                               example:"2024-12-31T20:00:00Z"`
+	SaleStartsAt           *time.Time       `json:"sale_starts_at,omitempty"           example:"2024-10-01T10:00:00Z"`
+	OnSale                 *bool            `json:"on_sale,omitempty"                  example:"true"`
+	Visibility             string           `json:"visibility,omitempty"               example:"PUBLIC"`
+	ReservationCutoffHours *int             `json:"reservation_cutoff_hours,omitempty" example:"2"`
+	SalesOpen              *bool            `json:"sales_open,omitempty"               example:"true"`
+	Location               LocationResponse `json:"location"`
+}
+
+// Response after generating an event series.
+type CreateEventSeriesResponse struct {
+	Message  string `json:"message"   example:"Event series created successfully."`
+	SeriesID int    `json:"series_id" example:"1"`
+	EventIDs []int  `json:"event_ids" example:"12,13,14"`
+}
+
+// Events of a series, ordered by date.
+type EventSeriesResponse struct {
+	ID        int             `json:"id"        example:"1"`
+	Frequency string          `json:"frequency" example:"WEEKLY"`
+	Interval  int             `json:"interval"  example:"1"`
+	Events    []EventResponse `json:"events"`
+}
+
+// Sections of an event tickets can be reserved in.
+type EventSectionsResponse struct {
+	EventID  int      `json:"event_id" example:"1"`
+	Sections []string `json:"sections" example:"North Stand,South Stand"`
+}
+
+// Number of tickets of a single type held by an attendee.
+type AttendeeTicketsResponse struct {
+	Type  string `json:"type"  example:"STANDARD"`
+	Count int    `json:"count" example:"2"`
+}
+
+// Attendee of an event, i.e. a user holding sold tickets for it.
+type AttendeeResponse struct {
+	UserID       string                    `json:"user_id"       example:"user123"`
+	Username     string                    `json:"username"      example:"johndoe"`
+	Name         string                    `json:"name"          example:"John"`
+	Surname      string                    `json:"surname"       example:"Doe"`
+	Email        string                    `json:"email"         example:"john@example.com"`
+	TotalTickets int                       `json:"total_tickets" example:"3"`
+	Tickets      []AttendeeTicketsResponse `json:"tickets"`
+}
+
+// Attendee list of an event.
+type EventAttendeesResponse struct {
+	EventID   int                `json:"event_id"  example:"1"`
+	Attendees []AttendeeResponse `json:"attendees"`
+}
+
+// Response after updating an event.
+type UpdateEventResponse struct {
+	Message              string `json:"message"               example:"Event updated successfully."`
+	AffectedReservations int    `json:"affected_reservations" example:"12"`
 }
 
 // Collection of events.
@@ -59,17 +138,113 @@ type EventsResponse struct {
 // Location, as it's returned to the user.
 type LocationResponse struct {
 	ID       int    `json:"id"       example:"101"`
-	Country  string `json:"country"  example:"England"`
+	Country  string `json:"country"  example:"United Kingdom"`
 	Address  string `json:"address"  example:"Wembley Park, London"`
 	Stadium  string `json:"stadium"  example:"Wembley Stadium"`
 	Capacity int    `json:"capacity" example:"90000"`
 }
 
+// Country accepted on locations.
+type CountryResponse struct {
+	Code   string `json:"code"   example:"GB"`
+	Alpha3 string `json:"alpha3" example:"GBR"`
+	Name   string `json:"name"   example:"United Kingdom"`
+}
+
+// Collection of accepted countries.
+type CountriesResponse struct {
+	Countries []CountryResponse `json:"countries"`
+}
+
+// Pagination of an enveloped list. Limit and offset are left out of lists
+// that are not paginated.
+type ListMeta struct {
+	Total      int    `json:"total"                 example:"250"`
+	Limit      *int   `json:"limit,omitempty"       example:"100"`
+	Offset     *int   `json:"offset,omitempty"      example:"0"`
+	NextCursor string `json:"next_cursor,omitempty" example:"MjAyNS0wMS0wMVQwMDowMDowMFp8YWJjMTIz"`
+}
+
+// List wrapped in the envelope, returned by list endpoints when enabled.
+type ListResponse[T any] struct {
+	Data []T      `json:"data"`
+	Meta ListMeta `json:"meta"`
+}
+
 // Collection of locations.
 type LocationsResponse struct {
 	Locations []LocationResponse `json:"locations"`
 }
 
+// Sales figures of a single location.
+type LocationStatsResponse struct {
+	Location    LocationResponse `json:"location"`
+	EventCount  int              `json:"event_count"  example:"4"`
+	TicketsSold int              `json:"tickets_sold" example:"1250"`
+	Revenue     Money            `json:"revenue"      example:"124987.50"`
+}
+
+// Sales figures of a ticket type.
+type TicketTypeStatsResponse struct {
+	TypeID       int    `json:"type_id"       example:"2"`
+	Type         string `json:"type"          example:"STUDENT"`
+	TicketsSold  int    `json:"tickets_sold"  example:"320"`
+	Revenue      Money  `json:"revenue"       example:"25596.80"`
+	AveragePrice Money  `json:"average_price" example:"79.99"`
+}
+
+// Collection of ticket type sales figures.
+type TicketTypesStatsResponse struct {
+	Currency string                    `json:"currency" example:"USD"`
+	Types    []TicketTypeStatsResponse `json:"types"`
+}
+
+// Event requiring more seats than its location has.
+type CapacityConflictResponse struct {
+	EventID          int       `json:"event_id"          example:"1"`
+	Name             string    `json:"name"              example:"Concert"`
+	Date             time.Time `json:"date"              example:"2025-01-01T20:00:00Z"`
+	AvailableTickets int       `json:"available_tickets" example:"500"`
+	TicketsIssued    int       `json:"tickets_issued"    example:"49800"`
+	Seats            int       `json:"seats"             example:"50300"`
+	Excess           int       `json:"excess"            example:"300"`
+}
+
+// Events of a location exceeding its capacity.
+type CapacityConflictsResponse struct {
+	LocationID int                        `json:"location_id" example:"1"`
+	Capacity   int                        `json:"capacity"    example:"50000"`
+	Conflicts  []CapacityConflictResponse `json:"conflicts"`
+}
+
+// Seats available across upcoming events.
+type AvailabilityStatsResponse struct {
+	AvailableSeats int `json:"available_seats" example:"125000"`
+	Events         int `json:"events"          example:"42"`
+}
+
+// Sales within a single bucket of the time series.
+type SalesBucketResponse struct {
+	Start       time.Time `json:"start"        example:"2025-01-06T00:00:00Z"`
+	TicketsSold int       `json:"tickets_sold" example:"42"`
+	Revenue     Money     `json:"revenue"      example:"3150.00"`
+}
+
+// Sales over a range of time, one bucket per day or week.
+type SalesTimeseriesResponse struct {
+	Bucket   string                `json:"bucket"   example:"day"`
+	From     time.Time             `json:"from"     example:"2025-01-01T00:00:00Z"`
+	To       time.Time             `json:"to"       example:"2025-01-31T00:00:00Z"`
+	Currency string                `json:"currency" example:"USD"`
+	Buckets  []SalesBucketResponse `json:"buckets"`
+}
+
+// Collection of location sales figures.
+type LocationsStatsResponse struct {
+	Currency  string                  `json:"currency"  example:"USD"`
+	Locations []LocationStatsResponse `json:"locations"`
+}
+
 // User response, as it's returned to the user.
 type UserResponse struct {
 	ID        uuid.UUID `json:"id"                   example:"123e4567-e89b-12d3-a456-426614174000"`
@@ -83,6 +258,22 @@ type UserResponse struct {
 	IsActive  bool      `json:"is_active"            example:"true"`
 }
 
+// Reduced view of a user, without personal data, for staff.
+type UserSummaryResponse struct {
+	ID        uuid.UUID `json:"id"         example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name      string    `json:"name"       example:"John"`
+	Surname   string    `json:"surname"    example:"Doe"`
+	Username  string    `json:"username"   example:"johndoe"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T10:00:00Z"`
+	RoleName  string    `json:"role_id"    example:"admin"`
+	IsActive  bool      `json:"is_active"  example:"true"`
+}
+
+// Collection of users in the reduced view.
+type UserSummariesResponse struct {
+	Users []UserSummaryResponse `json:"users"`
+}
+
 // Collection of users.
 type UsersResponse struct {
 	Users []UserResponse `json:"users"`
@@ -90,19 +281,23 @@ type UsersResponse struct {
 
 // Ticket, as it's returned to the user.
 type TicketResponse struct {
-	ID     string  `json:"id"     example:"abc123"`
-	Type   string  `json:"type"   example:"STANDARD"`
-	Price  float64 `json:"price"  example:"150.00"`
-	Status string  `json:"status" example:"available"`
+	ID       string  `json:"id"                example:"abc123"`
+	Type     string  `json:"type"              example:"STANDARD"`
+	Price    Money   `json:"price"             example:"150.00"`
+	Currency string  `json:"currency"          example:"USD"`
+	Status   string  `json:"status"            example:"available"`
+	Section  *string `json:"section,omitempty" example:"North Stand"`
 }
 
 // User's ticket response.
 type UserTicketResponse struct {
-	ID            string        `json:"id"             example:"ticket123"`
-	Type          string        `json:"type"           example:"STANDARD"`
-	Price         float64       `json:"price"          example:"50.00"`
-	Status        string        `json:"status"         example:"SOLD"`
-	ReservationID string        `json:"reservation_id" example:"res123"`
+	ID            string        `json:"id"                example:"ticket123"`
+	Type          string        `json:"type"              example:"STANDARD"`
+	Price         Money         `json:"price"             example:"50.00"`
+	Currency      string        `json:"currency"          example:"USD"`
+	Status        string        `json:"status"            example:"SOLD"`
+	Section       *string       `json:"section,omitempty" example:"North Stand"`
+	ReservationID string        `json:"reservation_id"    example:"res123"`
 	Event         EventResponse `json:"event"`
 }
 
@@ -114,18 +309,98 @@ type UserTicketsResponse struct {
 
 // Reservation, as it's returned to the user.
 type ReservationResponse struct {
-	ID           string           `json:"id"            example:"res123"`
-	Username     string           `json:"user"          example:"johndoe"`
-	CreatedAt    time.Time        `json:"created_at"    example:"2024-12-01T15:30:00Z"`
-	TotalTickets int              `json:"total_tickets" example:"5"`
-	Status       string           `json:"status"        example:"CONFIRMED"`
-	Event        EventResponse    `json:"event"`
-	Tickets      []TicketResponse `json:"tickets"`
+	ID            string           `json:"id"                        example:"res123"`
+	Username      string           `json:"user"                      example:"johndoe"`
+	CreatedAt     time.Time        `json:"created_at"                example:"2024-12-01T15:30:00Z"`
+	TotalTickets  int              `json:"total_tickets"             example:"5"`
+	Status        string           `json:"status"                    example:"CONFIRMED"`
+	Notes         *string          `json:"notes,omitempty"           example:"Customer called about refund."`
+	HoldExpiresAt *time.Time       `json:"hold_expires_at,omitempty" example:"2024-12-01T15:45:00Z"`
+	Event         EventResponse    `json:"event"`
+	Tickets       []TicketResponse `json:"tickets"`
+}
+
+// Reservation after being created. Pending reservations hold their tickets
+// only until hold_expires_at.
+type CreateReservationResponse struct {
+	Message       string     `json:"message"                   example:"Reservation created successfully."`
+	UUID          string     `json:"id"                        example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status        string     `json:"status"                    example:"PENDING"`
+	HoldExpiresAt *time.Time `json:"hold_expires_at,omitempty" example:"2024-12-01T15:45:00Z"`
+}
+
+// Refund recorded for a cancelled reservation.
+type RefundResponse struct {
+	ID            int       `json:"id"               example:"1"`
+	ReservationID string    `json:"reservation_id"   example:"123e4567-e89b-12d3-a456-426614174000"`
+	Amount        Money     `json:"amount"           example:"40.00"`
+	Currency      string    `json:"currency"         example:"USD"`
+	Reason        *string   `json:"reason,omitempty" example:"Cancellation fee deducted."`
+	CreatedAt     time.Time `json:"created_at"       example:"2024-12-01T15:30:00Z"`
+}
+
+// Payment recorded for a reservation.
+type PaymentResponse struct {
+	ID       int       `json:"id"       example:"1"`
+	Status   string    `json:"status"   example:"COMPLETED"`
+	Amount   Money     `json:"amount"   example:"150.00"`
+	Currency string    `json:"currency" example:"USD"`
+	PaidAt   time.Time `json:"paid_at"  example:"2024-12-01T15:30:00Z"`
+}
+
+// Payments of a reservation, oldest first.
+type ReservationPaymentsResponse struct {
+	ReservationID string            `json:"reservation_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Payments      []PaymentResponse `json:"payments"`
+}
+
+// Reservation after being cancelled, along with the refund of paid tickets.
+type CancelReservationResponse struct {
+	Message string          `json:"message"          example:"Reservation canceled successfully."`
+	Refund  *RefundResponse `json:"refund,omitempty"`
+}
+
+// Effects cancelling a reservation would have, computed without changing it.
+type CancelPreviewResponse struct {
+	ReservationID    string `json:"reservation_id"    example:"0b9f8e7d-6c5b-4a39-8281-7f6e5d4c3b2a"`
+	EventID          int    `json:"event_id"          example:"1"`
+	TicketsCancelled int    `json:"tickets_cancelled" example:"3"`
+	SeatsReleased    int    `json:"seats_released"    example:"2"`
+	RefundAmount     Money  `json:"refund_amount"     example:"150.00"`
+	Currency         string `json:"currency"          example:"USD"`
+}
+
+// Outcome of cancelling all active reservations of a user.
+type CancelUserReservationsResponse struct {
+	Message   string           `json:"message"   example:"Reservations cancelled successfully."`
+	Cancelled int              `json:"cancelled" example:"3"`
+	Refunds   []RefundResponse `json:"refunds"`
+}
+
+// Outcome of merging duplicate locations.
+type MergeLocationsResponse struct {
+	Message         string `json:"message"          example:"Locations merged successfully."`
+	RepointedEvents int    `json:"repointed_events" example:"4"`
+	DeletedIDs      []int  `json:"deleted_ids"      example:"2,3"`
+}
+
+// Outcome of validating a promo code against an event. Invalid codes carry
+// the reason instead of the discount.
+type PromoCodeValidationResponse struct {
+	Code            string  `json:"code"                       example:"SUMMER20"`
+	Valid           bool    `json:"valid"                      example:"true"`
+	Reason          string  `json:"reason,omitempty"           example:"expired"`
+	PercentOff      float64 `json:"percent_off,omitempty"      example:"20"`
+	EventID         int     `json:"event_id"                   example:"1"`
+	Currency        string  `json:"currency"                   example:"USD"`
+	BasePrice       Money   `json:"base_price"                 example:"99.99"`
+	DiscountedPrice *Money  `json:"discounted_price,omitempty" example:"79.99"`
 }
 
 // Collection of reservations.
 type ReservationsResponse struct {
 	Reservations []ReservationResponse `json:"reservations"`
+	NextCursor   string                `json:"next_cursor,omitempty" example:"MjAyNC0xMi0wMVQxNTozMDowMFp8cmVzMTIz"`
 }
 
 // Response for tickets under a reservation.
@@ -134,3 +409,253 @@ type ReservationTicketsResponse struct {
 	UserID        string           `json:"user"           example:"johndoe"`
 	Tickets       []TicketResponse `json:"tickets"`
 }
+
+// Role, along with its permissions and number of users.
+type RoleResponse struct {
+	ID          int      `json:"id"          example:"3"`
+	Name        string   `json:"name"        example:"ADMIN"`
+	Description string   `json:"description" example:"Full system access and management"`
+	Permissions []string `json:"permissions" example:"VIEW_EVENTS,MANAGE_USERS"`
+	UserCount   int      `json:"user_count"  example:"2"`
+}
+
+// Collection of roles.
+type RolesResponse struct {
+	Roles []RoleResponse `json:"roles"`
+}
+
+// Price of a ticket type for an event.
+type EventTicketPriceResponse struct {
+	Type     string `json:"type"     example:"STUDENT"`
+	Price    Money  `json:"price"    example:"59.99"`
+	Override bool   `json:"override" example:"true"`
+}
+
+// Prices of all ticket types for an event.
+type EventTicketPricesResponse struct {
+	EventID  int                        `json:"event_id" example:"1"`
+	Currency string                     `json:"currency" example:"USD"`
+	Prices   []EventTicketPriceResponse `json:"prices"`
+}
+
+// Outcome of repricing an event.
+type RepriceEventResponse struct {
+	Message          string `json:"message"           example:"Event repriced successfully."`
+	RepricedTickets  int    `json:"repriced_tickets"  example:"12"`
+	UnchangedTickets int    `json:"unchanged_tickets" example:"40"`
+}
+
+// Export of all data stored about a user.
+type UserExportResponse struct {
+	ExportedAt   time.Time             `json:"exported_at"  example:"2024-12-01T15:30:00Z"`
+	User         UserResponse          `json:"user"`
+	Reservations []ReservationResponse `json:"reservations"`
+	Tickets      []UserTicketResponse  `json:"tickets"`
+}
+
+// Active session of a user, i.e. a non-expired and non-revoked token.
+type SessionResponse struct {
+	ID        int       `json:"id"         example:"1"`
+	IssuedAt  time.Time `json:"issued_at"  example:"2024-12-01T15:30:00Z"`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-12-02T15:30:00Z"`
+	IPAddress *string   `json:"ip_address" example:"192.168.0.10"`
+	UserAgent *string   `json:"user_agent" example:"Mozilla/5.0"`
+	Current   bool      `json:"current"    example:"true"`
+}
+
+// Collection of active sessions.
+type SessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+// Token for subscribing to the calendar feed of a user.
+type CalendarFeedResponse struct {
+	Token string `json:"token" example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.0.5d41402abc4b2a76b9719d911017c592"`
+	URL   string `json:"url"   example:"/api/calendar/3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.0.5d41402abc4b2a76b9719d911017c592.ics"`
+}
+
+// Shareable link to a reservation, valid until expires_at.
+type ShareReservationResponse struct {
+	Token     string    `json:"token"      example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.1735689600.5d41402abc4b2a76b9719d911017c592"`
+	URL       string    `json:"url"        example:"/api/shared/reservation?token=3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.1735689600.5d41402abc4b2a76b9719d911017c592"`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-12-31T20:00:00Z"`
+}
+
+// Ticket of a shared reservation, without its identifier.
+type SharedTicketResponse struct {
+	Type   string `json:"type"   example:"STANDARD"`
+	Status string `json:"status" example:"SOLD"`
+}
+
+// Reservation as seen through a share link, without personal data of the buyer
+// beyond the first name.
+type SharedReservationResponse struct {
+	ID           string                 `json:"id"            example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"`
+	BuyerName    string                 `json:"buyer_name"    example:"John"`
+	TotalTickets int                    `json:"total_tickets" example:"2"`
+	Status       string                 `json:"status"        example:"CONFIRMED"`
+	Event        EventResponse          `json:"event"`
+	Tickets      []SharedTicketResponse `json:"tickets"`
+}
+
+// Secret of two-factor authentication being enrolled, along with the otpauth URI
+// authenticator apps read from a QR code.
+type TwoFactorEnrollResponse struct {
+	Secret     string `json:"secret"      example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	OTPAuthURL string `json:"otpauth_url" example:"otpauth://totp/Event%20Reservation%20API:admin?issuer=Event+Reservation+API&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+// Login of a user with two-factor authentication, waiting for a code.
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool      `json:"two_factor_required" example:"true"`
+	Challenge         string    `json:"challenge"           example:"8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f.1735689600.5d41402abc4b2a76"`
+	ExpiresAt         time.Time `json:"expires_at"          example:"2024-12-31T20:05:00Z"`
+}
+
+// Ticket after its status was corrected, along with the status it had before.
+type TicketStatusResponse struct {
+	ID             string `json:"id"              example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"`
+	PreviousStatus string `json:"previous_status" example:"CANCELLED"`
+	Status         string `json:"status"          example:"SOLD"`
+}
+
+// Ticket after being checked in at the gate.
+type TicketCheckInResponse struct {
+	ID          string    `json:"id"            example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"`
+	Status      string    `json:"status"        example:"USED"`
+	CheckedInAt time.Time `json:"checked_in_at" example:"2024-12-31T19:15:00Z"`
+	CheckedInBy string    `json:"checked_in_by" example:"8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f"`
+}
+
+// Signed token of a ticket, to be encoded in its QR code.
+type TicketTokenResponse struct {
+	TicketID string `json:"ticket_id" example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"`
+	Token    string `json:"token"     example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.5d41402abc4b2a76"`
+}
+
+// Pricing of a sold ticket. Base price, discount and event price of the type
+// are current, while price is what the ticket was charged at.
+type TicketPricingResponse struct {
+	TicketID       string  `json:"ticket_id"                  example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"`
+	EventID        int     `json:"event_id"                   example:"1"`
+	Type           string  `json:"type"                       example:"STUDENT"`
+	Currency       string  `json:"currency"                   example:"USD"`
+	BasePrice      Money   `json:"base_price"                 example:"99.99"`
+	Discount       float64 `json:"discount"                   example:"0.4"`
+	EventTypePrice *Money  `json:"event_type_price,omitempty" example:"55.00"`
+	Price          Money   `json:"price"                      example:"55.00"`
+}
+
+// Outcome of verifying a single ticket token. Only SOLD tickets are valid.
+type TicketVerificationResponse struct {
+	Token    string `json:"token"               example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b.5d41402abc4b2a76"`
+	Valid    bool   `json:"valid"               example:"false"`
+	TicketID string `json:"ticket_id,omitempty" example:"3f2b1c9e-7d4a-4e1f-9b6a-2c8d5e7f1a3b"`
+	EventID  int    `json:"event_id,omitempty"  example:"1"`
+	Status   string `json:"status,omitempty"    example:"USED"`
+	Reason   string `json:"reason,omitempty"    example:"Ticket has already been checked in."`
+}
+
+// Outcomes of verifying ticket tokens, in the order of the tokens.
+type TicketVerificationsResponse struct {
+	Results []TicketVerificationResponse `json:"results"`
+}
+
+// Counts of the user's reservations, for dashboard badges.
+type ReservationSummaryResponse struct {
+	Total       int            `json:"total"        example:"5"`
+	ByStatus    map[string]int `json:"by_status"`
+	TicketsHeld int            `json:"tickets_held" example:"12"`
+}
+
+// Price of a single ticket within a reservation preview.
+type TicketQuoteResponse struct {
+	Type  string `json:"type"  example:"STUDENT"`
+	Price Money  `json:"price" example:"59.99"`
+}
+
+// Preview of a reservation, computed without creating it.
+type ReservationQuoteResponse struct {
+	EventID  int                   `json:"event_id" example:"1"`
+	Currency string                `json:"currency" example:"USD"`
+	Tickets  []TicketQuoteResponse `json:"tickets"`
+	Total    Money                 `json:"total"    example:"159.98"`
+}
+
+// Outcome of a single user within a bulk import, by its index in the payload.
+type BulkUserResult struct {
+	Index int    `json:"index"           example:"0"`
+	UUID  string `json:"id,omitempty"    example:"123e4567-e89b-12d3-a456-426614174000"`
+	Error string `json:"error,omitempty" example:"Username 'johndoe' is already taken."`
+}
+
+// Result of a bulk user import.
+type BulkCreateUsersResponse struct {
+	Created int              `json:"created" example:"9"`
+	Failed  int              `json:"failed"  example:"1"`
+	Results []BulkUserResult `json:"results"`
+}
+
+// Price of a ticket type for an event, computed from its discount.
+type TicketTypePreviewResponse struct {
+	TypeID    int     `json:"type_id"    example:"2"`
+	Type      string  `json:"type"       example:"STUDENT"`
+	Discount  float64 `json:"discount"   example:"0.4"`
+	EventID   int     `json:"event_id"   example:"1"`
+	Currency  string  `json:"currency"   example:"USD"`
+	BasePrice Money   `json:"base_price" example:"99.99"`
+	Price     Money   `json:"price"      example:"59.99"`
+	Savings   Money   `json:"savings"    example:"40.00"`
+}
+
+// Configuration affecting clients.
+type ConfigResponse struct {
+	Currency                 string `json:"currency"                    example:"USD"`
+	ReservationHoldMinutes   int    `json:"reservation_hold_minutes"    example:"15"`
+	ReservationCutoffHours   int    `json:"reservation_cutoff_hours"    example:"0"`
+	MaxTicketsPerReservation int    `json:"max_tickets_per_reservation" example:"20"`
+	DefaultPageSize          int    `json:"default_page_size"           example:"100"`
+	MaxPageSize              int    `json:"max_page_size"               example:"500"`
+	RegistrationOpen         bool   `json:"registration_open"           example:"true"`
+	ListEnvelope             bool   `json:"list_envelope"               example:"false"`
+}
+
+// Versions of the deployed service and its database.
+type VersionResponse struct {
+	Version         string `json:"version"                    example:"1.4.0"`
+	Commit          string `json:"commit"                     example:"3f9c2a1"`
+	GoVersion       string `json:"go_version"                 example:"go1.22.5"`
+	DatabaseVersion string `json:"database_version,omitempty" example:"PostgreSQL 16.3 on x86_64-pc-linux-gnu"`
+}
+
+// Entry of a status lookup table.
+type StatusResponse struct {
+	ID   int    `json:"id"   example:"1"`
+	Name string `json:"name" example:"SOLD"`
+}
+
+// Collection of statuses.
+type StatusesResponse struct {
+	Statuses []StatusResponse `json:"statuses"`
+}
+
+// Response of a single request within a batch.
+type BatchResponseItem struct {
+	Path   string          `json:"path"   example:"/api/reservations/user/summary"`
+	Status int             `json:"status" example:"200"`
+	Body   json.RawMessage `json:"body"   swaggertype:"object"`
+}
+
+// Responses of a batch, in the order of the requests.
+type BatchResponse struct {
+	Responses []BatchResponseItem `json:"responses"`
+}
+
+// Identity of the current user, along with the permissions of their role.
+type WhoAmIResponse struct {
+	ID             string   `json:"id"                        example:"123e4567-e89b-12d3-a456-426614174000"`
+	Username       string   `json:"username"                  example:"johndoe"`
+	Role           string   `json:"role"                      example:"REGISTERED"`
+	Permissions    []string `json:"permissions"               example:"CREATE_RESERVATION"`
+	ImpersonatedBy string   `json:"impersonated_by,omitempty" example:"8c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f"`
+}
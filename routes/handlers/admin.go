@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/middlewares"
+	"event-reservation-api/models"
+)
+
+// ImpersonateUserHandler issues a token for acting as another user.
+//
+//	@Summary		Impersonate a user (admin only).
+//	@Description	Issue a short-lived token carrying the ID and role of the target user, flagged with the ID of the admin in the impersonated_by claim. Every request made with it is recorded in the audit log. Impersonation tokens cannot be used to impersonate further.
+//	@Tags			admin
+//	@ID				api.impersonateUser
+//	@Produce		json
+//	@Param			userId	path		string							true	"User ID"
+//	@Success		200		{object}	models.ImpersonationResponse	"Impersonation token"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse			"Not Found"
+//	@Failure		409		{object}	models.ErrorResponse			"Conflict"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/admin/impersonate/{userId} [post]
+func ImpersonateUserHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) || isImpersonated(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		adminId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		userId, err := parsePathID(r, "userId")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if userId == adminId {
+			writeErrorResponse(w, http.StatusBadRequest, "Cannot impersonate yourself.")
+			return
+		}
+
+		var username, role string
+		var isActive bool
+		query := `
+			SELECT u.username, r.name, u.is_active
+			FROM users u
+			JOIN roles r ON u.role_id = r.id
+			WHERE u.id = $1
+		`
+		if err := pool.QueryRow(r.Context(), query, userId).
+			Scan(&username, &role, &isActive); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "User not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
+			return
+		}
+		if !isActive {
+			writeErrorResponse(w, http.StatusConflict, "Inactive users cannot be impersonated.")
+			return
+		}
+
+		token, exp, err := middlewares.GenerateImpersonationJWT(userId, role, adminId, jwtSecret)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to generate access token.",
+			)
+			return
+		}
+
+		log.Printf("AUDIT impersonation: admin %s started acting as user %s", adminId, userId)
+		writeJSONResponse(w, http.StatusOK, models.ImpersonationResponse{
+			Token:          token,
+			Expires:        exp,
+			User:           models.UserUsernameID{ID: userId, Username: username},
+			Impersonated:   true,
+			ImpersonatedBy: adminId,
+		})
+	}
+}
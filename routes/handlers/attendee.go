@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// GetEventAttendeesHandler lists users holding sold or used tickets for an event.
+//
+//	@Summary		Get attendees of an event (admin/staff only).
+//	@Description	Retrieve users holding SOLD or USED (checked in) tickets for the event, one entry per user with the number of tickets of each type, ordered by surname. Returned as CSV when requested with format=csv or an Accept header of text/csv, e.g. for check-in sheets.
+//	@ID				api.getEventAttendees
+//	@Tags			events
+//	@Produce		json
+//	@Produce		text/csv
+//	@Param			id		path		string							true	"Event ID"
+//	@Param			format	query		string							false	"Response format, json or csv (default json)"
+//	@Success		200		{object}	models.EventAttendeesResponse	"Attendees of the event"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse			"Not Found"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/events/{id}/attendees [get]
+func GetEventAttendeesHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isStaffOrAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided in the URL.")
+			return
+		}
+
+		asCSV, err := wantsCSV(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response := models.EventAttendeesResponse{Attendees: []models.AttendeeResponse{}}
+		query := `SELECT id FROM Events WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), query, eventID).Scan(&response.EventID); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the event.")
+			return
+		}
+
+		// one row per user and ticket type, users kept together by the ordering
+		query = `
+			SELECT u.id, u.username, u.name, u.surname, u.email, tt.name, COUNT(*)
+			FROM tickets t
+			JOIN ticket_statuses ts ON t.status_id = ts.id
+			JOIN ticket_types tt ON t.type_id = tt.id
+			JOIN reservations r ON t.reservation_id = r.id
+			JOIN users u ON r.user_id = u.id
+			WHERE r.event_id = $1 AND ts.name IN ('SOLD', 'USED')
+			GROUP BY u.id, tt.name
+			ORDER BY u.surname, u.name, u.id, tt.name
+		`
+		rows, err := pool.Query(r.Context(), query, response.EventID)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch attendees.")
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var attendee models.AttendeeResponse
+			var tickets models.AttendeeTicketsResponse
+			if err := rows.Scan(
+				&attendee.UserID, &attendee.Username, &attendee.Name, &attendee.Surname,
+				&attendee.Email, &tickets.Type, &tickets.Count,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse attendees.")
+				return
+			}
+
+			last := len(response.Attendees) - 1
+			if last < 0 || response.Attendees[last].UserID != attendee.UserID {
+				attendee.Tickets = []models.AttendeeTicketsResponse{}
+				response.Attendees = append(response.Attendees, attendee)
+				last++
+			}
+			response.Attendees[last].Tickets = append(response.Attendees[last].Tickets, tickets)
+			response.Attendees[last].TotalTickets += tickets.Count
+		}
+		if err := rows.Err(); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch attendees.")
+			return
+		}
+
+		if !asCSV {
+			writeJSONResponse(w, http.StatusOK, response)
+			return
+		}
+
+		filename := fmt.Sprintf("event-%d-attendees.csv", response.EventID)
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+		w.WriteHeader(http.StatusOK)
+
+		writer := csv.NewWriter(w)
+		_ = writer.Write([]string{
+			"user_id", "username", "name", "surname", "email", "total_tickets", "tickets",
+		})
+		for _, attendee := range response.Attendees {
+			types := make([]string, 0, len(attendee.Tickets))
+			for _, tickets := range attendee.Tickets {
+				types = append(types, fmt.Sprintf("%s:%d", tickets.Type, tickets.Count))
+			}
+			if err := writer.Write([]string{
+				attendee.UserID, attendee.Username, attendee.Name, attendee.Surname,
+				attendee.Email, strconv.Itoa(attendee.TotalTickets), strings.Join(types, ";"),
+			}); err != nil {
+				// client is gone
+				return
+			}
+		}
+		writer.Flush()
+	}
+}
+
+// Determine whether CSV was requested, either by the format query parameter,
+// which takes precedence, or by the Accept header.
+func wantsCSV(r *http.Request) (bool, error) {
+	switch strings.ToLower(r.URL.Query().Get("format")) {
+	case "csv":
+		return true, nil
+	case "json":
+		return false, nil
+	case "":
+		return strings.Contains(r.Header.Get("Accept"), "text/csv"), nil
+	default:
+		return false, fmt.Errorf("Invalid format; must be json or csv.")
+	}
+}
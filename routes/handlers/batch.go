@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"event-reservation-api/models"
+)
+
+// Most sub-requests a single batch may carry.
+const maxBatchRequests = 20
+
+// Response writer collecting the response of a sub-request in memory.
+type batchResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *batchResponseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+// BatchHandler executes multiple read requests in a single round trip.
+//
+//	@Summary		Execute read requests in a batch.
+//	@Description	Run an array of GET sub-requests against the API, returning their responses in the same order. Each sub-request is authorized with the token of the batch, as if it was sent on its own.
+//	@Tags			batch
+//	@ID				api.batch
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		[]models.BatchRequestItem	true	"Sub-requests"
+//	@Success		200		{object}	models.BatchResponse		"Responses of the sub-requests"
+//	@Failure		400		{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		401		{object}	models.ErrorResponse		"Unauthorized"
+//	@Failure		415		{object}	models.ErrorResponse		"Unsupported Media Type"
+//	@Security		BearerAuth
+//	@Router			/batch [post]
+func BatchHandler(router http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items := []models.BatchRequestItem{}
+		if status, err := decodeJSONBody(r, &items); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		if len(items) == 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "No requests provided.")
+			return
+		}
+		if len(items) > maxBatchRequests {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				fmt.Sprintf("At most %d requests can be batched.", maxBatchRequests),
+			)
+			return
+		}
+
+		// validate everything upfront, so a batch either runs or is rejected
+		for i, item := range items {
+			if err := validateBatchRequest(item); err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("[%d] %s", i, err))
+				return
+			}
+		}
+
+		response := models.BatchResponse{
+			Responses: make([]models.BatchResponseItem, 0, len(items)),
+		}
+		for _, item := range items {
+			response.Responses = append(response.Responses, executeBatchRequest(router, r, item))
+		}
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// Verify the sub-request is a read within the API, other than the batch itself.
+func validateBatchRequest(item models.BatchRequestItem) error {
+	method := strings.ToUpper(item.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+	if method != http.MethodGet {
+		return fmt.Errorf("Only GET requests can be batched.")
+	}
+	if !strings.HasPrefix(item.Path, "/api/") {
+		return fmt.Errorf("Path must start with /api/.")
+	}
+	if strings.HasPrefix(item.Path, "/api/batch") {
+		return fmt.Errorf("Batches cannot be nested.")
+	}
+	return nil
+}
+
+// Run the sub-request through the router, with the context and the
+// credentials of the batch request.
+func executeBatchRequest(
+	router http.Handler,
+	r *http.Request,
+	item models.BatchRequestItem,
+) models.BatchResponseItem {
+	result := models.BatchResponseItem{Path: item.Path}
+
+	sub, err := http.NewRequestWithContext(r.Context(), http.MethodGet, item.Path, nil)
+	if err != nil {
+		result.Status = http.StatusBadRequest
+		result.Body, _ = json.Marshal(models.ErrorResponse{Message: "Invalid path."})
+		return result
+	}
+	sub.Header.Set("Authorization", r.Header.Get("Authorization"))
+	sub.RemoteAddr = r.RemoteAddr
+
+	recorder := &batchResponseWriter{header: http.Header{}}
+	router.ServeHTTP(recorder, sub)
+
+	result.Status = recorder.status
+	if result.Status == 0 {
+		result.Status = http.StatusOK
+	}
+
+	// non-JSON bodies, like plain text errors or calendars, are embedded as strings
+	body := bytes.TrimSpace(recorder.body.Bytes())
+	if json.Valid(body) {
+		result.Body = body
+	} else {
+		result.Body, _ = json.Marshal(string(body))
+	}
+	return result
+}
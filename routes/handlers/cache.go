@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"event-reservation-api/models"
+)
+
+// In-memory copy of a frequently requested public read. Event mutations
+// invalidate it; changes of available tickets made by reservations show up
+// once the TTL passes.
+type ttlCache[T any] struct {
+	mu          sync.RWMutex
+	value       T
+	loaded      bool
+	refreshedAt time.Time
+
+	// bumped on invalidation, so loads started before it are not stored
+	generation uint64
+
+	// serializes loads, so concurrent misses query the database once
+	loadMu sync.Mutex
+}
+
+// Unfiltered first page of events, the most requested public listing.
+var publicEventsCache = &ttlCache[[]models.EventResponse]{}
+
+// Seat availability figure shown on the landing page.
+var availabilityCache = &ttlCache[models.AvailabilityStatsResponse]{}
+
+// Drop the cached reads derived from events, after events or their
+// locations changed.
+func invalidateEventCaches() {
+	publicEventsCache.invalidate()
+	availabilityCache.invalidate()
+}
+
+// Return the cached value, if it is younger than the TTL.
+func (c *ttlCache[T]) fresh(ttl time.Duration) (T, uint64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.loaded && time.Since(c.refreshedAt) < ttl {
+		return c.value, c.generation, true
+	}
+	var zero T
+	return zero, c.generation, false
+}
+
+// Return the cached value, loading it on a miss. Callers waiting for the
+// load in progress get its result instead of querying the database again.
+func (c *ttlCache[T]) get(
+	ctx context.Context,
+	ttl time.Duration,
+	load func(ctx context.Context) (T, error),
+) (T, error) {
+	if value, _, ok := c.fresh(ttl); ok {
+		return value, nil
+	}
+
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+
+	// another request may have loaded the value while this one waited
+	value, generation, ok := c.fresh(ttl)
+	if ok {
+		return value, nil
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation == generation {
+		c.value = value
+		c.loaded = true
+		c.refreshedAt = time.Now()
+	}
+	return value, nil
+}
+
+// Drop the cached value, so the next request reads it from the database.
+func (c *ttlCache[T]) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero T
+	c.value = zero
+	c.loaded = false
+	c.generation++
+}
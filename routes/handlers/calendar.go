@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// Format of date-time values within the calendar, in UTC.
+const calendarTimeFormat = "20060102T150405Z"
+
+// GetCurrentUserCalendarHandler returns the calendar of the current user.
+//
+//	@Summary		Calendar of events reserved by the current user.
+//	@Description	Retrieve an iCalendar (RFC 5545) feed of upcoming events the current user holds active reservations for.
+//	@Tags			reservations
+//	@ID				api.getCurrentUserCalendar
+//	@Produce		text/calendar
+//	@Success		200	{string}	string					"iCalendar feed"
+//	@Failure		401	{object}	models.ErrorResponse	"Unauthorized"
+//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/user/calendar.ics [get]
+func GetCurrentUserCalendarHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+		writeUserCalendar(w, r, pool, userId)
+	}
+}
+
+// GetCalendarFeedTokenHandler returns the calendar feed token of the current user.
+//
+//	@Summary		Calendar feed token of the current user.
+//	@Description	Retrieve a signed token, which lets calendar applications subscribe to the feed of the current user without sending the Authorization header. The token stays valid until it is rotated.
+//	@Tags			reservations
+//	@ID				api.getCalendarFeedToken
+//	@Produce		json
+//	@Success		200	{object}	models.CalendarFeedResponse	"Calendar feed token"
+//	@Failure		401	{object}	models.ErrorResponse		"Unauthorized"
+//	@Failure		403	{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/user/calendar/token [get]
+func GetCalendarFeedTokenHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		// the feed outlives the short impersonation tokens, so admins cannot take it
+		if isImpersonated(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Calendar feed tokens cannot be issued while impersonating.",
+			)
+			return
+		}
+
+		var version int
+		query := `SELECT calendar_feed_version FROM users WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), query, userId).Scan(&version); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "User not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
+			return
+		}
+		writeCalendarFeedToken(w, userId, version, jwtSecret)
+	}
+}
+
+// RotateCalendarFeedTokenHandler replaces the calendar feed token of the current user.
+//
+//	@Summary		Rotate the calendar feed token of the current user.
+//	@Description	Issue a new calendar feed token, revoking the previous ones.
+//	@Tags			reservations
+//	@ID				api.rotateCalendarFeedToken
+//	@Produce		json
+//	@Success		200	{object}	models.CalendarFeedResponse	"New calendar feed token"
+//	@Failure		401	{object}	models.ErrorResponse		"Unauthorized"
+//	@Failure		403	{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/user/calendar/token [post]
+func RotateCalendarFeedTokenHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		// as when issuing, a rotated token must not reach the impersonating admin
+		if isImpersonated(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Calendar feed tokens cannot be issued while impersonating.",
+			)
+			return
+		}
+
+		var version int
+		query := `
+			UPDATE users SET calendar_feed_version = calendar_feed_version + 1
+			WHERE id = $1
+			RETURNING calendar_feed_version
+		`
+		if err := pool.QueryRow(r.Context(), query, userId).Scan(&version); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "User not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to rotate the token.")
+			return
+		}
+		writeCalendarFeedToken(w, userId, version, jwtSecret)
+	}
+}
+
+// GetCalendarFeedHandler returns the calendar of the user the feed token was signed for.
+//
+//	@Summary		Calendar feed, authenticated by a signed token.
+//	@Description	Retrieve an iCalendar (RFC 5545) feed of upcoming events, for the user the feed token was issued to. Tokens are revoked by rotating them, changing the password or anonymizing the user.
+//	@Tags			reservations
+//	@ID				api.getCalendarFeed
+//	@Produce		text/calendar
+//	@Param			token	path		string					true	"Calendar feed token"
+//	@Success		200		{string}	string					"iCalendar feed"
+//	@Failure		404		{object}	models.ErrorResponse	"Not Found"
+//	@Failure		500		{object}	models.ErrorResponse	"Internal Server Error"
+//	@Router			/calendar/{token}.ics [get]
+func GetCalendarFeedHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, err := parsePathID(r, "token")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// invalid signatures are indistinguishable from missing feeds
+		userId, version, ok := verifyCalendarToken(token, jwtSecret)
+		if !ok {
+			writeErrorResponse(w, http.StatusNotFound, "Calendar not found.")
+			return
+		}
+
+		// tokens signed before the last rotation are revoked
+		var valid bool
+		query := `
+			SELECT EXISTS(SELECT 1 FROM users WHERE id = $1 AND calendar_feed_version = $2)
+		`
+		if err := pool.QueryRow(r.Context(), query, userId, version).Scan(&valid); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the calendar.")
+			return
+		}
+		if !valid {
+			writeErrorResponse(w, http.StatusNotFound, "Calendar not found.")
+			return
+		}
+		writeUserCalendar(w, r, pool, userId)
+	}
+}
+
+// Write the calendar feed token of the user, signed with its current version.
+func writeCalendarFeedToken(w http.ResponseWriter, userID string, version int, secret string) {
+	token := signCalendarToken(userID, version, secret)
+	writeJSONResponse(w, http.StatusOK, models.CalendarFeedResponse{
+		Token: token,
+		URL:   "/api/calendar/" + token + ".ics",
+	})
+}
+
+// Sign the user ID along with the version of its feed, producing a token for
+// the calendar feed. Bumping the version revokes the tokens signed before.
+func signCalendarToken(userID string, version int, secret string) string {
+	payload := fmt.Sprintf("%s.%d", userID, version)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("calendar:" + payload))
+	return payload + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify the calendar feed token, returning the user ID and feed version it
+// was signed for.
+func verifyCalendarToken(token, secret string) (string, int, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", 0, false
+	}
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, false
+	}
+	expected := signCalendarToken(parts[0], version, secret)
+	return parts[0], version, hmac.Equal([]byte(token), []byte(expected))
+}
+
+// Write the calendar of upcoming events the user has active reservations for.
+func writeUserCalendar(w http.ResponseWriter, r *http.Request, pool *pgxpool.Pool, userID string) {
+	events, err := fetchUserUpcomingEvents(r.Context(), pool, userID)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch events.")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="events.ics"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(buildCalendar(events, time.Now())))
+}
+
+// Fetch upcoming events, for which the user holds reservations that were not cancelled.
+func fetchUserUpcomingEvents(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	userID string,
+) ([]models.EventResponse, error) {
+	query := `
+		SELECT DISTINCT e.id, e.name, e.date, l.stadium, l.address, l.country
+		FROM reservations r
+		JOIN reservation_statuses rs ON r.status_id = rs.id
+		JOIN events e ON r.event_id = e.id
+		JOIN locations l ON e.location_id = l.id
+		WHERE r.user_id = $1 AND rs.name <> 'CANCELLED' AND e.date >= $2
+		ORDER BY e.date, e.id
+	`
+	rows, err := pool.Query(ctx, query, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.EventResponse{}
+	for rows.Next() {
+		var event models.EventResponse
+		if err := rows.Scan(
+			&event.ID, &event.Name, &event.Date,
+			&event.Location.Stadium, &event.Location.Address, &event.Location.Country,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// Build an iCalendar (RFC 5545) document, with a VEVENT per event.
+func buildCalendar(events []models.EventResponse, now time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//event-reservation-api//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	for _, event := range events {
+		location := strings.Join([]string{
+			event.Location.Stadium,
+			event.Location.Address,
+			event.Location.Country,
+		}, ", ")
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:event-%d@event-reservation-api", event.ID),
+			"DTSTAMP:"+now.UTC().Format(calendarTimeFormat),
+			"DTSTART:"+event.Date.UTC().Format(calendarTimeFormat),
+			"SUMMARY:"+escapeCalendarText(event.Name),
+			"LOCATION:"+escapeCalendarText(location),
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(foldCalendarLine(line))
+		sb.WriteString("\r\n")
+	}
+	return sb.String()
+}
+
+// Escape special characters of a calendar text value.
+func escapeCalendarText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// Fold lines longer than 75 octets, without splitting multi-byte characters.
+func foldCalendarLine(line string) string {
+	const limit = 75
+
+	var sb strings.Builder
+	length := 0
+	for _, char := range line {
+		size := len(string(char))
+		if length+size > limit {
+			sb.WriteString("\r\n ")
+			// continuation lines start with a space, which counts towards the limit
+			length = 1
+		}
+		sb.WriteRune(char)
+		length += size
+	}
+	return sb.String()
+}
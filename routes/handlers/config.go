@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// Configuration of the handlers, read once at startup.
+var (
+	// Number of items returned by list endpoints when no limit is provided.
+	defaultPageSize = 100
+
+	// Largest limit a client is allowed to request.
+	maxPageSize = 500
+
+	// Most tickets a single reservation may hold, unless the event overrides it.
+	maxTicketsPerReservation = 20
+
+	// Whether cancelled tickets are listed when include_cancelled is not provided.
+	includeCancelledTickets = true
+
+	// Whether non-admins can create users; otherwise registration is invite-only.
+	registrationOpen = true
+
+	// Whether list endpoints wrap their items in a data and meta envelope.
+	listEnvelope = false
+
+	// ISO 4217 code of the currency all prices are expressed in.
+	currency = "USD"
+
+	// Hours before an event online sales close, unless the event overrides it.
+	reservationCutoffHours = 0
+
+	// Minutes a pending reservation holds its tickets, before it is cancelled.
+	reservationHoldMinutes = 15
+
+	// Minutes before the confirmation of a reservation can be sent again.
+	confirmationResendMinutes = 5
+
+	// Seconds the unfiltered first page of public events is cached for.
+	eventsCacheSeconds = 5
+
+	// Minutes ahead the expiring reservations worklist looks, unless within is provided.
+	expiringHoldWindowMinutes = 5
+
+	// Hours a shared reservation link stays valid.
+	shareLinkHours = 72
+
+	// Most events a single recurring series may generate.
+	maxSeriesEvents = 52
+
+	// Key TOTP secrets are encrypted with, derived from TOTP_ENCRYPTION_KEY.
+	// Two-factor authentication is unavailable without it.
+	totpEncryptionKey []byte
+
+	// Lowercase usernames only admins are allowed to claim.
+	reservedUsernames = map[string]bool{"admin": true, "root": true, "system": true, "api": true}
+)
+
+// Retrieve an environment variable as a positive integer or return a default value.
+func getEnvAsPositiveInt(key string, defaultValue int) int {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil || value <= 0 {
+		log.Printf("Invalid value for %s, defaulting to %d.", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
+
+// Read the handler configuration from the environment.
+func InitConfig() {
+	maxPageSize = getEnvAsPositiveInt("MAX_PAGE_SIZE", maxPageSize)
+	defaultPageSize = getEnvAsPositiveInt("DEFAULT_PAGE_SIZE", defaultPageSize)
+	maxTicketsPerReservation = getEnvAsPositiveInt(
+		"MAX_TICKETS_PER_RESERVATION",
+		maxTicketsPerReservation,
+	)
+	reservationHoldMinutes = getEnvAsPositiveInt(
+		"RESERVATION_HOLD_MINUTES",
+		reservationHoldMinutes,
+	)
+	confirmationResendMinutes = getEnvAsPositiveInt(
+		"CONFIRMATION_RESEND_MINUTES",
+		confirmationResendMinutes,
+	)
+	eventsCacheSeconds = getEnvAsPositiveInt("EVENTS_CACHE_SECONDS", eventsCacheSeconds)
+	expiringHoldWindowMinutes = getEnvAsPositiveInt(
+		"EXPIRING_HOLD_WINDOW_MINUTES",
+		expiringHoldWindowMinutes,
+	)
+	maxSeriesEvents = getEnvAsPositiveInt("MAX_SERIES_EVENTS", maxSeriesEvents)
+	shareLinkHours = getEnvAsPositiveInt("SHARE_LINK_HOURS", shareLinkHours)
+	if value := os.Getenv("RESERVATION_CUTOFF_HOURS"); value != "" {
+		hours, err := strconv.Atoi(value)
+		if err != nil || hours < 0 {
+			log.Printf(
+				"Invalid value for RESERVATION_CUTOFF_HOURS, defaulting to %d.",
+				reservationCutoffHours,
+			)
+		} else {
+			reservationCutoffHours = hours
+		}
+	}
+	if value := os.Getenv("CURRENCY"); value != "" {
+		if len(value) != 3 || strings.ToUpper(value) != value {
+			log.Printf("Invalid value for CURRENCY, defaulting to %s.", currency)
+		} else {
+			currency = value
+		}
+	}
+	if value := os.Getenv("INCLUDE_CANCELLED_TICKETS"); value != "" {
+		include, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf(
+				"Invalid value for INCLUDE_CANCELLED_TICKETS, defaulting to %t.",
+				includeCancelledTickets,
+			)
+		} else {
+			includeCancelledTickets = include
+		}
+	}
+	if value := os.Getenv("REGISTRATION_OPEN"); value != "" {
+		open, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf(
+				"Invalid value for REGISTRATION_OPEN, defaulting to %t.",
+				registrationOpen,
+			)
+		} else {
+			registrationOpen = open
+		}
+	}
+	if value := os.Getenv("LIST_ENVELOPE"); value != "" {
+		envelope, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Invalid value for LIST_ENVELOPE, defaulting to %t.", listEnvelope)
+		} else {
+			listEnvelope = envelope
+		}
+	}
+	// kept apart from the JWT secret, so rotating that one keeps enrollments
+	if value := os.Getenv("TOTP_ENCRYPTION_KEY"); value != "" {
+		sum := sha256.Sum256([]byte(value))
+		totpEncryptionKey = sum[:]
+	} else {
+		log.Println(
+			"WARNING: TOTP_ENCRYPTION_KEY not set. Two-factor authentication is unavailable.",
+		)
+	}
+	if defaultPageSize > maxPageSize {
+		log.Printf(
+			"DEFAULT_PAGE_SIZE exceeds MAX_PAGE_SIZE, defaulting to %d.",
+			maxPageSize,
+		)
+		defaultPageSize = maxPageSize
+	}
+}
+
+// Load the reserved usernames, either as a comma separated list from
+// RESERVED_USERNAMES or from the file at RESERVED_USERNAMES_FILE, one per line.
+// Lines starting with # are skipped. Without either, the built-in list is kept.
+func InitReservedUsernames() error {
+	var names []string
+	if value := os.Getenv("RESERVED_USERNAMES"); value != "" {
+		names = strings.Split(value, ",")
+	} else if path := os.Getenv("RESERVED_USERNAMES_FILE"); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read the reserved usernames: %w", err)
+		}
+		names = strings.Split(string(content), "\n")
+	} else {
+		return nil
+	}
+
+	reservedUsernames = map[string]bool{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+		reservedUsernames[name] = true
+	}
+	return nil
+}
+
+// Check if the username is reserved, regardless of its case.
+func isReservedUsername(username string) bool {
+	return reservedUsernames[strings.ToLower(strings.TrimSpace(username))]
+}
+
+// GetConfigHandler returns the configuration clients have to follow.
+//
+//	@Summary		Get the public configuration.
+//	@Description	Retrieve limits and settings which affect clients, such as the time pending reservations hold their tickets.
+//	@Tags			config
+//	@ID				api.getConfig
+//	@Produce		json
+//	@Success		200	{object}	models.ConfigResponse	"Configuration"
+//	@Router			/config [get]
+func GetConfigHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, models.ConfigResponse{
+			Currency:                 currency,
+			ReservationHoldMinutes:   reservationHoldMinutes,
+			ReservationCutoffHours:   reservationCutoffHours,
+			MaxTicketsPerReservation: maxTicketsPerReservation,
+			DefaultPageSize:          defaultPageSize,
+			MaxPageSize:              maxPageSize,
+			RegistrationOpen:         registrationOpen,
+			ListEnvelope:             listEnvelope,
+		})
+	}
+}
+
+// Parse limit and offset query parameters.
+// Limits above the configured maximum are rejected rather than clamped.
+func parsePagination(r *http.Request) (int, int, error) {
+	limit := defaultPageSize
+	offset := 0
+
+	if param := r.URL.Query().Get("limit"); param != "" {
+		value, err := strconv.Atoi(param)
+		if err != nil || value <= 0 {
+			return 0, 0, fmt.Errorf("Invalid limit; must be a positive integer.")
+		}
+		if value > maxPageSize {
+			return 0, 0, fmt.Errorf("Invalid limit; must not exceed %d.", maxPageSize)
+		}
+		limit = value
+	}
+
+	if param := r.URL.Query().Get("offset"); param != "" {
+		value, err := strconv.Atoi(param)
+		if err != nil || value < 0 {
+			return 0, 0, fmt.Errorf("Invalid offset; must be a non-negative integer.")
+		}
+		offset = value
+	}
+
+	return limit, offset, nil
+}
+
+// Parse the include_tickets query parameter of reservation lists, true unless
+// provided otherwise.
+func parseIncludeTickets(r *http.Request) (bool, error) {
+	param := r.URL.Query().Get("include_tickets")
+	if param == "" {
+		return true, nil
+	}
+	include, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, fmt.Errorf("Invalid value for include_tickets.")
+	}
+	return include, nil
+}
+
+// Page of a list, along with the FROM and WHERE clauses of its query and
+// their arguments, counting all of its items when the list is enveloped.
+type listPage struct {
+	Limit      int
+	Offset     int
+	NextCursor string
+	FromClause string
+	Args       []interface{}
+}
+
+// Write the list in its own response model or, when the list envelope is
+// enabled, as data along with the number of items.
+func writeListResponse[T any](w http.ResponseWriter, legacy interface{}, items []T) {
+	if !listEnvelope {
+		writeJSONResponse(w, http.StatusOK, legacy)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, models.ListResponse[T]{
+		Data: items,
+		Meta: models.ListMeta{Total: len(items)},
+	})
+}
+
+// Write a page of the list in its own response model or, when the list
+// envelope is enabled, as data along with the pagination metadata. The total
+// is only counted for the envelope.
+func writePageResponse[T any](
+	w http.ResponseWriter,
+	r *http.Request,
+	pool *pgxpool.Pool,
+	legacy interface{},
+	items []T,
+	page listPage,
+) {
+	if !listEnvelope {
+		writeJSONResponse(w, http.StatusOK, legacy)
+		return
+	}
+
+	var total int
+	query := `SELECT COUNT(*) ` + page.FromClause
+	if err := pool.QueryRow(r.Context(), query, page.Args...).Scan(&total); err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to count the items.")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, models.ListResponse[T]{
+		Data: items,
+		Meta: models.ListMeta{
+			Total:      total,
+			Limit:      &page.Limit,
+			Offset:     &page.Offset,
+			NextCursor: page.NextCursor,
+		},
+	})
+}
+
+// Parse the include_cancelled query parameter, falling back to the configured default.
+func parseIncludeCancelled(r *http.Request) (bool, error) {
+	param := r.URL.Query().Get("include_cancelled")
+	if param == "" {
+		return includeCancelledTickets, nil
+	}
+	include, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, fmt.Errorf("Invalid value for include_cancelled.")
+	}
+	return include, nil
+}
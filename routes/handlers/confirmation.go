@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// Times confirmations were last sent, keyed by the reservation ID.
+type resendLimiter struct {
+	mu     sync.Mutex
+	sentAt map[string]time.Time
+}
+
+var confirmationResends = &resendLimiter{sentAt: map[string]time.Time{}}
+
+// Reserve a send of the key, unless one happened within the interval, in
+// which case the time left until the next one is allowed is returned.
+func (l *resendLimiter) reserve(key string, interval time.Duration) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for k, sentAt := range l.sentAt {
+		if now.Sub(sentAt) >= interval {
+			delete(l.sentAt, k)
+		}
+	}
+	if sentAt, ok := l.sentAt[key]; ok {
+		return interval - now.Sub(sentAt), false
+	}
+	l.sentAt[key] = now
+	return 0, true
+}
+
+// Release the reservation of the key, after the send failed.
+func (l *resendLimiter) release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.sentAt, key)
+}
+
+// ResendConfirmationHandler sends the confirmation of a reservation again.
+//
+//	@Summary		Resend a reservation confirmation (owner/admin only).
+//	@Description	Send the confirmation message of a confirmed reservation to its buyer again. Limited to one send per reservation within the configured interval.
+//	@Tags			reservations
+//	@ID				api.resendConfirmation
+//	@Produce		json
+//	@Param			id	path		string					true	"Reservation ID"
+//	@Success		200	{object}	models.SuccessResponse	"Confirmation sent"
+//	@Failure		403	{object}	models.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse	"Not Found"
+//	@Failure		409	{object}	models.ErrorResponse	"Conflict"
+//	@Failure		429	{object}	models.ErrorResponse	"Too Many Requests"
+//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/{id}/resend-confirmation [post]
+func ResendConfirmationHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reservationId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var ownerId, status string
+		query := `
+			SELECT r.user_id, rs.name
+			FROM reservations r
+			JOIN reservation_statuses rs ON r.status_id = rs.id
+			WHERE r.id = $1
+		`
+		if err := pool.QueryRow(r.Context(), query, reservationId).
+			Scan(&ownerId, &status); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+				return
+			}
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the reservation.",
+			)
+			return
+		}
+
+		// permissions, checked against the owner of the reservation
+		if !isAdmin(r) && !isOwner(r, ownerId) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		if status != "CONFIRMED" {
+			writeErrorResponse(
+				w,
+				http.StatusConflict,
+				fmt.Sprintf("Only confirmed reservations have a confirmation; status is %s.", status),
+			)
+			return
+		}
+
+		interval := time.Duration(confirmationResendMinutes) * time.Minute
+		if wait, ok := confirmationResends.reserve(reservationId, interval); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeErrorResponse(
+				w,
+				http.StatusTooManyRequests,
+				"Confirmation was sent recently; try again later.",
+			)
+			return
+		}
+
+		if err := sendConfirmation(r.Context(), pool, reservationId); err != nil {
+			confirmationResends.release(reservationId)
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to send the confirmation.")
+			return
+		}
+
+		writeJSONResponse(
+			w,
+			http.StatusOK,
+			models.SuccessResponse{Message: "Confirmation sent successfully."},
+		)
+	}
+}
+
+// Render the confirmation of the reservation and send it to the buyer. There
+// is no mail transport yet, so the message is written to the server log.
+func sendConfirmation(ctx context.Context, pool *pgxpool.Pool, reservationID string) error {
+	data := ConfirmationTemplateData{ReservationID: reservationID, Currency: currency}
+
+	var eventID int
+	query := `
+		SELECT r.event_id, u.id, u.name, u.surname, u.username, u.email
+		FROM reservations r
+		JOIN users u ON r.user_id = u.id
+		WHERE r.id = $1
+	`
+	if err := pool.QueryRow(ctx, query, reservationID).Scan(
+		&eventID,
+		&data.Buyer.ID, &data.Buyer.Name, &data.Buyer.Surname,
+		&data.Buyer.Username, &data.Buyer.Email,
+	); err != nil {
+		return err
+	}
+
+	event, err := fetchEvent(ctx, pool, "e.id = $1", eventID)
+	if err != nil {
+		return err
+	}
+	data.Event = event
+
+	tickets, err := fetchTickets(ctx, pool, reservationID, false)
+	if err != nil {
+		return err
+	}
+	data.Tickets = tickets
+	for _, ticket := range tickets {
+		data.Total += ticket.Price
+	}
+
+	message, err := RenderConfirmation(data)
+	if err != nil {
+		return err
+	}
+	log.Printf("Confirmation of reservation %s to %s:\n%s", reservationID, data.Buyer.Email, message)
+	return nil
+}
@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/gorilla/mux"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -16,183 +20,1396 @@ import (
 // GetEventsHandler lists all events in the database.
 //
 //	@Summary		Get all events
-//	@Description	Retrieve a list of all events with their details and locations.
+//	@Description	Retrieve a list of all public events with their details and locations. Unlisted and private events are left out.
 //	@ID				api.getEvents
 //	@Tags			events
 //	@Produce		json
-//	@Success		200	{object}	models.EventsResponse	"List of events"
-//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
-//	@Failure		404	{object}	models.ErrorResponse	"Not Found"
+//	@Param			available	query		bool					false	"Only events with (true) or without (false) available tickets"
+//	@Param			fields		query		string					false	"Comma-separated top-level fields to return, e.g. id,name,date"
+//	@Param			limit		query		int						false	"Maximum number of events"
+//	@Param			offset		query		int						false	"Number of events to skip"
+//	@Param			tz			query		string					false	"IANA timezone of returned dates, e.g. America/New_York (default UTC)"
+//	@Success		200			{object}	models.EventsResponse	"List of events"
+//	@Failure		400			{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		500			{object}	models.ErrorResponse	"Internal Server Error"
 //	@Router			/events [get]
 func GetEventsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// build the filters from query parameters
+		whereClause, args, err := getEventsWhereClause(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		limit, offset, err := parsePagination(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		page := listPage{
+			Limit:      limit,
+			Offset:     offset,
+			FromClause: "FROM events e JOIN locations l ON e.location_id = l.id " + whereClause,
+			Args:       args,
+		}
+		args = append(args[:len(args):len(args)], limit, offset)
+
+		fields, err := parseFields(r, eventFields)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		loc, err := parseTimezone(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// the unfiltered first page is served from the cache
+		var events []models.EventResponse
+		if r.URL.RawQuery == "" {
+			events, err = publicEventsCache.get(
+				r.Context(),
+				time.Duration(eventsCacheSeconds)*time.Second,
+				func(ctx context.Context) ([]models.EventResponse, error) {
+					return fetchEvents(ctx, pool, whereClause, "ORDER BY e.date ASC", args)
+				},
+			)
+		} else {
+			events, err = fetchEvents(r.Context(), pool, whereClause, "ORDER BY e.date ASC", args)
+		}
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch events.")
+			return
+		}
+		events = localizeEvents(events, loc)
+
+		// only the selected fields of each event
+		if fields != nil {
+			selected := make([]map[string]json.RawMessage, 0, len(events))
+			for _, event := range events {
+				selectedEvent, err := selectFields(event, fields)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse events.")
+					return
+				}
+				selected = append(selected, selectedEvent)
+			}
+			writePageResponse(
+				w, r, pool,
+				map[string]interface{}{"events": selected}, selected, page,
+			)
+			return
+		}
+
+		events_response := models.EventsResponse{Events: events}
+		writePageResponse(w, r, pool, events_response, events, page)
+	}
+}
+
+// ExportEventsHandler exports events as JSON or CSV.
+//
+//	@Summary		Export events (admin only).
+//	@Description	Stream all events with their locations and availability, ordered by date. Accepts the same filters as the event list, without pagination, and includes unlisted and private events. Returned as CSV when requested with format=csv or an Accept header of text/csv.
+//	@ID				api.exportEvents
+//	@Tags			events
+//	@Produce		json
+//	@Produce		text/csv
+//	@Param			available	query		bool					false	"Filter by availability of tickets"
+//	@Param			format		query		string					false	"Response format, json or csv (default json)"
+//	@Param			tz			query		string					false	"IANA timezone of returned dates, e.g. America/New_York (default UTC)"
+//	@Success		200			{object}	models.EventsResponse	"All matching events"
+//	@Failure		400			{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		403			{object}	models.ErrorResponse	"Forbidden"
+//	@Failure		500			{object}	models.ErrorResponse	"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/events/export [get]
+func ExportEventsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		conditions, args, err := getEventFilters(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		whereClause := ""
+		if len(conditions) > 0 {
+			whereClause = "WHERE " + strings.Join(conditions, " AND ")
+		}
+
+		asCSV, err := wantsCSV(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		loc, err := parseTimezone(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// the query is bound to the request, so a disconnect cancels it
 		query := `
 			SELECT
-				e.id, e.name, e.date, e.price, e.available_tickets,
+				e.id, e.name, COALESCE(e.slug, ''), e.date, e.price, e.available_tickets,
+				e.sale_starts_at, e.visibility, e.reservation_cutoff_hours,
 				l.id, l.stadium, l.address, l.country, l.capacity
 			FROM events e
 			JOIN locations l ON e.location_id = l.id
-			ORDER BY e.date ASC
-		`
-
-		// query the database for events
-		rows, err := pool.Query(r.Context(), query)
+		` + whereClause + " ORDER BY e.date ASC, e.id ASC"
+		rows, err := pool.Query(r.Context(), query, args...)
 		if err != nil {
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch events.")
 			return
 		}
 		defer rows.Close()
 
-		// results in event and attached location information
-		events := []models.EventResponse{}
-		for rows.Next() {
+		var writer *csv.Writer
+		if asCSV {
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="events.csv"`)
+			w.WriteHeader(http.StatusOK)
+
+			writer = csv.NewWriter(w)
+			_ = writer.Write([]string{
+				"id", "name", "slug", "date", "price", "currency", "available_tickets",
+				"sale_starts_at", "visibility", "reservation_cutoff_hours", "location_id",
+				"stadium", "address", "country", "capacity",
+			})
+		} else {
+			// written piece by piece, in the shape of the event list
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Disposition", `attachment; filename="events.json"`)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"events":[`))
+		}
+
+		for first := true; rows.Next(); first = false {
 			var event models.EventResponse
 			var location models.LocationResponse
-
+			var cutoffHours *int
 			if err := rows.Scan(
 				&event.ID,
 				&event.Name,
+				&event.Slug,
 				&event.Date,
 				&event.Price,
 				&event.AvailableTickets,
+				&event.SaleStartsAt,
+				&event.Visibility,
+				&cutoffHours,
 				&location.ID,
 				&location.Stadium,
 				&location.Address,
 				&location.Country,
 				&location.Capacity,
 			); err != nil {
-				if err == pgx.ErrNoRows {
-					writeErrorResponse(w, http.StatusNotFound, "No events in the database.")
+				// headers are already sent, the truncated file is all we can do
+				log.Printf("Failed to export events: %v", err)
+				return
+			}
+			event.Location = location
+			event.Currency = currency
+			event.OnSale = isOnSale(event.SaleStartsAt)
+			setSalesCutoff(&event, cutoffHours)
+			event.Date = event.Date.In(loc)
+
+			if asCSV {
+				saleStartsAt := ""
+				if event.SaleStartsAt != nil {
+					saleStartsAt = event.SaleStartsAt.In(loc).Format(time.RFC3339)
+				}
+				price, _ := event.Price.MarshalJSON()
+				if err := writer.Write([]string{
+					strconv.Itoa(event.ID), event.Name, event.Slug,
+					event.Date.Format(time.RFC3339), string(price), event.Currency,
+					strconv.Itoa(event.AvailableTickets), saleStartsAt, event.Visibility,
+					strconv.Itoa(*event.ReservationCutoffHours), strconv.Itoa(location.ID), location.Stadium, location.Address,
+					location.Country, strconv.Itoa(location.Capacity),
+				}); err != nil {
+					// client is gone
 					return
 				}
-				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse events.")
+				continue
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Printf("Failed to export events: %v", err)
+				return
+			}
+			if !first {
+				data = append([]byte(","), data...)
+			}
+			if _, err := w.Write(data); err != nil {
+				// client is gone
 				return
 			}
+		}
+		if err := rows.Err(); err != nil {
+			log.Printf("Failed to export events: %v", err)
+			return
+		}
 
-			// append the location and event to the list
-			event.Location = location
-			events = append(events, event)
+		if asCSV {
+			writer.Flush()
+			return
 		}
-		events_response := models.EventsResponse{Events: events}
-		writeJSONResponse(w, http.StatusOK, events_response)
+		_, _ = w.Write([]byte("]}"))
+	}
+}
+
+// Fetch events matching the where clause, in the given order. The last two
+// arguments are the limit and offset.
+func fetchEvents(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	whereClause string,
+	orderClause string,
+	args []interface{},
+) ([]models.EventResponse, error) {
+	query := `
+		SELECT
+			e.id, e.name, COALESCE(e.slug, ''), e.date, e.price, e.available_tickets,
+			e.sale_starts_at, e.visibility, e.reservation_cutoff_hours,
+			l.id, l.stadium, l.address, l.country, l.capacity
+		FROM events e
+		JOIN locations l ON e.location_id = l.id
+	` + whereClause + " " + orderClause + fmt.Sprintf(`
+		LIMIT $%d OFFSET $%d
+	`, len(args)-1, len(args))
+
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// results in event and attached location information
+	events := []models.EventResponse{}
+	for rows.Next() {
+		var event models.EventResponse
+		var location models.LocationResponse
+		var cutoffHours *int
+
+		if err := rows.Scan(
+			&event.ID,
+			&event.Name,
+			&event.Slug,
+			&event.Date,
+			&event.Price,
+			&event.AvailableTickets,
+			&event.SaleStartsAt,
+			&event.Visibility,
+			&cutoffHours,
+			&location.ID,
+			&location.Stadium,
+			&location.Address,
+			&location.Country,
+			&location.Capacity,
+		); err != nil {
+			return nil, err
+		}
+
+		event.Location = location
+		event.Currency = currency
+		event.OnSale = isOnSale(event.SaleStartsAt)
+		setSalesCutoff(&event, cutoffHours)
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// Most days back the recent events listing may reach.
+const maxRecentEventDays = 90
+
+// GetRecentEventsHandler lists upcoming events created within the last days.
+//
+//	@Summary		Get recently created events
+//	@Description	Retrieve upcoming events created within the given number of days, newest first.
+//	@ID				api.getRecentEvents
+//	@Tags			events
+//	@Produce		json
+//	@Param			days	query		int						false	"Days back to look (default 7, max 90)"
+//	@Param			limit	query		int						false	"Maximum number of events"
+//	@Param			offset	query		int						false	"Number of events to skip"
+//	@Param			tz		query		string					false	"IANA timezone of returned dates, e.g. America/New_York (default UTC)"
+//	@Success		200		{object}	models.EventsResponse	"List of events"
+//	@Failure		400		{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		500		{object}	models.ErrorResponse	"Internal Server Error"
+//	@Router			/events/recent [get]
+func GetRecentEventsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		days := 7
+		if param := r.URL.Query().Get("days"); param != "" {
+			value, err := strconv.Atoi(param)
+			if err != nil || value <= 0 || value > maxRecentEventDays {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					fmt.Sprintf("Invalid days; must be between 1 and %d.", maxRecentEventDays),
+				)
+				return
+			}
+			days = value
+		}
+
+		limit, offset, err := parsePagination(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		loc, err := parseTimezone(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// past events are of no interest, however recently they were added
+		whereClause := `
+			WHERE e.created_at >= NOW() - make_interval(days => $1) AND e.date > NOW()
+				AND e.visibility = 'PUBLIC'
+		`
+		events, err := fetchEvents(
+			r.Context(), pool,
+			whereClause, "ORDER BY e.created_at DESC, e.id DESC",
+			[]interface{}{days, limit, offset},
+		)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch events.")
+			return
+		}
+
+		events = localizeEvents(events, loc)
+		writePageResponse(w, r, pool, models.EventsResponse{Events: events}, events, listPage{
+			Limit:      limit,
+			Offset:     offset,
+			FromClause: "FROM events e " + whereClause,
+			Args:       []interface{}{days},
+		})
+	}
+}
+
+// GetEventByIDHandler returns a single event by ID.
+//
+//	@Summary		Get an event by ID
+//	@Description	Retrieve an event with its details and location. Private events are only shown to admins, staff and users holding a reservation for them; anyone else gets 404.
+//	@ID				api.getEventByID
+//	@Tags			events
+//	@Produce		json
+//	@Param			id					path		string					true	"Event ID"
+//	@Param			fields				query		string					false	"Comma-separated top-level fields to return, e.g. id,name,date"
+//	@Param			tz					query		string					false	"IANA timezone of returned dates, e.g. America/New_York (default UTC)"
+//	@Param			If-Modified-Since	header		string					false	"Time of the cached copy, answered with 304 if unchanged"
+//	@Success		200					{object}	models.EventResponse	"Event details"
+//	@Success		304					"Not Modified"
+//	@Failure		400					{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		500					{object}	models.ErrorResponse	"Internal Server Error"
+//	@Failure		404					{object}	models.ErrorResponse	"Not Found"
+//	@Router			/events/{id} [get]
+func GetEventByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// parse the event id from the url
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided in the URL.")
+			return
+		}
+
+		fields, err := parseFields(r, eventFields)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		loc, err := parseTimezone(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// the event shows its location, so changes of either modify it
+		var modified time.Time
+		var event models.EventResponse
+		modifiedQuery := `
+			SELECT e.id, e.visibility, GREATEST(e.updated_at, l.updated_at)
+			FROM events e
+			JOIN locations l ON e.location_id = l.id
+			WHERE e.id = $1
+		`
+		if err := pool.QueryRow(r.Context(), modifiedQuery, eventID).Scan(
+			&event.ID, &event.Visibility, &modified,
+		); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse selected event.")
+			return
+		}
+
+		// private events are not revealed, not even by a 304
+		if ok, err := canViewEvent(r, pool, event); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse selected event.")
+			return
+		} else if !ok {
+			writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+			return
+		}
+		if checkNotModified(w, r, modified) {
+			return
+		}
+
+		event, err = fetchEvent(r.Context(), pool, "e.id = $1", eventID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse selected event.")
+			return
+		}
+		event.Date = event.Date.In(loc)
+
+		if fields != nil {
+			selected, err := selectFields(event, fields)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse selected event.")
+				return
+			}
+			writeJSONResponse(w, http.StatusOK, selected)
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, event)
+	}
+}
+
+// GetEventBySlugHandler returns a single event by its slug.
+//
+//	@Summary		Get an event by slug
+//	@Description	Retrieve an event with its details and location, using its human-readable identifier. Private events are only shown to admins, staff and users holding a reservation for them.
+//	@ID				api.getEventBySlug
+//	@Tags			events
+//	@Produce		json
+//	@Param			slug	path		string					true	"Event slug"
+//	@Param			tz		query		string					false	"IANA timezone of returned dates, e.g. America/New_York (default UTC)"
+//	@Success		200		{object}	models.EventResponse	"Event details"
+//	@Failure		404		{object}	models.ErrorResponse	"Not Found"
+//	@Failure		500		{object}	models.ErrorResponse	"Internal Server Error"
+//	@Router			/events/slug/{slug} [get]
+func GetEventBySlugHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		slug, err := parsePathID(r, "slug")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		loc, err := parseTimezone(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		event, err := fetchEvent(r.Context(), pool, "e.slug = $1", slug)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse selected event.")
+			return
+		}
+		if ok, err := canViewEvent(r, pool, event); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse selected event.")
+			return
+		} else if !ok {
+			writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+			return
+		}
+		event.Date = event.Date.In(loc)
+
+		writeJSONResponse(w, http.StatusOK, event)
+	}
+}
+
+// GetSimilarEventsHandler lists upcoming events related to the given one.
+//
+//	@Summary		Get events similar to given event.
+//	@Description	Retrieve upcoming events held at the same venue or in the same country, excluding sold-out ones.
+//	@ID				api.getSimilarEvents
+//	@Tags			events
+//	@Produce		json
+//	@Param			id		path		string					true	"Event ID"
+//	@Param			limit	query		int						false	"Maximum number of events (default 5, max 20)"
+//	@Param			tz		query		string					false	"IANA timezone of returned dates, e.g. America/New_York (default UTC)"
+//	@Success		200		{object}	models.EventsResponse	"List of similar events"
+//	@Failure		400		{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		404		{object}	models.ErrorResponse	"Not Found"
+//	@Failure		500		{object}	models.ErrorResponse	"Internal Server Error"
+//	@Router			/events/{id}/similar [get]
+func GetSimilarEventsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided in the URL.")
+			return
+		}
+
+		// number of suggestions
+		limit := 5
+		if param := r.URL.Query().Get("limit"); param != "" {
+			value, err := strconv.Atoi(param)
+			if err != nil || value <= 0 || value > 20 {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					"Invalid limit; must be between 1 and 20.",
+				)
+				return
+			}
+			limit = value
+		}
+
+		loc, err := parseTimezone(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// check if the base event exists
+		var exists bool
+		existsQuery := `SELECT EXISTS(SELECT 1 FROM Events WHERE id = $1)`
+		if err := pool.QueryRow(r.Context(), existsQuery, eventID).Scan(&exists); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the event.")
+			return
+		}
+		if !exists {
+			writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+			return
+		}
+
+		// same venue or same country, still on sale
+		query := `
+			SELECT
+				e.id, e.name, e.date, e.price, e.available_tickets,
+				l.id, l.stadium, l.address, l.country, l.capacity
+			FROM events e
+			JOIN locations l ON e.location_id = l.id
+			JOIN events base ON base.id = $1
+			JOIN locations bl ON base.location_id = bl.id
+			WHERE e.id <> base.id
+				AND e.date > NOW()
+				AND e.available_tickets > 0
+				AND e.visibility = 'PUBLIC'
+				AND (e.location_id = base.location_id OR l.country = bl.country)
+			ORDER BY e.date ASC
+			LIMIT $2
+		`
+		rows, err := pool.Query(r.Context(), query, eventID, limit)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch events.")
+			return
+		}
+		defer rows.Close()
+
+		events := []models.EventResponse{}
+		for rows.Next() {
+			var event models.EventResponse
+			var location models.LocationResponse
+
+			if err := rows.Scan(
+				&event.ID,
+				&event.Name,
+				&event.Date,
+				&event.Price,
+				&event.AvailableTickets,
+				&location.ID,
+				&location.Stadium,
+				&location.Address,
+				&location.Country,
+				&location.Capacity,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse events.")
+				return
+			}
+
+			event.Date = event.Date.In(loc)
+			event.Location = location
+			event.Currency = currency
+			events = append(events, event)
+		}
+		writeListResponse(w, models.EventsResponse{Events: events}, events)
+	}
+}
+
+// CreateEventHandler creates a single event in the database.
+//
+//	@Summary		Create a new event (admin only).
+//	@Description	Parse the payload and create a new event with provided dataset. Visibility defaults to PUBLIC; UNLISTED events are reachable by link only, PRIVATE ones by invitation.
+//	@ID				api.createEvent
+//	@Tags			events
+//	@Produce		json
+//	@Accept			json
+//	@Param			body	body		models.CreateEventRequest		true	"Payload to create an event"
+//	@Success		200		{object}	models.SuccessResponseCreate	"Event created successfully"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		415		{object}	models.ErrorResponse			"Unsupported Media Type"
+//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/events [put]
+func CreateEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to create an event.",
+			)
+			return
+		}
+
+		// event structure in order to create an event
+		event := models.CreateEventRequest{}
+
+		// decode the input
+		if status, err := decodeJSONBody(r, &event); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		// check if the required fields are present
+		if !isValidCreateEventRequest(event) {
+			writeErrorResponse(w, http.StatusBadRequest, "Missing or invalid fields.")
+			return
+		}
+
+		// convert date to rfc3339 or yyyy-mm-dd hh:mm format
+		rfc3339Date, err := dateToRFC3339(event.Date)
+		if err != nil && rfc3339Date == "" {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				"Invalid date format; must be YYYY-MM-DD HH:MM or RFC3339.",
+			)
+			return
+		}
+
+		// tickets of hot events go on sale at a scheduled moment
+		event.SaleStartsAt, err = normalizeSaleStart(event.SaleStartsAt, rfc3339Date)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		visibility, err := normalizeVisibility(event.Visibility)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		event.Visibility = &visibility
+
+		// to ensure atomicity, we will start a transaction
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		// check if location exists, if not, insert it
+		locationID, err := getLocationID(
+			r, tx,
+			&event.Location.Address, &event.Location.Stadium, // mandatory
+			&event.Location.Capacity,
+			&event.Location.Country,
+		)
+		if err != nil {
+			if err == errUnknownCountry {
+				writeErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		// insert new event
+		eventID, err := insertEvent(r.Context(), tx, event, rfc3339Date, locationID, nil)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if err = tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to commit the transaction.",
+			)
+			return
+		}
+
+		invalidateEventCaches()
+
+		writeJSONResponse(
+			w,
+			http.StatusCreated,
+			models.SuccessResponseCreate{Message: "Event created successfully.", ID: eventID},
+		)
+	}
+}
+
+// CloneEventHandler creates a copy of an existing event.
+//
+//	@Summary		Clone an event (admin only).
+//	@Description	Copy the event, its location, limits and per-type prices into a new event. Availability is reset to the seats of the source event, counting the tickets it has already given out. Name and date can be overridden; the date must be in the future.
+//	@ID				api.cloneEvent
+//	@Tags			events
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Event ID"
+//	@Param			body	body		models.CloneEventRequest		false	"Overrides of the copied fields"
+//	@Success		201		{object}	models.SuccessResponseCreate	"Event cloned successfully"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse			"Not Found"
+//	@Failure		415		{object}	models.ErrorResponse			"Unsupported Media Type"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/events/{id}/clone [post]
+func CloneEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to clone an event.",
+			)
+			return
+		}
+
+		sourceID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// the body is optional, without it the event is copied as is
+		var payload models.CloneEventRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil && err != errEmptyBody {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		if payload.Name != nil && strings.TrimSpace(*payload.Name) == "" {
+			writeErrorResponse(w, http.StatusBadRequest, "Name must not be empty.")
+			return
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		// seats given out by the source count towards the fresh availability
+		var name string
+		var date time.Time
+		var availableTickets int
+		query := `
+			SELECT
+				e.name,
+				e.date,
+				e.available_tickets + (
+					SELECT COUNT(t.id)
+					FROM tickets t
+					JOIN reservations res ON t.reservation_id = res.id
+					JOIN ticket_statuses ts ON t.status_id = ts.id
+					WHERE res.event_id = e.id AND ts.name <> 'CANCELLED'
+				)
+			FROM events e
+			WHERE e.id = $1
+		`
+		if err := tx.QueryRow(r.Context(), query, sourceID).Scan(
+			&name, &date, &availableTickets,
+		); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the event.")
+			return
+		}
+
+		if payload.Name != nil {
+			name = *payload.Name
+		}
+		if payload.Date != nil {
+			rfc3339Date, err := dateToRFC3339(*payload.Date)
+			if err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					"Invalid date format; must be YYYY-MM-DD HH:MM or RFC3339.",
+				)
+				return
+			}
+			date, _ = time.Parse(time.RFC3339, rfc3339Date)
+		}
+		if !date.After(time.Now()) {
+			writeErrorResponse(w, http.StatusBadRequest, "Date must be in the future.")
+			return
+		}
+
+		var eventID int
+		query = `
+			INSERT INTO events
+				(name, date, price, available_tickets, max_tickets_per_reservation,
+				max_reservations_per_user, location_id, visibility, reservation_cutoff_hours)
+			SELECT $2, $3, price, $4, max_tickets_per_reservation,
+				max_reservations_per_user, location_id, visibility, reservation_cutoff_hours
+			FROM events
+			WHERE id = $1
+			RETURNING id
+		`
+		if err := tx.QueryRow(
+			r.Context(), query, sourceID, name, date, availableTickets,
+		).Scan(&eventID); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to clone the event.")
+			return
+		}
+
+		query = `
+			INSERT INTO event_ticket_prices (event_id, type_id, price)
+			SELECT $2, type_id, price
+			FROM event_ticket_prices
+			WHERE event_id = $1
+		`
+		if _, err := tx.Exec(r.Context(), query, sourceID, eventID); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to copy ticket prices.")
+			return
+		}
+
+		query = `
+			INSERT INTO event_sections (event_id, name)
+			SELECT $2, name
+			FROM event_sections
+			WHERE event_id = $1
+		`
+		if _, err := tx.Exec(r.Context(), query, sourceID, eventID); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to copy sections.")
+			return
+		}
+
+		if err := setEventSlug(r.Context(), tx, eventID); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to generate the slug.")
+			return
+		}
+
+		if err = tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to commit the transaction.",
+			)
+			return
+		}
+
+		invalidateEventCaches()
+
+		writeJSONResponse(
+			w,
+			http.StatusCreated,
+			models.SuccessResponseCreate{Message: "Event cloned successfully.", ID: eventID},
+		)
+	}
+}
+
+// UpdateEventHandler updates an existing event by ID.
+//
+//	@Summary		Update an existing event (admin only).
+//	@Description	Update event details based on the provided payload. Changing the date of an event with active reservations requires notify=true. The resulting event is validated as a whole, listing every violation.
+//	@ID				api.updateEvent
+//	@Tags			events
+//	@Produce		json
+//	@Accept			json
+//	@Param			id		path		string						true	"Event ID"
+//	@Param			notify	query		bool						false	"Acknowledge rescheduling an event with active reservations"
+//	@Param			body	body		models.UpdateEventRequest	true	"Payload to update an event"
+//	@Success		200		{object}	models.UpdateEventResponse	"Event updated successfully"
+//	@Failure		400		{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		415		{object}	models.ErrorResponse		"Unsupported Media Type"
+//	@Failure		403		{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse		"Not Found"
+//	@Failure		409		{object}	models.ErrorResponse		"Conflict"
+//	@Failure		422		{object}	models.ValidationErrorResponse	"Unprocessable Entity"
+//	@Failure		500		{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/events/{id} [put]
+func UpdateEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to update an event.",
+			)
+			return
+		}
+
+		// parse the event ID from the URL
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid event ID.")
+			return
+		}
+
+		// parse the request body into UpdateEventRequest
+		var eventPayload models.UpdateEventRequest
+		if status, err := decodeJSONBody(r, &eventPayload); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		// acknowledgment of rescheduling an event with active reservations
+		notify := false
+		if param := r.URL.Query().Get("notify"); param != "" {
+			notify, err = strconv.ParseBool(param)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "Invalid value for notify.")
+				return
+			}
+		}
+		affectedReservations := 0
+
+		var updateQueries []string
+		var updateArgs []interface{}
+		argIndex := 1
+
+		// resulting state of the event, validated as a whole before the update
+		var proposed eventUpdateState
+
+		// construct the update query
+		if eventPayload.Name != nil {
+			updateQueries = append(updateQueries, fmt.Sprintf("name = $%d", argIndex))
+			updateArgs = append(updateArgs, *eventPayload.Name)
+			argIndex++
+		}
+		if eventPayload.AvailableTickets != nil {
+			updateQueries = append(updateQueries, fmt.Sprintf("available_tickets = $%d", argIndex))
+			updateArgs = append(updateArgs, *eventPayload.AvailableTickets)
+			argIndex++
+			proposed.AvailableTickets = eventPayload.AvailableTickets
+		}
+		if eventPayload.Date != nil {
+			rfc3339Date, err := dateToRFC3339(*eventPayload.Date)
+			if err != nil && rfc3339Date == "" {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					"Invalid date format; must be YYYY-MM-DD HH:MM or RFC3339.",
+				)
+				return
+			}
+
+			// rescheduling affects everyone who already reserved
+			affectedReservations, err = countRescheduledReservations(
+				r, tx, eventID, rfc3339Date,
+			)
+			if err != nil {
+				if err == pgx.ErrNoRows {
+					writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+					return
+				}
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to fetch reservations of the event.",
+				)
+				return
+			}
+			if affectedReservations > 0 && !notify {
+				writeErrorResponse(
+					w,
+					http.StatusConflict,
+					fmt.Sprintf(
+						"Event has %d active reservations; confirm the reschedule with notify=true.",
+						affectedReservations,
+					),
+				)
+				return
+			}
+
+			updateQueries = append(updateQueries, fmt.Sprintf("date = $%d", argIndex))
+			updateArgs = append(updateArgs, rfc3339Date)
+			argIndex++
+			if date, err := time.Parse(time.RFC3339, rfc3339Date); err == nil {
+				proposed.Date = &date
+			}
+		}
+		if eventPayload.Price != nil {
+			updateQueries = append(updateQueries, fmt.Sprintf("price = $%d", argIndex))
+			updateArgs = append(updateArgs, *eventPayload.Price)
+			argIndex++
+			proposed.Price = eventPayload.Price
+		}
+		if eventPayload.MaxTicketsPerReservation != nil {
+			// zero removes the override, falling back to the global limit
+			limit := *eventPayload.MaxTicketsPerReservation
+			if limit < 0 {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					"Invalid max_tickets_per_reservation; must not be negative.",
+				)
+				return
+			}
+			updateQueries = append(
+				updateQueries,
+				fmt.Sprintf("max_tickets_per_reservation = NULLIF($%d, 0)", argIndex),
+			)
+			updateArgs = append(updateArgs, limit)
+			argIndex++
+		}
+		if eventPayload.MaxReservationsPerUser != nil {
+			// zero removes the cap
+			limit := *eventPayload.MaxReservationsPerUser
+			if limit < 0 {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					"Invalid max_reservations_per_user; must not be negative.",
+				)
+				return
+			}
+			updateQueries = append(
+				updateQueries,
+				fmt.Sprintf("max_reservations_per_user = NULLIF($%d, 0)", argIndex),
+			)
+			updateArgs = append(updateArgs, limit)
+			argIndex++
+		}
+		if eventPayload.SaleStartsAt != nil {
+			// empty string puts the event on sale right away
+			var saleStartArg *string
+			var saleStartsAt *time.Time
+			if *eventPayload.SaleStartsAt != "" {
+				rfc3339Date, err := dateToRFC3339(*eventPayload.SaleStartsAt)
+				if err != nil {
+					writeErrorResponse(
+						w,
+						http.StatusBadRequest,
+						"Invalid sale_starts_at; must be YYYY-MM-DD HH:MM or RFC3339.",
+					)
+					return
+				}
+				date, _ := time.Parse(time.RFC3339, rfc3339Date)
+				saleStartArg, saleStartsAt = &rfc3339Date, &date
+			}
+			updateQueries = append(updateQueries, fmt.Sprintf("sale_starts_at = $%d", argIndex))
+			updateArgs = append(updateArgs, saleStartArg)
+			argIndex++
+			proposed.SaleStartChanged = true
+			proposed.SaleStartsAt = saleStartsAt
+		}
+		if eventPayload.ReservationCutoffHours != nil {
+			hours := *eventPayload.ReservationCutoffHours
+			if hours < 0 {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					"Invalid reservation_cutoff_hours; must not be negative.",
+				)
+				return
+			}
+			updateQueries = append(
+				updateQueries,
+				fmt.Sprintf("reservation_cutoff_hours = $%d", argIndex),
+			)
+			updateArgs = append(updateArgs, hours)
+			argIndex++
+		}
+		if eventPayload.Visibility != nil {
+			visibility, err := normalizeVisibility(eventPayload.Visibility)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			updateQueries = append(updateQueries, fmt.Sprintf("visibility = $%d", argIndex))
+			updateArgs = append(updateArgs, visibility)
+			argIndex++
+		}
+		if eventPayload.Location != nil {
+			locationID, err := getLocationID(
+				r, tx,
+				eventPayload.Location.Address,
+				eventPayload.Location.Stadium,
+				eventPayload.Location.Capacity,
+				eventPayload.Location.Country,
+			)
+			if err != nil {
+				if err == errUnknownCountry {
+					writeErrorResponse(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			updateQueries = append(updateQueries, fmt.Sprintf("location_id = $%d", argIndex))
+			updateArgs = append(updateArgs, locationID)
+			argIndex++
+			proposed.LocationID = &locationID
+		}
+
+		if len(updateQueries) == 0 {
+			writeErrorResponse(w, http.StatusUnprocessableEntity, "Nothing to update.")
+			return
+		}
+
+		// fields changed together have to agree with each other
+		violations, err := validateEventUpdate(r.Context(), tx, eventID, proposed)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to validate the event.")
+			return
+		}
+		if len(violations) > 0 {
+			writeJSONResponse(w, http.StatusUnprocessableEntity, models.ValidationErrorResponse{
+				Message:    "Updated event would be invalid.",
+				Violations: violations,
+			})
+			return
+		}
+
+		// append the args to the query
+		updateArgs = append(updateArgs, eventID)
+		updateQuery := fmt.Sprintf(
+			"UPDATE Events SET %s WHERE id = $%d",
+			strings.Join(updateQueries, ", "),
+			argIndex,
+		)
+
+		_, err = tx.Exec(r.Context(), updateQuery, updateArgs...)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to update the event.")
+			return
+		}
+
+		// renamed events get a new slug
+		if eventPayload.Name != nil {
+			id, err := strconv.Atoi(eventID)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "Invalid event ID.")
+				return
+			}
+			if err := setEventSlug(r.Context(), tx, id); err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to generate the slug.",
+				)
+				return
+			}
+		}
+
+		if err = tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to commit transaction.")
+			return
+		}
+
+		invalidateEventCaches()
+
+		writeJSONResponse(
+			w,
+			http.StatusOK,
+			models.UpdateEventResponse{
+				Message:              "Event updated successfully.",
+				AffectedReservations: affectedReservations,
+			},
+		)
+	}
+}
+
+// DeleteEventHandler deletes an existing event by ID.
+//
+//	@Summary		Delete an existing event (admin only).
+//	@Description	Delete event by its ID.
+//	@ID				api.deleteEvent
+//	@Tags			events
+//	@Produce		json
+//	@Param			id	path		string							true	"Event ID"
+//	@Success		200	{object}	models.SuccessResponseCreate	"Event deleted successfully"
+//	@Failure		400	{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		500	{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/events/{id} [delete]
+func DeleteEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided.")
+			return
+		}
+
+		// delete the event
+		query := `DELETE FROM Events WHERE id = $1`
+		_, err = pool.Exec(r.Context(), query, eventID)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to delete event.")
+			return
+		}
+
+		invalidateEventCaches()
+
+		writeJSONResponse(
+			w,
+			http.StatusOK,
+			models.SuccessResponse{Message: "Event deleted successfully."},
+		)
 	}
 }
 
-// GetEventByIDHandler returns a single event by ID.
+// GetEventTicketPricesHandler lists prices of all ticket types for an event.
 //
-//	@Summary		Get an event by ID
-//	@Description	Retrieve an event with its details and location.
-//	@ID				api.getEventByID
+//	@Summary		Get ticket prices of an event.
+//	@Description	Retrieve the price of each ticket type for the event, taking per-event overrides into account.
+//	@ID				api.getEventTicketPrices
 //	@Tags			events
 //	@Produce		json
-//	@Param			id	path		string					true	"Event ID"
-//	@Success		200	{object}	models.EventResponse	"Event details"
-//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
-//	@Failure		404	{object}	models.ErrorResponse	"Not Found"
-//	@Router			/events/{id} [get]
-func GetEventByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
+//	@Param			id	path		string								true	"Event ID"
+//	@Success		200	{object}	models.EventTicketPricesResponse	"Ticket prices of the event"
+//	@Failure		404	{object}	models.ErrorResponse				"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse				"Internal Server Error"
+//	@Router			/events/{id}/prices [get]
+func GetEventTicketPricesHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// parse the event id from the url
-		vars := mux.Vars(r)
-		eventID, ok := vars["id"]
-		if !ok {
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided in the URL.")
 			return
 		}
 
-		// execute the query
-		query := `
-			SELECT
-				e.id, e.name, e.date, e.price, e.available_tickets,
-				l.id, l.stadium, l.address, l.country, l.capacity
-			FROM events e
-			JOIN locations l ON e.location_id = l.id
-			WHERE e.id = $1
-		`
-		row := pool.QueryRow(r.Context(), query, eventID)
-
-		// build the structs
-		var event models.EventResponse
-		var location models.LocationResponse
-		if err := row.Scan(
-			&event.ID,
-			&event.Name,
-			&event.Date,
-			&event.Price,
-			&event.AvailableTickets,
-			&location.ID,
-			&location.Stadium,
-			&location.Address,
-			&location.Country,
-			&location.Capacity,
-		); err != nil {
+		response := models.EventTicketPricesResponse{Currency: currency}
+		var basePrice float64
+		if err := pool.QueryRow(
+			r.Context(),
+			`SELECT id, price FROM Events WHERE id = $1`,
+			eventID,
+		).Scan(&response.EventID, &basePrice); err != nil {
 			if err == pgx.ErrNoRows {
 				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
 				return
 			}
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse selected event.")
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the event.")
 			return
 		}
 
-		event.Location = location
-		writeJSONResponse(w, http.StatusOK, event)
+		// override if present, otherwise discounted base price
+		query := `
+			SELECT
+				tt.name,
+				COALESCE(etp.price, ROUND($2 * (1 - tt.discount), 2)),
+				etp.price IS NOT NULL
+			FROM ticket_types tt
+			LEFT JOIN event_ticket_prices etp ON etp.type_id = tt.id AND etp.event_id = $1
+			ORDER BY tt.id ASC
+		`
+		rows, err := pool.Query(r.Context(), query, response.EventID, basePrice)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch ticket prices.")
+			return
+		}
+		defer rows.Close()
+
+		response.Prices = []models.EventTicketPriceResponse{}
+		for rows.Next() {
+			var price models.EventTicketPriceResponse
+			if err := rows.Scan(&price.Type, &price.Price, &price.Override); err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to parse ticket prices.",
+				)
+				return
+			}
+			response.Prices = append(response.Prices, price)
+		}
+		writeJSONResponse(w, http.StatusOK, response)
 	}
 }
 
-// CreateEventHandler creates a single event in the database.
+// SetEventTicketPricesHandler sets per-event prices of ticket types.
 //
-//	@Summary		Create a new event (admin only).
-//	@Description	Parse the payload and create a new event with provided dataset.
-//	@ID				api.createEvent
+//	@Summary		Set ticket prices of an event (admin only).
+//	@Description	Override prices of given ticket types for the event. Types not listed keep their current price.
+//	@ID				api.setEventTicketPrices
 //	@Tags			events
-//	@Produce		json
 //	@Accept			json
-//	@Param			body	body		models.CreateEventRequest		true	"Payload to create an event"
-//	@Success		200		{object}	models.SuccessResponseCreate	"Event created successfully"
-//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
-//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
-//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Produce		json
+//	@Param			id		path		string								true	"Event ID"
+//	@Param			body	body		models.SetEventTicketPricesRequest	true	"Prices per ticket type"
+//	@Success		200		{object}	models.SuccessResponse				"Prices updated successfully"
+//	@Failure		400		{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		415		{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		403		{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse				"Not Found"
+//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
 //	@Security		BearerAuth
-//	@Router			/events [put]
-func CreateEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
+//	@Router			/events/{id}/prices [put]
+func SetEventTicketPricesHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if !isAdmin(r) {
 			writeErrorResponse(
 				w,
 				http.StatusForbidden,
-				"Insufficient permissions to create an event.",
+				"Insufficient permissions to set ticket prices.",
 			)
 			return
 		}
 
-		// event structure in order to create an event
-		event := models.CreateEventRequest{}
-
-		// decode the input
-		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON payload.")
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided in the URL.")
 			return
 		}
 
-		// check if the required fields are present
-		if event.Name == "" || event.Date == "" || event.Location.Address == "" ||
-			event.AvailableTickets < 0 {
-			writeErrorResponse(w, http.StatusBadRequest, "Missing or invalid fields.")
+		var payload models.SetEventTicketPricesRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil {
+			writeErrorResponse(w, status, err.Error())
 			return
 		}
-
-		// convert date to rfc3339 or yyyy-mm-dd hh:mm format
-		rfc3339Date, err := dateToRFC3339(event.Date)
-		if err != nil && rfc3339Date == "" {
-			writeErrorResponse(
-				w,
-				http.StatusBadRequest,
-				"Invalid date format; must be YYYY-MM-DD HH:MM or RFC3339.",
-			)
+		if len(payload.Prices) == 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "No prices provided.")
 			return
 		}
+		for i := range payload.Prices {
+			payload.Prices[i].Type = normalizeTicketType(payload.Prices[i].Type)
+			if payload.Prices[i].Type == "" || payload.Prices[i].Price < 0 {
+				writeErrorResponse(w, http.StatusBadRequest, "Missing or invalid fields.")
+				return
+			}
+		}
 
-		// to ensure atomicity, we will start a transaction
 		tx, err := pool.Begin(r.Context())
 		if err != nil {
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
@@ -200,93 +1417,106 @@ func CreateEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		}
 		defer tx.Rollback(r.Context())
 
-		// check if location exists, if not, insert it
-		locationID, err := getLocationID(
-			r, tx,
-			&event.Location.Address, &event.Location.Stadium, // mandatory
-			&event.Location.Capacity,
-			&event.Location.Country,
-		)
-		if err != nil {
-			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		var exists bool
+		existsQuery := `SELECT EXISTS(SELECT 1 FROM Events WHERE id = $1)`
+		if err := tx.QueryRow(r.Context(), existsQuery, eventID).Scan(&exists); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the event.")
+			return
+		}
+		if !exists {
+			writeErrorResponse(w, http.StatusNotFound, "Event not found.")
 			return
 		}
 
-		// insert new event
-		var eventID int
-		eventQuery := `
-				INSERT INTO Events (name, date, price, available_tickets, location_id)
-				VALUES ($1, $2, $3, $4, $5)
-				RETURNING id
+		// upsert each override, resolving the type by its name
+		query := `
+			INSERT INTO event_ticket_prices (event_id, type_id, price)
+			SELECT $1, id, $3 FROM ticket_types WHERE name = $2
+			ON CONFLICT (event_id, type_id) DO UPDATE SET price = EXCLUDED.price
 		`
-		if err := tx.QueryRow(
-			r.Context(), eventQuery,
-			event.Name, rfc3339Date, event.Price, event.AvailableTickets,
-			locationID,
-		).Scan(&eventID); err != nil {
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to create the event.")
-			return
+		for _, price := range payload.Prices {
+			tag, err := tx.Exec(r.Context(), query, eventID, price.Type, price.Price)
+			if err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to set ticket prices.",
+				)
+				return
+			}
+			if tag.RowsAffected() == 0 {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					fmt.Sprintf("Unknown ticket type '%s'.", price.Type),
+				)
+				return
+			}
 		}
 
 		if err = tx.Commit(r.Context()); err != nil {
-			writeErrorResponse(
-				w,
-				http.StatusInternalServerError,
-				"Failed to commit the transaction.",
-			)
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to commit transaction.")
 			return
 		}
 
 		writeJSONResponse(
 			w,
-			http.StatusCreated,
-			models.SuccessResponseCreate{Message: "Event created successfully.", ID: eventID},
+			http.StatusOK,
+			models.SuccessResponse{Message: "Ticket prices updated successfully."},
 		)
 	}
 }
 
-// UpdateEventHandler updates an existing event by ID.
+// RepriceEventHandler changes the base price of an event, along with prices of unsold tickets.
 //
-//	@Summary		Update an existing event (admin only).
-//	@Description	Update event details based on the provided payload.
-//	@ID				api.updateEvent
+//	@Summary		Reprice an event (admin only).
+//	@Description	Set the base price of the event and optional price overrides of ticket types, then reprice RESERVED tickets accordingly. SOLD tickets keep the price they were paid for.
+//	@ID				api.repriceEvent
 //	@Tags			events
-//	@Produce		json
 //	@Accept			json
+//	@Produce		json
 //	@Param			id		path		string						true	"Event ID"
-//	@Param			body	body		models.UpdateEventRequest	true	"Payload to update an event"
-//	@Success		200		{object}	models.SuccessResponse		"Event updated successfully"
+//	@Param			body	body		models.RepriceEventRequest	true	"New base price and ticket type overrides"
+//	@Success		200		{object}	models.RepriceEventResponse	"Event repriced successfully"
 //	@Failure		400		{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		415		{object}	models.ErrorResponse		"Unsupported Media Type"
 //	@Failure		403		{object}	models.ErrorResponse		"Forbidden"
-//	@Failure		422		{object}	models.ErrorResponse		"Unprocessable Entity"
+//	@Failure		404		{object}	models.ErrorResponse		"Not Found"
 //	@Failure		500		{object}	models.ErrorResponse		"Internal Server Error"
 //	@Security		BearerAuth
-//	@Router			/events/{id} [put]
-func UpdateEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
+//	@Router			/events/{id}/reprice [post]
+func RepriceEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if !isAdmin(r) {
 			writeErrorResponse(
 				w,
 				http.StatusForbidden,
-				"Insufficient permissions to update an event.",
+				"Insufficient permissions to reprice the event.",
 			)
 			return
 		}
 
-		// parse the event ID from the URL
-		vars := mux.Vars(r)
-		eventID, ok := vars["id"]
-		if !ok {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid event ID.")
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided in the URL.")
 			return
 		}
 
-		// parse the request body into UpdateEventRequest
-		var eventPayload models.UpdateEventRequest
-		if err := json.NewDecoder(r.Body).Decode(&eventPayload); err != nil {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON input.")
+		var payload models.RepriceEventRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil {
+			writeErrorResponse(w, status, err.Error())
 			return
 		}
+		if payload.Price < 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "Price cannot be negative.")
+			return
+		}
+		for _, price := range payload.Prices {
+			if price.Type == "" || price.Price < 0 {
+				writeErrorResponse(w, http.StatusBadRequest, "Missing or invalid fields.")
+				return
+			}
+		}
 
 		tx, err := pool.Begin(r.Context())
 		if err != nil {
@@ -295,74 +1525,80 @@ func UpdateEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		}
 		defer tx.Rollback(r.Context())
 
-		var updateQueries []string
-		var updateArgs []interface{}
-		argIndex := 1
-
-		// construct the update query
-		if eventPayload.Name != nil {
-			updateQueries = append(updateQueries, fmt.Sprintf("name = $%d", argIndex))
-			updateArgs = append(updateArgs, *eventPayload.Name)
-			argIndex++
+		tag, err := tx.Exec(
+			r.Context(),
+			`UPDATE events SET price = $2 WHERE id = $1`,
+			eventID, payload.Price,
+		)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to update the event.")
+			return
 		}
-		if eventPayload.AvailableTickets != nil {
-			updateQueries = append(updateQueries, fmt.Sprintf("available_tickets = $%d", argIndex))
-			updateArgs = append(updateArgs, *eventPayload.AvailableTickets)
-			argIndex++
+		if tag.RowsAffected() == 0 {
+			writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+			return
 		}
-		if eventPayload.Date != nil {
-			rfc3339Date, err := dateToRFC3339(*eventPayload.Date)
-			if err != nil && rfc3339Date == "" {
+
+		overrideQuery := `
+			INSERT INTO event_ticket_prices (event_id, type_id, price)
+			SELECT $1, id, $3 FROM ticket_types WHERE name = $2
+			ON CONFLICT (event_id, type_id) DO UPDATE SET price = EXCLUDED.price
+		`
+		for _, price := range payload.Prices {
+			tag, err := tx.Exec(r.Context(), overrideQuery, eventID, price.Type, price.Price)
+			if err != nil {
 				writeErrorResponse(
 					w,
-					http.StatusBadRequest,
-					"Invalid date format; must be YYYY-MM-DD HH:MM or RFC3339.",
+					http.StatusInternalServerError,
+					"Failed to set ticket prices.",
 				)
 				return
 			}
-
-			updateQueries = append(updateQueries, fmt.Sprintf("date = $%d", argIndex))
-			updateArgs = append(updateArgs, rfc3339Date)
-			argIndex++
-		}
-		if eventPayload.Price != nil {
-			updateQueries = append(updateQueries, fmt.Sprintf("price = $%d", argIndex))
-			updateArgs = append(updateArgs, *eventPayload.Price)
-			argIndex++
-		}
-		if eventPayload.Location != nil {
-			locationID, err := getLocationID(
-				r, tx,
-				eventPayload.Location.Address,
-				eventPayload.Location.Stadium,
-				eventPayload.Location.Capacity,
-				eventPayload.Location.Country,
-			)
-			if err != nil {
-				writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			if tag.RowsAffected() == 0 {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					fmt.Sprintf("Unknown ticket type '%s'.", price.Type),
+				)
 				return
 			}
-			updateQueries = append(updateQueries, fmt.Sprintf("location_id = $%d", argIndex))
-			updateArgs = append(updateArgs, locationID)
-			argIndex++
 		}
 
-		if len(updateQueries) == 0 {
-			writeErrorResponse(w, http.StatusUnprocessableEntity, "Nothing to update.")
+		// unsold tickets follow the override of their type, or the discounted base price
+		repriceQuery := `
+			UPDATE tickets t
+			SET price = COALESCE(
+				(
+					SELECT etp.price
+					FROM event_ticket_prices etp
+					WHERE etp.event_id = r.event_id AND etp.type_id = t.type_id
+				),
+				$2 * (1 - tt.discount)
+			)
+			FROM reservations r, ticket_types tt, ticket_statuses ts
+			WHERE t.reservation_id = r.id
+				AND t.type_id = tt.id
+				AND t.status_id = ts.id
+				AND r.event_id = $1
+				AND ts.name = 'RESERVED'
+		`
+		tag, err = tx.Exec(r.Context(), repriceQuery, eventID, payload.Price)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to reprice tickets.")
 			return
 		}
+		repriced := int(tag.RowsAffected())
 
-		// append the args to the query
-		updateArgs = append(updateArgs, eventID)
-		updateQuery := fmt.Sprintf(
-			"UPDATE Events SET %s WHERE id = $%d",
-			strings.Join(updateQueries, ", "),
-			argIndex,
-		)
-
-		_, err = tx.Exec(r.Context(), updateQuery, updateArgs...)
-		if err != nil {
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to update the event.")
+		var unchanged int
+		unchangedQuery := `
+			SELECT COUNT(*)
+			FROM tickets t
+			JOIN reservations r ON t.reservation_id = r.id
+			JOIN ticket_statuses ts ON t.status_id = ts.id
+			WHERE r.event_id = $1 AND ts.name = 'SOLD'
+		`
+		if err := tx.QueryRow(r.Context(), unchangedQuery, eventID).Scan(&unchanged); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to count tickets.")
 			return
 		}
 
@@ -371,48 +1607,104 @@ func UpdateEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
-		writeJSONResponse(
-			w,
-			http.StatusOK,
-			models.SuccessResponse{Message: "Event updated successfully."},
-		)
+		invalidateEventCaches()
+
+		writeJSONResponse(w, http.StatusOK, models.RepriceEventResponse{
+			Message:          "Event repriced successfully.",
+			RepricedTickets:  repriced,
+			UnchangedTickets: unchanged,
+		})
 	}
 }
 
-// DeleteEventHandler deletes an existing event by ID.
+// DeleteEventTicketPriceHandler removes per-event price of a ticket type.
 //
-//	@Summary		Delete an existing event (admin only).
-//	@Description	Delete event by its ID.
-//	@ID				api.deleteEvent
+//	@Summary		Remove ticket price override of an event (admin only).
+//	@Description	Remove the price override, so the ticket type falls back to the discounted base price.
+//	@ID				api.deleteEventTicketPrice
 //	@Tags			events
 //	@Produce		json
-//	@Param			id	path		string							true	"Event ID"
-//	@Success		200	{object}	models.SuccessResponseCreate	"Event deleted successfully"
-//	@Failure		400	{object}	models.ErrorResponse			"Bad Request"
-//	@Failure		500	{object}	models.ErrorResponse			"Internal Server Error"
+//	@Param			id		path		string					true	"Event ID"
+//	@Param			type	path		string					true	"Ticket type"
+//	@Success		200		{object}	models.SuccessResponse	"Price override removed successfully"
+//	@Failure		403		{object}	models.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse	"Not Found"
+//	@Failure		500		{object}	models.ErrorResponse	"Internal Server Error"
 //	@Security		BearerAuth
-//	@Router			/events/{id} [delete]
-func DeleteEventHandler(pool *pgxpool.Pool) http.HandlerFunc {
+//	@Router			/events/{id}/prices/{type} [delete]
+func DeleteEventTicketPriceHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-		eventID, ok := vars["id"]
-		if !ok {
-			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided.")
+		if !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to remove ticket prices.",
+			)
 			return
 		}
 
-		// delete the event
-		query := `DELETE FROM Events WHERE id = $1`
-		_, err := pool.Exec(r.Context(), query, eventID)
+		eventID, err := parsePathID(r, "id")
 		if err != nil {
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to delete event.")
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ticketType, err := parsePathID(r, "type")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		query := `
+			DELETE FROM event_ticket_prices
+			WHERE event_id = $1
+				AND type_id = (SELECT id FROM ticket_types WHERE name = $2)
+		`
+		tag, err := pool.Exec(r.Context(), query, eventID, normalizeTicketType(ticketType))
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to remove the price.")
+			return
+		}
+		if tag.RowsAffected() == 0 {
+			writeErrorResponse(w, http.StatusNotFound, "Price override not found.")
 			return
 		}
 
 		writeJSONResponse(
 			w,
 			http.StatusOK,
-			models.SuccessResponse{Message: "Event deleted successfully."},
+			models.SuccessResponse{Message: "Ticket price override removed successfully."},
 		)
 	}
 }
+
+// Count active reservations of an event, if its date is about to change.
+// Returns 0 if the date stays the same; pgx.ErrNoRows if the event does not exist.
+func countRescheduledReservations(
+	r *http.Request,
+	tx pgx.Tx,
+	eventID string,
+	newDate string,
+) (int, error) {
+	query := `
+		SELECT
+			e.date <> $2::timestamp,
+			(
+				SELECT COUNT(*)
+				FROM reservations res
+				JOIN reservation_statuses rs ON res.status_id = rs.id
+				WHERE res.event_id = e.id AND rs.name <> 'CANCELLED'
+			)
+		FROM events e
+		WHERE e.id = $1
+		FOR UPDATE
+	`
+	var changed bool
+	var count int
+	if err := tx.QueryRow(r.Context(), query, eventID, newDate).Scan(&changed, &count); err != nil {
+		return 0, err
+	}
+	if !changed {
+		return 0, nil
+	}
+	return count, nil
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import "net/http"
+
+// NotFoundHandler responds to requests for unknown paths with a JSON error.
+func NotFoundHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeErrorResponse(w, http.StatusNotFound, "Resource not found.")
+	}
+}
+
+// MethodNotAllowedHandler responds to requests using an unsupported method
+// on a known path with a JSON error.
+func MethodNotAllowedHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed.")
+	}
+}
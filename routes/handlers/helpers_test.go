@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+
+	"event-reservation-api/db"
+	"event-reservation-api/middlewares"
+)
+
+// Password of the users created by the tests.
+const testPassword = "test-password"
+
+// Connect to the database of TEST_DATABASE_URL, initialized with
+// db/init/schema.sql. Tests needing it are skipped when it is not set.
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	pool, err := pgxpool.New(context.Background(), dbURL)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	if err := db.SeedRoles(pool); err != nil {
+		t.Fatalf("failed to seed the roles: %v", err)
+	}
+	return pool
+}
+
+// Create a user with the role, removed once the test finishes.
+// Returns the ID and username of the user.
+func createTestUser(t *testing.T, pool *pgxpool.Pool, role string) (string, string) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash the password: %v", err)
+	}
+
+	username := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	var id string
+	query := `
+		INSERT INTO users (name, surname, username, email, password_hash, role_id)
+		SELECT 'Test', 'User', $1, $1 || '@example.com', $2, id FROM roles WHERE name = $3
+		RETURNING id
+	`
+	if err := pool.QueryRow(context.Background(), query, username, string(hash), role).
+		Scan(&id); err != nil {
+		t.Fatalf("failed to create the user: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, id)
+	})
+	return id, username
+}
+
+// Create a location, removed along with its events once the test finishes.
+func createTestLocation(t *testing.T, pool *pgxpool.Pool) int {
+	t.Helper()
+	var id int
+	query := `
+		INSERT INTO locations (stadium, address, country, capacity)
+		VALUES ('Test Stadium', 'Test Street 1', 'Testland', 1000)
+		RETURNING id
+	`
+	if err := pool.QueryRow(context.Background(), query).Scan(&id); err != nil {
+		t.Fatalf("failed to create the location: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM locations WHERE id = $1`, id)
+	})
+	return id
+}
+
+// Create an event a month ahead at the location, with tickets available.
+func createTestEvent(t *testing.T, pool *pgxpool.Pool, locationID int) int {
+	t.Helper()
+	var id int
+	query := `
+		INSERT INTO events (name, date, price, location_id, available_tickets)
+		VALUES ('Test Event', $1, 100, $2, 100)
+		RETURNING id
+	`
+	date := time.Now().AddDate(0, 1, 0)
+	if err := pool.QueryRow(context.Background(), query, date, locationID).
+		Scan(&id); err != nil {
+		t.Fatalf("failed to create the event: %v", err)
+	}
+	return id
+}
+
+// Create an event at the location which has already taken place.
+func createPastTestEvent(t *testing.T, pool *pgxpool.Pool, locationID int) int {
+	t.Helper()
+
+	// dates in the past cannot be set, so the event passes instead
+	var id int
+	query := `
+		INSERT INTO events (name, date, price, location_id, available_tickets)
+		VALUES ('Past Test Event', $1, 100, $2, 100)
+		RETURNING id
+	`
+	date := time.Now().Add(time.Second)
+	if err := pool.QueryRow(context.Background(), query, date, locationID).
+		Scan(&id); err != nil {
+		t.Fatalf("failed to create the event: %v", err)
+	}
+	time.Sleep(time.Until(date) + 100*time.Millisecond)
+	return id
+}
+
+// Create a reservation of a single standard ticket with the status.
+func createTestReservation(
+	t *testing.T,
+	pool *pgxpool.Pool,
+	userID string,
+	eventID int,
+	status string,
+) string {
+	t.Helper()
+	ctx := context.Background()
+	var id string
+	query := `
+		INSERT INTO reservations (user_id, event_id, total_tickets, status_id)
+		SELECT $1, $2, 1, id FROM reservation_statuses WHERE name = $3
+		RETURNING id
+	`
+	if err := pool.QueryRow(ctx, query, userID, eventID, status).Scan(&id); err != nil {
+		t.Fatalf("failed to create the reservation: %v", err)
+	}
+	query = `
+		INSERT INTO tickets (reservation_id, price, type_id, status_id)
+		SELECT $1, 100,
+			(SELECT id FROM ticket_types WHERE name = 'STANDARD' ORDER BY id LIMIT 1),
+			(SELECT id FROM ticket_statuses WHERE name = 'SOLD')
+	`
+	if _, err := pool.Exec(ctx, query, id); err != nil {
+		t.Fatalf("failed to create the ticket: %v", err)
+	}
+	return id
+}
+
+// Build a request, with a JSON body if one is provided.
+func newTestRequest(method, path, body string) *http.Request {
+	if body == "" {
+		return httptest.NewRequest(method, path, nil)
+	}
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+// Authenticate the request as the user, as RequireAuth does.
+func withUser(r *http.Request, userID, role string) *http.Request {
+	claims := jwt.MapClaims{"userID": userID, "role": role}
+	return r.WithContext(context.WithValue(r.Context(), middlewares.UserClaimsKey, claims))
+}
+
+// Set the path parameters of the request, as the router does.
+func withVars(r *http.Request, vars map[string]string) *http.Request {
+	return mux.SetURLVars(r, vars)
+}
+
+// Serve the request with the handler, returning the recorded response.
+func serve(handler http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	return rec
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// Time the tickets of a pending reservation are held until, after which the
+// sweeper cancels it. Reservations in other statuses are not held.
+func holdExpiresAt(status string, createdAt time.Time) *time.Time {
+	if status != "PENDING" {
+		return nil
+	}
+	expiresAt := createdAt.Add(time.Duration(reservationHoldMinutes) * time.Minute)
+	return &expiresAt
+}
+
+// GetExpiringReservationsHandler lists pending reservations about to lose their hold.
+//
+//	@Summary		List reservations with expiring holds (admin/staff only).
+//	@Description	Retrieve pending reservations, whose hold expires within the given number of minutes, soonest first. Already expired holds awaiting the sweeper are included.
+//	@Tags			reservations
+//	@ID				api.getExpiringReservations
+//	@Produce		json
+//	@Param			within			query		int							false	"Minutes ahead to look (defaults to the configured window)"
+//	@Param			include_tickets	query		bool						false	"Include the tickets of each reservation (default true)"
+//	@Success		200				{object}	models.ReservationsResponse	"List of expiring reservations"
+//	@Failure		400				{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		403				{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		500				{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/expiring [get]
+func GetExpiringReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isStaffOrAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		within, err := parseExpiringWindow(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		includeTickets, err := parseIncludeTickets(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// holds expire at created_at + reservationHoldMinutes, see holdExpiresAt
+		query := `
+			SELECT r.id, u.username, r.created_at, r.total_tickets, rs.name, r.notes,
+				e.id, e.name, e.date, l.country, l.address, l.stadium
+			FROM Reservations r
+			JOIN reservation_statuses rs ON r.status_id = rs.id
+			JOIN Users u ON r.user_id = u.id
+			JOIN Events e ON r.event_id = e.id
+			JOIN Locations l ON e.location_id = l.id
+			WHERE rs.name = 'PENDING'
+				AND r.created_at + make_interval(mins => $1) <= NOW() + make_interval(mins => $2)
+			ORDER BY r.created_at, r.id
+		`
+		rows, err := pool.Query(r.Context(), query, reservationHoldMinutes, within)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch reservations.",
+			)
+			return
+		}
+		defer rows.Close()
+
+		reservations := []models.ReservationResponse{}
+		for rows.Next() {
+			var res models.ReservationResponse
+			if err := rows.Scan(
+				&res.ID, &res.Username, &res.CreatedAt, &res.TotalTickets, &res.Status, &res.Notes,
+				&res.Event.ID, &res.Event.Name, &res.Event.Date,
+				&res.Event.Location.Country, &res.Event.Location.Address,
+				&res.Event.Location.Stadium,
+			); err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to parse reservation.",
+				)
+				return
+			}
+			res.HoldExpiresAt = holdExpiresAt(res.Status, res.CreatedAt)
+			reservations = append(reservations, res)
+		}
+		rows.Close()
+
+		if includeTickets {
+			if err := attachTickets(r.Context(), pool, reservations); err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to fetch the tickets.",
+				)
+				return
+			}
+		}
+
+		writeListResponse(
+			w,
+			models.ReservationsResponse{Reservations: reservations},
+			reservations,
+		)
+	}
+}
+
+// Parse the within query parameter, falling back to the configured window.
+func parseExpiringWindow(r *http.Request) (int, error) {
+	param := r.URL.Query().Get("within")
+	if param == "" {
+		return expiringHoldWindowMinutes, nil
+	}
+	within, err := strconv.Atoi(param)
+	if err != nil || within <= 0 {
+		return 0, fmt.Errorf("Invalid within; must be a positive number of minutes.")
+	}
+	return within, nil
+}
+
+// Routine cancelling pending reservations, which held their tickets for too long.
+func StartReservationHoldSweeper(pool *pgxpool.Pool, interval time.Duration) {
+	go func() {
+		for {
+			time.Sleep(interval)
+			if err := expireReservationHolds(context.Background(), pool); err != nil {
+				log.Printf("Error expiring reservation holds: %v", err)
+			}
+		}
+	}()
+}
+
+// Cancel pending reservations past their hold, along with their tickets, and
+// return the tickets to the events. Uses the same TTL as holdExpiresAt.
+func expireReservationHolds(ctx context.Context, pool *pgxpool.Pool) error {
+	query := `
+		WITH expired AS (
+			UPDATE reservations r
+			SET status_id = (SELECT id FROM reservation_statuses WHERE name = 'CANCELLED')
+			FROM reservation_statuses rs
+			WHERE r.status_id = rs.id
+				AND rs.name = 'PENDING'
+				AND r.created_at + make_interval(mins => $1) < NOW()
+			RETURNING r.id, r.event_id, r.total_tickets
+		), cancelled_tickets AS (
+			UPDATE tickets
+			SET status_id = (SELECT id FROM ticket_statuses WHERE name = 'CANCELLED')
+			WHERE reservation_id IN (SELECT id FROM expired)
+		)
+		UPDATE events e
+		SET available_tickets = e.available_tickets + released.tickets
+		FROM (
+			SELECT event_id, SUM(total_tickets) AS tickets
+			FROM expired
+			GROUP BY event_id
+		) released
+		WHERE e.id = released.event_id
+	`
+	tag, err := pool.Exec(ctx, query, reservationHoldMinutes)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() > 0 {
+		log.Printf("Released held tickets of %d events.", tag.RowsAffected())
+	}
+	return nil
+}
@@ -1,12 +1,12 @@
 package handlers
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/gorilla/mux"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -20,21 +20,30 @@ import (
 //	@ID				api.getLocations
 //	@Tags			locations
 //	@Produce		json
-//	@Success		200	{object}	models.LocationsResponse	"List of locations"
-//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
-//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
+//	@Param			limit	query		int							false	"Maximum number of locations"
+//	@Param			offset	query		int							false	"Number of locations to skip"
+//	@Success		200		{object}	models.LocationsResponse	"List of locations"
+//	@Failure		400		{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		500		{object}	models.ErrorResponse		"Internal Server Error"
 //	@Router			/locations [get]
 func GetLocationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		limit, offset, err := parsePagination(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		query := `
 			SELECT
 				id, stadium, address, country, capacity
 			FROM Locations
 			ORDER BY id ASC
+			LIMIT $1 OFFSET $2
 		`
 
 		// execute the query
-		rows, err := pool.Query(r.Context(), query)
+		rows, err := pool.Query(r.Context(), query, limit, offset)
 		if err != nil {
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch locations.")
 			return
@@ -54,13 +63,6 @@ func GetLocationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 				&location.Capacity,
 			)
 			if err != nil {
-				if err == pgx.ErrNoRows {
-					writeErrorResponse(
-						w,
-						http.StatusNotFound,
-						"No locations found in the database.",
-					)
-				}
 				writeErrorResponse(
 					w,
 					http.StatusInternalServerError,
@@ -71,7 +73,11 @@ func GetLocationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			locations = append(locations, location)
 		}
 		locations_response := models.LocationsResponse{Locations: locations}
-		writeJSONResponse(w, http.StatusOK, locations_response)
+		writePageResponse(w, r, pool, locations_response, locations, listPage{
+			Limit:      limit,
+			Offset:     offset,
+			FromClause: "FROM Locations",
+		})
 	}
 }
 
@@ -82,27 +88,29 @@ func GetLocationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 //	@ID				api.getLocationByID
 //	@Tags			locations
 //	@Produce		json
-//	@Param			id	path		string					true	"Location ID"
-//	@Success		200	{object}	models.LocationResponse	"Location details"
-//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
-//	@Failure		404	{object}	models.ErrorResponse	"Not Found"
+//	@Param			id					path		string					true	"Location ID"
+//	@Param			If-Modified-Since	header		string					false	"Time of the cached copy, answered with 304 if unchanged"
+//	@Success		200					{object}	models.LocationResponse	"Location details"
+//	@Success		304					"Not Modified"
+//	@Failure		500					{object}	models.ErrorResponse	"Internal Server Error"
+//	@Failure		404					{object}	models.ErrorResponse	"Not Found"
 //	@Router			/locations/{id} [get]
 func GetLocationByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-		locationID, ok := vars["id"]
-		if !ok {
+		locationID, err := parsePathID(r, "id")
+		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, "Location ID not provided in the URL.")
 			return
 		}
 
 		query := `
-			SELECT id, stadium, address, country, capacity
+			SELECT id, stadium, address, country, capacity, updated_at
 			FROM Locations
 			WHERE id = $1
 		`
 
 		var location models.LocationResponse
+		var modified time.Time
 		row := pool.QueryRow(r.Context(), query, locationID)
 
 		if err := row.Scan(
@@ -111,6 +119,7 @@ func GetLocationByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			&location.Address,
 			&location.Country,
 			&location.Capacity,
+			&modified,
 		); err != nil {
 			if err == pgx.ErrNoRows {
 				writeErrorResponse(w, http.StatusNotFound, "Location not found.")
@@ -119,10 +128,109 @@ func GetLocationByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the location.")
 			return
 		}
+		if checkNotModified(w, r, modified) {
+			return
+		}
 		writeJSONResponse(w, http.StatusOK, location)
 	}
 }
 
+// GetLocationsStatsHandler reports revenue grouped by location.
+//
+//	@Summary		Get sales statistics per location (admin only).
+//	@Description	Retrieve each location with its event count, tickets sold and gross revenue of sold tickets. Locations without activity are reported with zeros.
+//	@ID				api.getLocationsStats
+//	@Tags			locations
+//	@Produce		json
+//	@Param			from	query		string							false	"Only reservations created at or after (YYYY-MM-DD HH:MM or RFC3339)"
+//	@Param			to		query		string							false	"Only reservations created before (YYYY-MM-DD HH:MM or RFC3339)"
+//	@Success		200		{object}	models.LocationsStatsResponse	"Statistics per location"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/locations/stats [get]
+func GetLocationsStatsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		// optional date range, applied to the reservation creation time
+		conditions, args, err := appendDateRange(r, "r.created_at", nil, nil)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		reservationFilter := ""
+		if len(conditions) > 0 {
+			reservationFilter = " AND " + strings.Join(conditions, " AND ")
+		}
+
+		// left joins keep the locations without any activity
+		query := `
+			SELECT
+				l.id, l.stadium, l.address, l.country, l.capacity,
+				COUNT(DISTINCT e.id),
+				COUNT(t.id),
+				COALESCE(SUM(t.price), 0)
+			FROM locations l
+			LEFT JOIN events e ON e.location_id = l.id
+			LEFT JOIN reservations r ON r.event_id = e.id` + reservationFilter + `
+			LEFT JOIN tickets t ON t.reservation_id = r.id
+				AND t.status_id IN (SELECT id FROM ticket_statuses WHERE name IN ('SOLD', 'USED'))
+			GROUP BY l.id
+			ORDER BY l.id ASC
+		`
+		rows, err := pool.Query(r.Context(), query, args...)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch statistics.")
+			return
+		}
+		defer rows.Close()
+
+		stats := []models.LocationStatsResponse{}
+		for rows.Next() {
+			var stat models.LocationStatsResponse
+			if err := rows.Scan(
+				&stat.Location.ID,
+				&stat.Location.Stadium,
+				&stat.Location.Address,
+				&stat.Location.Country,
+				&stat.Location.Capacity,
+				&stat.EventCount,
+				&stat.TicketsSold,
+				&stat.Revenue,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse statistics.")
+				return
+			}
+			stats = append(stats, stat)
+		}
+		writeJSONResponse(
+			w,
+			http.StatusOK,
+			models.LocationsStatsResponse{Currency: currency, Locations: stats},
+		)
+	}
+}
+
+// GetCountriesHandler lists the countries accepted on locations.
+//
+//	@Summary		Get accepted countries.
+//	@Description	Retrieve the ISO 3166-1 countries locations can be placed in, ordered by name. Locations accept the alpha-2 code, alpha-3 code, name or a common alias such as USA or UK, and store the name.
+//	@ID				api.getCountries
+//	@Tags			locations
+//	@Produce		json
+//	@Success		200	{object}	models.CountriesResponse	"Accepted countries"
+//	@Router			/countries [get]
+func GetCountriesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, models.CountriesResponse{Countries: models.Countries})
+	}
+}
+
 // CreateLocationHandler creates a single location in the database.
 //
 //	@Summary		Create a new location (admin only).
@@ -134,6 +242,7 @@ func GetLocationByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
 //	@Param			body	body		models.CreateLocationRequest	true	"Payload to create a location"
 //	@Success		200		{object}	models.SuccessResponseCreate	"Location created successfully"
 //	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		415		{object}	models.ErrorResponse			"Unsupported Media Type"
 //	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
 //	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
 //	@Security		BearerAuth
@@ -151,8 +260,8 @@ func CreateLocationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 
 		// decode the request body
 		input := models.CreateLocationRequest{}
-		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON input.")
+		if status, err := decodeJSONBody(r, &input); err != nil {
+			writeErrorResponse(w, status, err.Error())
 			return
 		}
 
@@ -166,9 +275,14 @@ func CreateLocationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
-		// set default value for country
+		// set default value for country, otherwise store its canonical name
 		if input.Country == "" {
 			input.Country = "N/A"
+		} else if country, err := normalizeCountry(input.Country); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		} else {
+			input.Country = country
 		}
 
 		// execute the query
@@ -209,7 +323,9 @@ func CreateLocationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 //	@Param			body	body		models.UpdateLocationRequest	true	"Payload to update a location"
 //	@Success		200		{object}	models.SuccessResponse			"Event updated successfully"
 //	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		415		{object}	models.ErrorResponse			"Unsupported Media Type"
 //	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse			"Not Found"
 //	@Failure		422		{object}	models.ErrorResponse			"Unprocessable Entity"
 //	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
 //	@Security		BearerAuth
@@ -227,17 +343,16 @@ func UpdateLocationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		}
 
 		// parse the location ID from the URL
-		vars := mux.Vars(r)
-		locationID, ok := vars["id"]
-		if !ok {
+		locationID, err := parsePathID(r, "id")
+		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, "Location ID not provided.")
 			return
 		}
 
 		// decode the body and parse the request
 		input := models.UpdateLocationRequest{}
-		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON input.")
+		if status, err := decodeJSONBody(r, &input); err != nil {
+			writeErrorResponse(w, status, err.Error())
 			return
 		}
 
@@ -257,8 +372,13 @@ func UpdateLocationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			idx++
 		}
 		if input.Country != nil {
+			country, err := normalizeCountry(*input.Country)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
 			query += fmt.Sprintf("country = $%d, ", idx)
-			args = append(args, *input.Country)
+			args = append(args, country)
 			idx++
 		}
 		if input.Capacity != nil {
@@ -275,12 +395,35 @@ func UpdateLocationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		query = strings.TrimSuffix(query, ", ") + fmt.Sprintf(" WHERE id = $%d", idx)
 		args = append(args, locationID)
 
-		_, err := pool.Exec(r.Context(), query, args...)
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		// capacity cannot drop below the seats of events held at the location
+		if input.Capacity != nil {
+			status, err := checkLocationCapacity(r, tx, locationID, *input.Capacity)
+			if err != nil {
+				writeErrorResponse(w, status, err.Error())
+				return
+			}
+		}
+
+		_, err = tx.Exec(r.Context(), query, args...)
 		if err != nil {
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to update the location.")
 			return
 		}
 
+		if err := tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to commit transaction.")
+			return
+		}
+
+		invalidateEventCaches()
+
 		writeJSONResponse(
 			w,
 			http.StatusOK,
@@ -310,21 +453,22 @@ func DeleteLocationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		}
 
 		// parse the id
-		vars := mux.Vars(r)
-		locationID, ok := vars["id"]
-		if !ok {
+		locationID, err := parsePathID(r, "id")
+		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, "Location ID not provided.")
 			return
 		}
 
 		// delete the user
 		query := `DELETE FROM Locations WHERE id = $1`
-		_, err := pool.Exec(r.Context(), query, locationID)
+		_, err = pool.Exec(r.Context(), query, locationID)
 		if err != nil {
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to delete the location.")
 			return
 		}
 
+		invalidateEventCaches()
+
 		writeJSONResponse(
 			w,
 			http.StatusOK,
@@ -332,3 +476,314 @@ func DeleteLocationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		)
 	}
 }
+
+// MergeLocationsHandler folds duplicate locations into a single one.
+//
+//	@Summary		Merge duplicate locations (admin only).
+//	@Description	Repoint events of the merged locations to the kept one, then delete the merged locations.
+//	@ID				api.mergeLocations
+//	@Tags			locations
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		models.MergeLocationsRequest	true	"Kept and merged location IDs"
+//	@Success		200		{object}	models.MergeLocationsResponse	"Locations merged successfully"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse			"Not Found"
+//	@Failure		415		{object}	models.ErrorResponse			"Unsupported Media Type"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/locations/merge [post]
+func MergeLocationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Forbidden: Insufficient permissions")
+			return
+		}
+
+		var payload models.MergeLocationsRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		if payload.KeepID <= 0 || len(payload.MergeIDs) == 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "Missing or invalid fields.")
+			return
+		}
+
+		// the kept location cannot be merged into itself, nor any location twice
+		seen := map[int]bool{payload.KeepID: true}
+		for _, id := range payload.MergeIDs {
+			if seen[id] {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					fmt.Sprintf("Location %d listed more than once.", id),
+				)
+				return
+			}
+			seen[id] = true
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		// lock all involved locations, so none is deleted or merged concurrently
+		ids := append([]int{payload.KeepID}, payload.MergeIDs...)
+		rows, err := tx.Query(
+			r.Context(),
+			`SELECT id FROM Locations WHERE id = ANY($1) FOR UPDATE`,
+			ids,
+		)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch locations.")
+			return
+		}
+		found := map[int]bool{}
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse location.")
+				return
+			}
+			found[id] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch locations.")
+			return
+		}
+		for _, id := range ids {
+			if !found[id] {
+				writeErrorResponse(
+					w,
+					http.StatusNotFound,
+					fmt.Sprintf("Location %d not found.", id),
+				)
+				return
+			}
+		}
+
+		tag, err := tx.Exec(
+			r.Context(),
+			`UPDATE Events SET location_id = $1 WHERE location_id = ANY($2)`,
+			payload.KeepID, payload.MergeIDs,
+		)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to repoint events.")
+			return
+		}
+		repointed := int(tag.RowsAffected())
+
+		if _, err := tx.Exec(
+			r.Context(),
+			`DELETE FROM Locations WHERE id = ANY($1)`,
+			payload.MergeIDs,
+		); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to delete locations.")
+			return
+		}
+
+		if err = tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to commit transaction.")
+			return
+		}
+
+		invalidateEventCaches()
+
+		writeJSONResponse(w, http.StatusOK, models.MergeLocationsResponse{
+			Message:         "Locations merged successfully.",
+			RepointedEvents: repointed,
+			DeletedIDs:      payload.MergeIDs,
+		})
+	}
+}
+
+// GetLocationConflictsHandler lists events exceeding the capacity of a location.
+//
+//	@Summary		Get capacity conflicts of a location (admin only).
+//	@Description	Retrieve events at the location, whose available tickets along with issued tickets that were not cancelled exceed its current capacity, e.g. after the capacity was reduced. Empty if the location is consistent.
+//	@ID				api.getLocationConflicts
+//	@Tags			locations
+//	@Produce		json
+//	@Param			id	path		string								true	"Location ID"
+//	@Success		200	{object}	models.CapacityConflictsResponse	"Events exceeding the capacity"
+//	@Failure		400	{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		403	{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse				"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse				"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/locations/{id}/conflicts [get]
+func GetLocationConflictsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		locationID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Location ID not provided in the URL.")
+			return
+		}
+
+		response := models.CapacityConflictsResponse{
+			Conflicts: []models.CapacityConflictResponse{},
+		}
+		locationQuery := `SELECT id, capacity FROM locations WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), locationQuery, locationID).
+			Scan(&response.LocationID, &response.Capacity); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Location not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the location.")
+			return
+		}
+
+		// seats counted the same way as when the capacity is validated
+		query := `
+			SELECT e.id, e.name, e.date, e.available_tickets, COUNT(t.id)
+			FROM events e
+			LEFT JOIN reservations res ON res.event_id = e.id
+			LEFT JOIN tickets t ON t.reservation_id = res.id
+				AND t.status_id <> (SELECT id FROM ticket_statuses WHERE name = 'CANCELLED')
+			WHERE e.location_id = $1
+			GROUP BY e.id
+			HAVING e.available_tickets + COUNT(t.id) > $2
+			ORDER BY e.date ASC, e.id
+		`
+		rows, err := pool.Query(r.Context(), query, locationID, response.Capacity)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch events.")
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var conflict models.CapacityConflictResponse
+			if err := rows.Scan(
+				&conflict.EventID,
+				&conflict.Name,
+				&conflict.Date,
+				&conflict.AvailableTickets,
+				&conflict.TicketsIssued,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse events.")
+				return
+			}
+			conflict.Seats = conflict.AvailableTickets + conflict.TicketsIssued
+			conflict.Excess = conflict.Seats - response.Capacity
+			response.Conflicts = append(response.Conflicts, conflict)
+		}
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// GetLocationEventsHandler lists all events taking place at given location.
+//
+//	@Summary		Get events at a location.
+//	@Description	Retrieve the schedule of a location, ordered by date. Optionally only upcoming events.
+//	@ID				api.getLocationEvents
+//	@Tags			locations
+//	@Produce		json
+//	@Param			id			path		string					true	"Location ID"
+//	@Param			upcoming	query		bool					false	"Only events that have not taken place yet"
+//	@Param			tz			query		string					false	"IANA timezone of returned dates, e.g. America/New_York (default UTC)"
+//	@Success		200			{object}	models.EventsResponse	"List of events at the location"
+//	@Failure		400			{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		404			{object}	models.ErrorResponse	"Not Found"
+//	@Failure		500			{object}	models.ErrorResponse	"Internal Server Error"
+//	@Router			/locations/{id}/events [get]
+func GetLocationEventsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locationID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Location ID not provided in the URL.")
+			return
+		}
+
+		// optional filter for upcoming events
+		upcoming := false
+		if param := r.URL.Query().Get("upcoming"); param != "" {
+			value, err := strconv.ParseBool(param)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "Invalid value for upcoming.")
+				return
+			}
+			upcoming = value
+		}
+
+		loc, err := parseTimezone(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// check if the location exists
+		var exists bool
+		existsQuery := `SELECT EXISTS(SELECT 1 FROM Locations WHERE id = $1)`
+		if err := pool.QueryRow(r.Context(), existsQuery, locationID).Scan(&exists); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the location.")
+			return
+		}
+		if !exists {
+			writeErrorResponse(w, http.StatusNotFound, "Location not found.")
+			return
+		}
+
+		query := `
+			SELECT
+				e.id, e.name, e.date, e.price, e.available_tickets,
+				l.id, l.stadium, l.address, l.country, l.capacity
+			FROM events e
+			JOIN locations l ON e.location_id = l.id
+			WHERE e.location_id = $1 AND e.visibility = 'PUBLIC'
+		`
+		if upcoming {
+			query += " AND e.date > NOW()"
+		}
+		query += " ORDER BY e.date ASC"
+
+		rows, err := pool.Query(r.Context(), query, locationID)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch events.")
+			return
+		}
+		defer rows.Close()
+
+		// results in event and attached location information
+		events := []models.EventResponse{}
+		for rows.Next() {
+			var event models.EventResponse
+			var location models.LocationResponse
+
+			if err := rows.Scan(
+				&event.ID,
+				&event.Name,
+				&event.Date,
+				&event.Price,
+				&event.AvailableTickets,
+				&location.ID,
+				&location.Stadium,
+				&location.Address,
+				&location.Country,
+				&location.Capacity,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse events.")
+				return
+			}
+
+			event.Date = event.Date.In(loc)
+			event.Location = location
+			event.Currency = currency
+			events = append(events, event)
+		}
+		writeListResponse(w, models.EventsResponse{Events: events}, events)
+	}
+}
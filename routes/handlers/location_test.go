@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMergeLocationsWithPastEvents(t *testing.T) {
+	pool := testPool(t)
+	keepID := createTestLocation(t, pool)
+	mergeID := createTestLocation(t, pool)
+	eventID := createPastTestEvent(t, pool, mergeID)
+
+	body := fmt.Sprintf(`{"keep_id": %d, "merge_ids": [%d]}`, keepID, mergeID)
+	r := newTestRequest(http.MethodPost, "/api/locations/merge", body)
+	rec := serve(MergeLocationsHandler(pool), withUser(r, uuid.NewString(), "ADMIN"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	var locationID int
+	query := `SELECT location_id FROM events WHERE id = $1`
+	if err := pool.QueryRow(context.Background(), query, eventID).Scan(&locationID); err != nil {
+		t.Fatalf("failed to fetch the event: %v", err)
+	}
+	if locationID != keepID {
+		t.Fatalf("location of the past event = %d, want %d", locationID, keepID)
+	}
+}
+
+func TestEventDateInThePastIsRejected(t *testing.T) {
+	pool := testPool(t)
+	locationID := createTestLocation(t, pool)
+
+	query := `
+		INSERT INTO events (name, date, price, location_id, available_tickets)
+		VALUES ('Test Event', $1, 100, $2, 100)
+	`
+	past := time.Now().AddDate(0, 0, -1)
+	if _, err := pool.Exec(context.Background(), query, past, locationID); err == nil {
+		t.Fatal("event dated in the past was inserted")
+	}
+
+	eventID := createTestEvent(t, pool, locationID)
+	query = `UPDATE events SET date = $2 WHERE id = $1`
+	if _, err := pool.Exec(context.Background(), query, eventID, past); err == nil {
+		t.Fatal("event was moved into the past")
+	}
+}
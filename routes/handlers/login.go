@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 
@@ -12,26 +14,35 @@ import (
 	"event-reservation-api/models"
 )
 
+// Hash compared against when the user does not exist, so unknown usernames
+// take as long to reject as wrong passwords.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword(
+	[]byte("dummy-password"),
+	bcrypt.DefaultCost,
+)
+
 // Login handler facilitates the login process.
 //
 //	@Summary		Login to the API.
-//	@Description	Pass username and password to authenticate and get a JWT token.
+//	@Description	Pass username and password to authenticate and get a JWT token. Users with two-factor authentication enabled get a challenge instead, to be answered with a code at /auth/2fa/verify.
 //	@ID				api.login
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		models.LoginRequest		true	"Login credentials"
-//	@Success		200		{object}	models.LoginResponse	"Successfully logged in"
-//	@Failure		400		{object}	models.ErrorResponse	"Bad Request"
-//	@Failure		401		{object}	models.ErrorResponse	"Unauthorized"
-//	@Failure		500		{object}	models.ErrorResponse	"Internal Server Error"
+//	@Param			body	body		models.LoginRequest					true	"Login credentials"
+//	@Success		200		{object}	models.LoginResponse				"Successfully logged in"
+//	@Success		202		{object}	models.TwoFactorChallengeResponse	"Two-factor code required"
+//	@Failure		400		{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		415		{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		401		{object}	models.ErrorResponse				"Unauthorized"
+//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
 //	@Router			/login [post]
 func LoginHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// parse login request
 		var loginReq models.LoginRequest
-		if err := json.NewDecoder(r.Body).Decode(&loginReq); err != nil {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload.")
+		if status, err := decodeJSONBody(r, &loginReq); err != nil {
+			writeErrorResponse(w, status, err.Error())
 			return
 		}
 
@@ -43,19 +54,45 @@ func LoginHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
 			FROM users u
 			JOIN roles r ON u.role_id = r.id
 			WHERE u.username = $1`
-		if err := pool.QueryRow(
+		err := pool.QueryRow(
 			context.Background(), query, loginReq.Username,
-		).Scan(&userID, &hashedPassword, &role); err != nil {
-			writeErrorResponse(w, http.StatusNotFound, "User not found.")
+		).Scan(&userID, &hashedPassword, &role)
+		if err != nil && err != pgx.ErrNoRows {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
 			return
 		}
+		userFound := err == nil
+		if !userFound {
+			hashedPassword = string(dummyPasswordHash)
+		}
 
-		// check if the password matches the hash
-		if err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(loginReq.Password)); err != nil {
+		// compare the password even for unknown users, so both cases are
+		// indistinguishable by the response and its timing
+		err = bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(loginReq.Password))
+		if err != nil || !userFound {
 			writeErrorResponse(w, http.StatusUnauthorized, "Invalid username or password.")
 			return
 		}
 
+		// users with a second factor get the token only once they provide a code
+		var twoFactor bool
+		query = `SELECT EXISTS(SELECT 1 FROM user_totp WHERE user_id = $1 AND enabled)`
+		if err := pool.QueryRow(r.Context(), query, userID).Scan(&twoFactor); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
+			return
+		}
+		if twoFactor {
+			expiresAt := time.Now().
+				Add(twoFactorChallengeMinutes * time.Minute).
+				Truncate(time.Second)
+			writeJSONResponse(w, http.StatusAccepted, models.TwoFactorChallengeResponse{
+				TwoFactorRequired: true,
+				Challenge:         signTwoFactorChallenge(userID, expiresAt, jwtSecret),
+				ExpiresAt:         expiresAt.UTC(),
+			})
+			return
+		}
+
 		// get the token validity duration from env variable, otherwise 24 hours
 		tokenString, exp, err := middlewares.GenerateJWT(userID, role, jwtSecret)
 		if err != nil {
@@ -67,6 +104,12 @@ func LoginHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
 			return
 		}
 
+		// track the session, so the user can list and revoke it later
+		if err := createSession(r, pool, userID, tokenString, exp); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to create the session.")
+			return
+		}
+
 		// send the token back to client
 		writeTokenResponse(w, tokenString, exp, userID, loginReq.Username)
 	}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestLoginDoesNotRevealUsers(t *testing.T) {
+	pool := testPool(t)
+	_, username := createTestUser(t, pool, "REGISTERED")
+	handler := LoginHandler(pool, "test-secret")
+
+	login := func(username, password string) (int, string) {
+		body := fmt.Sprintf(`{"username": %q, "password": %q}`, username, password)
+		rec := serve(handler, newTestRequest(http.MethodPost, "/api/login", body))
+		return rec.Code, rec.Body.String()
+	}
+
+	unknownStatus, unknownBody := login(username+"_unknown", testPassword)
+	wrongStatus, wrongBody := login(username, "wrong-password")
+
+	if unknownStatus != http.StatusUnauthorized {
+		t.Fatalf("status for an unknown user = %d, want %d", unknownStatus, http.StatusUnauthorized)
+	}
+	if wrongStatus != unknownStatus || wrongBody != unknownBody {
+		t.Fatalf(
+			"responses differ: unknown user got %d %q, wrong password got %d %q",
+			unknownStatus, unknownBody, wrongStatus, wrongBody,
+		)
+	}
+
+	if status, _ := login(username, testPassword); status != http.StatusOK {
+		t.Fatalf("status for valid credentials = %d, want %d", status, http.StatusOK)
+	}
+}
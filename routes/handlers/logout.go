@@ -6,8 +6,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgxpool"
-
 	"event-reservation-api/middlewares"
 	"event-reservation-api/models"
 )
@@ -24,7 +22,7 @@ import (
 //	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/logout [post]
-func LogoutHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+func LogoutHandler(blacklist middlewares.TokenBlacklist, jwtSecret string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// extract the claims from the token
 		tokenString, err := middlewares.ExtractToken(r)
@@ -52,7 +50,12 @@ func LogoutHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
 		}
 
 		// invalidate current token
-		if err := invalidateToken(pool, tokenString, expirationTime); err != nil {
+		if err := invalidateToken(
+			r.Context(),
+			blacklist,
+			tokenString,
+			expirationTime,
+		); err != nil {
 			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
@@ -67,14 +70,14 @@ func LogoutHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
 }
 
 // Invalidate the token by adding it to the blacklist.
-func invalidateToken(pool *pgxpool.Pool, tokenString string, expirationTime float64) error {
-	query := `INSERT INTO token_blacklist (token, expires_at) VALUES ($1, $2)`
-	if _, err := pool.Exec(
-		context.Background(),
-		query,
-		tokenString,
-		time.Unix(int64(expirationTime), 0),
-	); err != nil {
+func invalidateToken(
+	ctx context.Context,
+	blacklist middlewares.TokenBlacklist,
+	tokenString string,
+	expirationTime float64,
+) error {
+	expiresAt := time.Unix(int64(expirationTime), 0)
+	if err := blacklist.Add(ctx, tokenString, expiresAt); err != nil {
 		return fmt.Errorf("Failed to invalidate the token.")
 	}
 	return nil
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// GetReservationPaymentsHandler lists the payments of a reservation.
+//
+//	@Summary		List payments of a reservation (owner/admin only).
+//	@Description	Retrieve the payments recorded for the reservation with their status and amount, oldest first. Unpaid reservations have none.
+//	@Tags			reservations
+//	@ID				api.getReservationPayments
+//	@Produce		json
+//	@Param			id	path		string								true	"Reservation ID"
+//	@Success		200	{object}	models.ReservationPaymentsResponse	"Payments of the reservation"
+//	@Failure		400	{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		403	{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse				"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse				"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/{id}/payments [get]
+func GetReservationPaymentsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reservationId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var ownerId string
+		query := `SELECT user_id FROM reservations WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), query, reservationId).Scan(&ownerId); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+				return
+			}
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the reservation.",
+			)
+			return
+		}
+
+		if !isStaffOrAdmin(r) && !isOwner(r, ownerId) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		query = `
+			SELECT p.id, ps.name, p.total_amount, p.payment_date
+			FROM payment p
+			JOIN payment_statuses ps ON p.status_id = ps.id
+			WHERE p.order_id = $1
+			ORDER BY p.payment_date, p.id
+		`
+		rows, err := pool.Query(r.Context(), query, reservationId)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch payments.")
+			return
+		}
+		defer rows.Close()
+
+		payments := []models.PaymentResponse{}
+		for rows.Next() {
+			payment := models.PaymentResponse{Currency: currency}
+			if err := rows.Scan(
+				&payment.ID, &payment.Status, &payment.Amount, &payment.PaidAt,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse payments.")
+				return
+			}
+			payments = append(payments, payment)
+		}
+		if err := rows.Err(); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch payments.")
+			return
+		}
+
+		writeListResponse(
+			w,
+			models.ReservationPaymentsResponse{ReservationID: reservationId, Payments: payments},
+			payments,
+		)
+	}
+}
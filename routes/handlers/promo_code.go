@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// Reasons a promo code cannot be applied.
+const (
+	promoCodeNotFound  = "not_found"
+	promoCodeExpired   = "expired"
+	promoCodeExhausted = "exhausted"
+)
+
+// ValidatePromoCodeHandler checks a promo code against an event, without using it.
+//
+//	@Summary		Validate a promo code.
+//	@Description	Check whether the promo code can be applied, returning its percent off and the discounted base price of the event. Invalid codes return the reason (not_found, expired, exhausted). The code is not used up.
+//	@Tags			promo-codes
+//	@ID				api.validatePromoCode
+//	@Produce		json
+//	@Param			code		path		string								true	"Promo code"
+//	@Param			event_id	query		int									true	"Event ID"
+//	@Success		200			{object}	models.PromoCodeValidationResponse	"Validation result"
+//	@Failure		400			{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		404			{object}	models.ErrorResponse				"Not Found"
+//	@Failure		500			{object}	models.ErrorResponse				"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/promo-codes/{code}/validate [get]
+func ValidatePromoCodeHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code, err := parsePathID(r, "code")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		eventId, err := strconv.Atoi(r.URL.Query().Get("event_id"))
+		if err != nil || eventId <= 0 {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				"Invalid event_id; must be a positive integer.",
+			)
+			return
+		}
+
+		response := models.PromoCodeValidationResponse{
+			Code:     strings.ToUpper(code),
+			EventID:  eventId,
+			Currency: currency,
+		}
+		var basePrice float64
+		eventQuery := `SELECT price FROM events WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), eventQuery, eventId).Scan(&basePrice); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the event.")
+			return
+		}
+		response.BasePrice = models.Money(basePrice)
+
+		percentOff, reason, err := checkPromoCode(r.Context(), pool, response.Code, time.Now())
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the promo code.",
+			)
+			return
+		}
+		if reason != "" {
+			response.Reason = reason
+			writeJSONResponse(w, http.StatusOK, response)
+			return
+		}
+
+		// rounded the same way as the listed ticket prices
+		discounted := models.Money(math.Round(basePrice*(100-percentOff)) / 100)
+		response.Valid = true
+		response.PercentOff = percentOff
+		response.DiscountedPrice = &discounted
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// Check if the promo code can be applied at the given time, returning its
+// percent off, or the reason it cannot be applied. Codes are not used up, so
+// applying one has to repeat the check while holding a lock on its row.
+func checkPromoCode(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	code string,
+	now time.Time,
+) (float64, string, error) {
+	var percentOff float64
+	var expiresAt *time.Time
+	var maxUses *int
+	var uses int
+	query := `
+		SELECT percent_off, expires_at, max_uses, uses
+		FROM promo_codes
+		WHERE code = $1
+	`
+	err := pool.QueryRow(ctx, query, code).Scan(&percentOff, &expiresAt, &maxUses, &uses)
+	if err == pgx.ErrNoRows {
+		return 0, promoCodeNotFound, nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	if expiresAt != nil && !now.Before(*expiresAt) {
+		return 0, promoCodeExpired, nil
+	}
+	if maxUses != nil && uses >= *maxUses {
+		return 0, promoCodeExhausted, nil
+	}
+	return percentOff, "", nil
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"event-reservation-api/models"
+)
+
+// Fetch the amount paid for the reservation, which can still be refunded.
+// Only sold tickets were paid for, and earlier refunds are deducted.
+func fetchRefundableAmount(ctx context.Context, tx pgx.Tx, reservationID string) (float64, error) {
+	query := `
+		SELECT
+			COALESCE((
+				SELECT SUM(t.price)
+				FROM tickets t
+				JOIN ticket_statuses ts ON t.status_id = ts.id
+				WHERE t.reservation_id = $1 AND ts.name = 'SOLD'
+			), 0) - COALESCE((
+				SELECT SUM(amount) FROM refunds WHERE reservation_id = $1
+			), 0)
+	`
+	var amount float64
+	if err := tx.QueryRow(ctx, query, reservationID).Scan(&amount); err != nil {
+		return 0.0, fmt.Errorf("Failed to fetch the paid amount: %w", err)
+	}
+	return max(amount, 0), nil
+}
+
+// Record a refund of the reservation, issued by the given user.
+func insertRefund(
+	ctx context.Context,
+	tx pgx.Tx,
+	reservationID string,
+	amount float64,
+	reason *string,
+	refundedBy string,
+) (models.RefundResponse, error) {
+	query := `
+		INSERT INTO refunds (reservation_id, amount, reason, refunded_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, amount, reason, created_at
+	`
+	refund := models.RefundResponse{ReservationID: reservationID, Currency: currency}
+	var refunded float64
+	if err := tx.QueryRow(
+		ctx, query, reservationID, amount, reason, refundedBy,
+	).Scan(&refund.ID, &refunded, &refund.Reason, &refund.CreatedAt); err != nil {
+		return models.RefundResponse{}, fmt.Errorf("Failed to record the refund: %w", err)
+	}
+	refund.Amount = models.Money(refunded)
+	return refund, nil
+}
@@ -1,9 +1,16 @@
 package handlers
 
 import (
-	"encoding/json"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -12,39 +19,93 @@ import (
 
 // GetReservationHandler lists all reservations.
 //
-//	@Summary		List all reservations (admin only).
-//	@Description	Retrieve a list of all reservations, including their details and tickets they reserve.
+//	@Summary		List all reservations (admin or staff).
+//	@Description	Retrieve a list of all reservations, including their details and tickets they reserve. When sorted by created_at, full pages carry a cursor of the next one, which stays consistent under concurrent inserts, unlike the offset.
 //	@Tags			reservations
 //	@ID				api.getReservations
 //	@Produce		json
-//	@Success		200	{object}	models.ReservationsResponse	"List of reservations"
-//	@Failure		403	{object}	models.ErrorResponse		"Forbidden"
-//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
-//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
+//	@Param			status			query		string						false	"Filter by reservation status"
+//	@Param			ticket_status	query		string						false	"Filter by reservations having a ticket in this status"
+//	@Param			username		query		string						false	"Filter by username of the buyer; * matches any characters, case-insensitively"
+//	@Param			from			query		string						false	"Only reservations created at or after (YYYY-MM-DD HH:MM or RFC3339)"
+//	@Param			to				query		string						false	"Only reservations created before (YYYY-MM-DD HH:MM or RFC3339)"
+//	@Param			sort			query		string						false	"Sort by created_at, total_tickets or event_date"
+//	@Param			order			query		string						false	"Sort order, asc or desc (default desc)"
+//	@Param			limit			query		int							false	"Maximum number of reservations"
+//	@Param			offset			query		int							false	"Number of reservations to skip"
+//	@Param			after			query		string						false	"Cursor of the next page, taken from next_cursor (requires sorting by created_at)"
+//	@Param			include_tickets	query		bool						false	"Include the tickets of each reservation (default true)"
+//	@Success		200				{object}	models.ReservationsResponse	"List of reservations"
+//	@Failure		400				{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		403				{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		500				{object}	models.ErrorResponse		"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/reservations [get]
 func GetReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if !isAdmin(r) {
+		if !isStaffOrAdmin(r) {
 			writeErrorResponse(w, http.StatusBadRequest, "Insufficient permissions.")
 			return
 		}
 
+		// build the filters from query parameters
+		whereClause, args, status, err := getReservationsWhereClause(r, pool)
+		if err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		orderClause, err := getReservationsOrderClause(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// the total covers all matching reservations, regardless of the cursor
+		page := listPage{
+			FromClause: `
+				FROM Reservations r
+				JOIN reservation_statuses rs ON r.status_id = rs.id
+				JOIN Users u ON r.user_id = u.id
+				JOIN Events e ON r.event_id = e.id
+				JOIN Locations l ON e.location_id = l.id
+			` + whereClause,
+			Args: args,
+		}
+
+		// keyset pagination, if the cursor is provided
+		whereClause, args, err = getReservationsCursorClause(r, whereClause, args)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		limit, offset, err := parsePagination(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		page.Limit, page.Offset = limit, offset
+
+		includeTickets, err := parseIncludeTickets(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		args = append(args[:len(args):len(args)], limit, offset)
+
 		query := `
-			SELECT r.id, u.username, r.created_at, r.total_tickets, rs.name,
+			SELECT r.id, u.username, r.created_at, r.total_tickets, rs.name, r.notes,
 				e.id, e.name, e.date, l.country, l.address, l.stadium
 			FROM Reservations r
 			JOIN reservation_statuses rs ON r.status_id = rs.id
 			JOIN Users u ON r.user_id = u.id
 			JOIN Events e ON r.event_id = e.id
 			JOIN Locations l ON e.location_id = l.id
-		`
-		rows, err := pool.Query(r.Context(), query)
+		` + whereClause + " " + orderClause + fmt.Sprintf(`
+			LIMIT $%d OFFSET $%d
+		`, len(args)-1, len(args))
+		rows, err := pool.Query(r.Context(), query, args...)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				writeErrorResponse(w, http.StatusNotFound, "Reservations not found.")
-				return
-			}
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
@@ -62,7 +123,7 @@ func GetReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			var event models.EventResponse
 
 			if err := rows.Scan(
-				&res.ID, &res.Username, &res.CreatedAt, &res.TotalTickets, &res.Status,
+				&res.ID, &res.Username, &res.CreatedAt, &res.TotalTickets, &res.Status, &res.Notes,
 				&event.ID, &event.Name, &event.Date,
 				&location.Country, &location.Address, &location.Stadium,
 			); err != nil {
@@ -78,121 +139,244 @@ func GetReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			event.Location = location
 			res.Event = event
 
-			tickets, err := fetchTickets(r.Context(), pool, res.ID)
-			if err != nil {
-				writeErrorResponse(
-					w,
-					http.StatusInternalServerError,
-					"Failed to fetch the tickets.",
-				)
-				return
-			}
+			if includeTickets {
+				tickets, err := fetchTickets(r.Context(), pool, res.ID, true)
+				if err != nil {
+					writeErrorResponse(
+						w,
+						http.StatusInternalServerError,
+						"Failed to fetch the tickets.",
+					)
+					return
+				}
 
-			// append ticket struct to the response
-			res.Tickets = tickets
+				// append ticket struct to the response
+				res.Tickets = tickets
+			}
 			reservations = append(reservations, res)
 		}
 		reservations_response := models.ReservationsResponse{Reservations: reservations}
-		writeJSONResponse(w, http.StatusOK, reservations_response)
+
+		// a full page may be followed by another one
+		sortColumn, _, _ := parseReservationsSort(r)
+		if sortColumn == reservationSortColumns["created_at"] && len(reservations) == limit {
+			last := reservations[len(reservations)-1]
+			reservations_response.NextCursor = encodeReservationCursor(last.CreatedAt, last.ID)
+			page.NextCursor = reservations_response.NextCursor
+		}
+		writePageResponse(w, r, pool, reservations_response, reservations, page)
 	}
 }
 
-// GetReservationByIDHandler returns a handler function that returns a single reservation.
+// ExportReservationsHandler exports reservations as CSV.
 //
-//	@Summary		Get a reservation by ID (admin/owner only).
-//	@Description	Retrieve a single reservation, including their details and tickets they reserve.
+//	@Summary		Export reservations as CSV (admin only).
+//	@Description	Stream reservations matching the filters as CSV, one row per reservation. Accepts the same filters and sorting as the reservation list, without pagination.
 //	@Tags			reservations
-//	@ID				api.getReservationsByID
-//	@Produce		json
-//	@Param			id	path		string						true	"Reservation ID"
-//	@Success		200	{object}	models.ReservationResponse	"Reservation details"
-//	@Failure		403	{object}	models.ErrorResponse		"Forbidden"
-//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
-//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
+//	@ID				api.exportReservations
+//	@Produce		text/csv
+//	@Param			status			query		string					false	"Filter by reservation status"
+//	@Param			ticket_status	query		string					false	"Filter by reservations having a ticket in this status"
+//	@Param			username		query		string					false	"Filter by username of the buyer; * matches any characters, case-insensitively"
+//	@Param			from			query		string					false	"Only reservations created at or after (YYYY-MM-DD HH:MM or RFC3339)"
+//	@Param			to				query		string					false	"Only reservations created before (YYYY-MM-DD HH:MM or RFC3339)"
+//	@Param			sort			query		string					false	"Sort by created_at, total_tickets or event_date"
+//	@Param			order			query		string					false	"Sort order, asc or desc (default desc)"
+//	@Success		200				{string}	string					"CSV file"
+//	@Failure		400				{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		403				{object}	models.ErrorResponse	"Forbidden"
+//	@Failure		500				{object}	models.ErrorResponse	"Internal Server Error"
 //	@Security		BearerAuth
-//	@Router			/reservations/{id} [get]
-func GetReservationByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
+//	@Router			/reservations/export [get]
+func ExportReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// get the user id
-		userId, err := getUserIdFromContext(r.Context())
-		if err != nil {
-			writeErrorResponse(
-				w,
-				http.StatusInternalServerError,
-				"Failed to fetch the user identifier.",
-			)
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
 			return
 		}
 
-		// permissions
-		if !isAdmin(r) && !isOwner(r, userId) {
-			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+		whereClause, args, status, err := getReservationsWhereClause(r, pool)
+		if err != nil {
+			writeErrorResponse(w, status, err.Error())
 			return
 		}
-
-		reservationId, err := parseReservationIdFromURL(r)
+		orderClause, err := getReservationsOrderClause(r)
 		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
 		}
 
-		var res models.ReservationResponse
-		var location models.LocationResponse
-		var event models.EventResponse
-
-		// fetch the reservation details
+		// the query is bound to the request, so a disconnect cancels it
 		query := `
-			SELECT r.id, u.username, r.created_at, r.total_tickets, rs.name,
-				e.name, e.date, l.country, l.address, l.stadium
+			SELECT r.id, u.username, r.created_at, rs.name, r.total_tickets,
+				e.id, e.name, e.date, l.stadium, l.country
 			FROM Reservations r
 			JOIN reservation_statuses rs ON r.status_id = rs.id
 			JOIN Users u ON r.user_id = u.id
 			JOIN Events e ON r.event_id = e.id
 			JOIN Locations l ON e.location_id = l.id
-			WHERE r.id = $1
-		`
-		if err := pool.QueryRow(r.Context(), query, reservationId).Scan(
-			&res.ID,
-			&res.Username,
-			&res.CreatedAt,
-			&res.TotalTickets,
-			&res.Status,
-			&event.Name,
-			&event.Date,
-			&location.Country,
-			&location.Address,
-			&location.Stadium,
-		); err != nil {
-			writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+		` + whereClause + " " + orderClause
+		rows, err := pool.Query(r.Context(), query, args...)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch reservations.")
+			return
+		}
+		defer rows.Close()
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="reservations.csv"`)
+		w.WriteHeader(http.StatusOK)
+
+		writer := csv.NewWriter(w)
+		_ = writer.Write([]string{
+			"id", "username", "created_at", "status", "total_tickets",
+			"event_id", "event_name", "event_date", "stadium", "country",
+		})
+		for rows.Next() {
+			var id, username, resStatus, eventName, stadium, country string
+			var createdAt, eventDate time.Time
+			var totalTickets, eventID int
+			if err := rows.Scan(
+				&id, &username, &createdAt, &resStatus, &totalTickets,
+				&eventID, &eventName, &eventDate, &stadium, &country,
+			); err != nil {
+				// headers are already sent, the truncated file is all we can do
+				log.Printf("Failed to export reservations: %v", err)
+				return
+			}
+			if err := writer.Write([]string{
+				id, username, createdAt.Format(time.RFC3339), resStatus,
+				strconv.Itoa(totalTickets), strconv.Itoa(eventID), eventName,
+				eventDate.Format(time.RFC3339), stadium, country,
+			}); err != nil {
+				// client is gone
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			log.Printf("Failed to export reservations: %v", err)
 			return
 		}
+		writer.Flush()
+	}
+}
 
-		// fetch the tickets associated with the reservation
-		tickets, err := fetchTickets(r.Context(), pool, res.ID)
+// GetReservationByIDHandler returns a handler function that returns a single reservation.
+//
+//	@Summary		Get a reservation by ID (admin/owner only).
+//	@Description	Retrieve a single reservation, including their details and tickets they reserve.
+//	@Tags			reservations
+//	@ID				api.getReservationsByID
+//	@Produce		json
+//	@Param			id					path		string						true	"Reservation ID"
+//	@Param			include_cancelled	query		bool						false	"Include cancelled tickets"
+//	@Success		200					{object}	models.ReservationResponse	"Reservation details"
+//	@Failure		400					{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		403					{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		404					{object}	models.ErrorResponse		"Not Found"
+//	@Failure		500					{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/{id} [get]
+func GetReservationByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reservationId, err := parsePathID(r, "id")
 		if err != nil {
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets")
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		// append to the response
-		event.Location = location
-		res.Tickets = tickets
-		res.Event = event
+		writeReservation(w, r, pool, reservationId)
+	}
+}
+
+// Write the reservation along with its tickets, provided the user is its owner,
+// an admin or staff.
+func writeReservation(
+	w http.ResponseWriter,
+	r *http.Request,
+	pool *pgxpool.Pool,
+	reservationId string,
+) {
+	includeCancelled, err := parseIncludeCancelled(r)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var res models.ReservationResponse
+	var location models.LocationResponse
+	var event models.EventResponse
+	var ownerId string
+
+	// fetch the reservation details
+	query := `
+		SELECT r.id, r.user_id, u.username, r.created_at, r.total_tickets, rs.name, r.notes,
+			e.name, e.date, l.country, l.address, l.stadium
+		FROM Reservations r
+		JOIN reservation_statuses rs ON r.status_id = rs.id
+		JOIN Users u ON r.user_id = u.id
+		JOIN Events e ON r.event_id = e.id
+		JOIN Locations l ON e.location_id = l.id
+		WHERE r.id = $1
+	`
+	if err := pool.QueryRow(r.Context(), query, reservationId).Scan(
+		&res.ID,
+		&ownerId,
+		&res.Username,
+		&res.CreatedAt,
+		&res.TotalTickets,
+		&res.Status,
+		&res.Notes,
+		&event.Name,
+		&event.Date,
+		&location.Country,
+		&location.Address,
+		&location.Stadium,
+	); err != nil {
+		writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+		return
+	}
+
+	// permissions, checked against the owner of the reservation
+	if !isStaffOrAdmin(r) && !isOwner(r, ownerId) {
+		writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+		return
+	}
+
+	// fetch the tickets associated with the reservation
+	tickets, err := fetchTickets(r.Context(), pool, res.ID, includeCancelled)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets")
+		return
+	}
 
-		writeJSONResponse(w, http.StatusOK, res)
+	// notes are internal, only admins and staff can see them
+	if !isStaffOrAdmin(r) {
+		res.Notes = nil
 	}
+
+	// append to the response
+	event.Location = location
+	res.Tickets = tickets
+	res.Event = event
+	res.HoldExpiresAt = holdExpiresAt(res.Status, res.CreatedAt)
+
+	writeJSONResponse(w, http.StatusOK, res)
 }
 
 // GetCurrentUserReservationsHandler lists all reservations for currently logged in user.
 //
 //	@Summary		List user reservations for currently logged in user.
-//	@Description	Retrieve a list of current user's reservations along with details and tickets they reserve.
+//	@Description	Retrieve a list of current user's reservations along with details and tickets they reserve, ordered by the event date. Optionally only those for past or upcoming events.
 //	@Tags			reservations
 //	@ID				api.getReservationsForCurrentUser
 //	@Produce		json
-//	@Success		200	{object}	models.ReservationsResponse	"List of reservations for the user"
-//	@Failure		400	{object}	models.ErrorResponse		"Bad Request"
-//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
-//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
+//	@Param			when			query		string						false	"Filter by the event date relative to now"	Enums(past, upcoming, all)	default(all)
+//	@Param			include_tickets	query		bool						false	"Include the tickets of each reservation (default true)"
+//	@Success		200				{object}	models.ReservationsResponse	"List of reservations for the user"
+//	@Failure		400				{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		403				{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		500				{object}	models.ErrorResponse		"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/reservations/user [get]
 func GetCurrentUserReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
@@ -213,21 +397,97 @@ func GetCurrentUserReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
+		when, err := parseReservationWhen(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		includeTickets, err := parseIncludeTickets(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		reservations, err := fetchUserReservations(
+			r.Context(), pool, userID, when, includeTickets,
+		)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch reservations.",
+			)
+			return
+		}
+
+		writeListResponse(
+			w,
+			models.ReservationsResponse{Reservations: reservations},
+			reservations,
+		)
+	}
+}
+
+// GetReservationsCreatedByCurrentUserHandler lists reservations made by currently logged in staff.
+//
+//	@Summary		List reservations created by currently logged in admin or staff.
+//	@Description	Retrieve reservations the current user made, either on behalf of other users or through impersonation, along with details and tickets they reserve, newest first.
+//	@Tags			reservations
+//	@ID				api.getReservationsCreatedByCurrentUser
+//	@Produce		json
+//	@Param			limit			query		int							false	"Maximum number of reservations"
+//	@Param			offset			query		int							false	"Number of reservations to skip"
+//	@Param			include_tickets	query		bool						false	"Include the tickets of each reservation (default true)"
+//	@Success		200				{object}	models.ReservationsResponse	"List of reservations created by the user"
+//	@Failure		400				{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		403				{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		500				{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/created-by-me [get]
+func GetReservationsCreatedByCurrentUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isStaffOrAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		userID, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the user id.",
+			)
+			return
+		}
+
+		limit, offset, err := parsePagination(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		includeTickets, err := parseIncludeTickets(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		query := `
-			SELECT r.id, u.username, r.created_at, r.total_tickets, rs.name,
+			SELECT r.id, u.username, r.created_at, r.total_tickets, rs.name, r.notes,
 				e.id, e.name, e.date, l.country, l.address, l.stadium
 			FROM Reservations r
 			JOIN reservation_statuses rs ON r.status_id = rs.id
 			JOIN Users u ON r.user_id = u.id
 			JOIN Events e ON r.event_id = e.id
 			JOIN Locations l ON e.location_id = l.id
-			WHERE r.user_id = $1
+			WHERE r.created_by = $1
+			ORDER BY r.created_at DESC, r.id
+			LIMIT $2 OFFSET $3
 		`
-		rows, err := pool.Query(r.Context(), query, userID)
+		rows, err := pool.Query(r.Context(), query, userID, limit, offset)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				writeErrorResponse(w, http.StatusNotFound, "No reservations found for the user.")
-			}
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
@@ -237,26 +497,15 @@ func GetCurrentUserReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		}
 		defer rows.Close()
 
-		// build the response data
 		reservations := []models.ReservationResponse{}
 		for rows.Next() {
 			var res models.ReservationResponse
-			var location models.LocationResponse
-			var event models.EventResponse
-
 			if err := rows.Scan(
-				&res.ID, &res.Username, &res.CreatedAt, &res.TotalTickets, &res.Status,
-				&event.ID, &event.Name, &event.Date,
-				&location.Country, &location.Address, &location.Stadium,
+				&res.ID, &res.Username, &res.CreatedAt, &res.TotalTickets, &res.Status, &res.Notes,
+				&res.Event.ID, &res.Event.Name, &res.Event.Date,
+				&res.Event.Location.Country, &res.Event.Location.Address,
+				&res.Event.Location.Stadium,
 			); err != nil {
-				if err == pgx.ErrNoRows {
-					writeErrorResponse(
-						w,
-						http.StatusNotFound,
-						"No reservations found for the user.",
-					)
-					return
-				}
 				writeErrorResponse(
 					w,
 					http.StatusInternalServerError,
@@ -264,13 +513,13 @@ func GetCurrentUserReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 				)
 				return
 			}
+			res.HoldExpiresAt = holdExpiresAt(res.Status, res.CreatedAt)
+			reservations = append(reservations, res)
+		}
+		rows.Close()
 
-			// append the structs to the response
-			event.Location = location
-			res.Event = event
-
-			tickets, err := fetchTickets(r.Context(), pool, res.ID)
-			if err != nil {
+		if includeTickets {
+			if err := attachTickets(r.Context(), pool, reservations); err != nil {
 				writeErrorResponse(
 					w,
 					http.StatusInternalServerError,
@@ -278,36 +527,34 @@ func GetCurrentUserReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 				)
 				return
 			}
-
-			// append ticket struct to the response
-			res.Tickets = tickets
-			reservations = append(reservations, res)
-		}
-
-		if len(reservations) == 0 {
-			writeErrorResponse(w, http.StatusNotFound, "No reservations found for the user.")
-			return
 		}
 
-		writeJSONResponse(w, http.StatusOK, reservations)
+		writePageResponse(
+			w, r, pool,
+			models.ReservationsResponse{Reservations: reservations}, reservations,
+			listPage{
+				Limit:      limit,
+				Offset:     offset,
+				FromClause: "FROM Reservations r WHERE r.created_by = $1",
+				Args:       []interface{}{userID},
+			},
+		)
 	}
 }
 
-// GetCurrentUserReservationsTicketsHandler lists all tickets for currently logged in user.
+// GetCurrentUserReservationSummaryHandler counts reservations of currently logged in user.
 //
-//	@Summary		List user tickets for currently logged in user.
-//	@Description	Retrieve a list of current user's tickets.
+//	@Summary		Summary of reservations for currently logged in user.
+//	@Description	Retrieve counts of current user's reservations by status, along with the number of tickets held in reservations that were not cancelled.
 //	@Tags			reservations
-//	@ID				api.getReservationTicketsForCurrentUser
+//	@ID				api.getReservationSummaryForCurrentUser
 //	@Produce		json
-//	@Param			id	path		string						true	"User ID"
-//	@Success		200	{object}	models.UserTicketsResponse	"List of tickets belonging to the user"
-//	@Failure		400	{object}	models.ErrorResponse		"Bad Request"
-//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
-//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
+//	@Success		200	{object}	models.ReservationSummaryResponse	"Summary of the user's reservations"
+//	@Failure		403	{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		500	{object}	models.ErrorResponse				"Internal Server Error"
 //	@Security		BearerAuth
-//	@Router			/reservations/user/tickets [get]
-func GetCurrentUserReservationsTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+//	@Router			/reservations/user/summary [get]
+func GetCurrentUserReservationSummaryHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// get the user ID out of context
 		userID, err := getUserIdFromContext(r.Context())
@@ -325,176 +572,145 @@ func GetCurrentUserReservationsTicketsHandler(pool *pgxpool.Pool) http.HandlerFu
 			return
 		}
 
-		// fetch all tickets user has bought
+		// every status is listed, so users without reservations get zeros
 		query := `
-			SELECT
-				t.id, t.reservation_id, t.price,
-				tt.name, ts.name,
-				e.id, e.name, e.date,
-				l.country, l.address, l.stadium
-			FROM tickets t
-			JOIN ticket_types tt ON t.type_id = tt.id
-			JOIN ticket_statuses ts ON t.status_id = ts.id
-			JOIN reservations r ON t.reservation_id = r.id
-			JOIN events e ON r.event_id = e.id
-			JOIN locations l ON e.location_id = l.id
-			WHERE r.user_id = $1
+			SELECT rs.name, COUNT(r.id), COALESCE(SUM(r.total_tickets), 0)
+			FROM reservation_statuses rs
+			LEFT JOIN reservations r ON r.status_id = rs.id AND r.user_id = $1
+			GROUP BY rs.id, rs.name
+			ORDER BY rs.id
 		`
 		rows, err := pool.Query(r.Context(), query, userID)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				writeErrorResponse(w, http.StatusNotFound, "No tickets found for the user.")
-			}
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
-				"Failed to fetch tickets.",
+				"Failed to fetch the summary.",
 			)
 			return
 		}
 		defer rows.Close()
 
-		// build the response data
-		tickets := []models.UserTicketResponse{}
+		summary := models.ReservationSummaryResponse{ByStatus: map[string]int{}}
 		for rows.Next() {
-			var ticket models.UserTicketResponse
-			var location models.LocationResponse
-			var event models.EventResponse
-
-			if err := rows.Scan(
-				&ticket.ID, &ticket.ReservationID, &ticket.Price, &ticket.Type, &ticket.Status,
-				&event.ID, &event.Name, &event.Date,
-				&location.Country, &location.Address, &location.Stadium,
-			); err != nil {
+			var status string
+			var count, tickets int
+			if err := rows.Scan(&status, &count, &tickets); err != nil {
 				writeErrorResponse(
 					w,
 					http.StatusInternalServerError,
-					"Failed to parse the tickets.",
+					"Failed to parse the summary.",
 				)
 				return
 			}
-
-			// append the structs to the response
-			event.Location = location
-			ticket.Event = event
-
-			tickets = append(tickets, ticket)
-		}
-
-		if len(tickets) == 0 {
-			writeErrorResponse(w, http.StatusNotFound, "No tickets found for the user.")
-			return
+			summary.ByStatus[status] = count
+			summary.Total += count
+			if status != "CANCELLED" {
+				summary.TicketsHeld += tickets
+			}
 		}
 
-		tickets_respone := models.UserTicketsResponse{UserID: userID, Tickets: tickets}
-		writeJSONResponse(w, http.StatusOK, tickets_respone)
+		writeJSONResponse(w, http.StatusOK, summary)
 	}
 }
 
-// GetUserReservationsHandler returns all reservations for a specific user.
+// GetCurrentUserReservationsTicketsHandler lists all tickets for currently logged in user.
 //
-//	@Summary		List user reservations (admin/owner only)
-//	@Description	Retrieve a list of all reservations made by a specific user, including their details and tickets they reserve.
+//	@Summary		List user tickets for currently logged in user.
+//	@Description	Retrieve a list of current user's tickets.
 //	@Tags			reservations
-//	@ID				api.getReservationsForUserByID
+//	@ID				api.getReservationTicketsForCurrentUser
 //	@Produce		json
-//	@Param			user_id	query		int							true	"User ID"
-//	@Success		200		{object}	models.ReservationsResponse	"List of reservations for the user"
-//	@Failure		400		{object}	models.ErrorResponse		"Bad Request"
-//	@Failure		404		{object}	models.ErrorResponse		"Not Found"
-//	@Failure		500		{object}	models.ErrorResponse		"Internal Server Error"
+//	@Param			id	path		string						true	"User ID"
+//	@Success		200	{object}	models.UserTicketsResponse	"List of tickets belonging to the user"
+//	@Failure		400	{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
 //	@Security		BearerAuth
-//	@Router			/reservations/user/{id} [get]
-func GetUserReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+//	@Router			/reservations/user/tickets [get]
+func GetCurrentUserReservationsTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// get the user id
-		userId, err := getUserIdFromContext(r.Context())
+		// get the user ID out of context
+		userID, err := getUserIdFromContext(r.Context())
 		if err != nil {
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
-				"Failed to fetch the user identifier.",
+				"Failed to fetch the user id.",
 			)
 			return
 		}
 
-		// permissions
-		if !isAdmin(r) && !isOwner(r, userId) {
+		if !isOverUnregistered(r) {
 			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
 			return
 		}
 
-		query := `
-			SELECT r.id, u.username, r.created_at, r.total_tickets, rs.name,
-				e.id, e.name, e.date, l.country, l.address, l.stadium
-			FROM Reservations r
-			JOIN reservation_statuses rs ON r.status_id = rs.id
-			JOIN Users u ON r.user_id = u.id
-			JOIN Events e ON r.event_id = e.id
-			JOIN Locations l ON e.location_id = l.id
-			WHERE r.user_id = $1
-		`
-		rows, err := pool.Query(r.Context(), query, userId)
+		// fetch all tickets user has bought
+		tickets, err := fetchUserTickets(r.Context(), pool, userID)
 		if err != nil {
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
-				"Failed to fetch reservations.",
+				"Failed to fetch tickets.",
 			)
 			return
 		}
-		defer rows.Close()
-
-		// build the response data
-		reservations := []models.ReservationResponse{}
-		for rows.Next() {
-			var res models.ReservationResponse
-			var location models.LocationResponse
-			var event models.EventResponse
 
-			if err := rows.Scan(
-				&res.ID, &res.Username, &res.CreatedAt, &res.TotalTickets, &res.Status,
-				&event.ID, &event.Name, &event.Date,
-				&location.Country, &location.Address, &location.Stadium,
-			); err != nil {
-				if err == pgx.ErrNoRows {
-					writeErrorResponse(
-						w,
-						http.StatusNotFound,
-						"No reservations found for the user.",
-					)
-					return
-				}
-				writeErrorResponse(
-					w,
-					http.StatusInternalServerError,
-					"Failed to parse reservation.",
-				)
-				return
-			}
+		tickets_respone := models.UserTicketsResponse{UserID: userID, Tickets: tickets}
+		writeListResponse(w, tickets_respone, tickets)
+	}
+}
 
-			// append the structs to the response
-			event.Location = location
-			res.Event = event
+// GetUserReservationsHandler returns all reservations for a specific user.
+//
+//	@Summary		List user reservations (admin/owner only)
+//	@Description	Retrieve a list of all reservations made by a specific user, including their details and tickets they reserve.
+//	@Tags			reservations
+//	@ID				api.getReservationsForUserByID
+//	@Produce		json
+//	@Param			id				path		string						true	"User ID"
+//	@Param			include_tickets	query		bool						false	"Include the tickets of each reservation (default true)"
+//	@Success		200				{object}	models.ReservationsResponse	"List of reservations for the user"
+//	@Failure		400				{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		403				{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		500				{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/user/{id} [get]
+func GetUserReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// get the user id
+		userId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
-			tickets, err := fetchTickets(r.Context(), pool, res.ID)
-			if err != nil {
-				writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the tickets")
-				return
-			}
+		// permissions
+		if !isStaffOrAdmin(r) && !isOwner(r, userId) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
 
-			// append ticket struct to the response
-			res.Tickets = tickets
-			reservations = append(reservations, res)
+		includeTickets, err := parseIncludeTickets(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
 		}
 
-		if len(reservations) == 0 {
-			writeErrorResponse(w, http.StatusNotFound, "No reservations found for the user.")
+		reservations, err := fetchUserReservations(
+			r.Context(), pool, userId, "all", includeTickets,
+		)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch reservations.",
+			)
 			return
 		}
 
 		reservations_response := models.ReservationsResponse{Reservations: reservations}
-		writeJSONResponse(w, http.StatusOK, reservations_response)
+		writeListResponse(w, reservations_response, reservations)
 	}
 }
 
@@ -508,49 +724,27 @@ func GetUserReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 //	@Param			id	path		string						true	"User ID"
 //	@Success		200	{object}	models.UserTicketsResponse	"List of tickets belonging to the user"
 //	@Failure		400	{object}	models.ErrorResponse		"Bad Request"
-//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
 //	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/reservations/user/{id}/tickets [get]
 func GetUserReservationsTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// get the user id
-		userID, err := getUserIdFromContext(r.Context())
+		userID, err := parsePathID(r, "id")
 		if err != nil {
-			writeErrorResponse(
-				w,
-				http.StatusInternalServerError,
-				"Failed to fetch the user identifier.",
-			)
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
 		// permissions
-		if !isAdmin(r) && !isOwner(r, userID) {
+		if !isStaffOrAdmin(r) && !isOwner(r, userID) {
 			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
 			return
 		}
 
 		// fetch all tickets user has bought
-		query := `
-			SELECT
-				t.id, t.reservation_id, t.price,
-				tt.name, ts.name,
-				e.id, e.name, e.date,
-				l.country, l.address, l.stadium
-			FROM tickets t
-			JOIN ticket_types tt ON t.type_id = tt.id
-			JOIN ticket_statuses ts ON t.status_id = ts.id
-			JOIN reservations r ON t.reservation_id = r.id
-			JOIN events e ON r.event_id = e.id
-			JOIN locations l ON e.location_id = l.id
-			WHERE r.user_id = $1
-		`
-		rows, err := pool.Query(r.Context(), query, userID)
+		tickets, err := fetchUserTickets(r.Context(), pool, userID)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				writeErrorResponse(w, http.StatusNotFound, "No tickets found for the user.")
-			}
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
@@ -558,42 +752,9 @@ func GetUserReservationsTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			)
 			return
 		}
-		defer rows.Close()
-
-		// build the response data
-		tickets := []models.UserTicketResponse{}
-		for rows.Next() {
-			var ticket models.UserTicketResponse
-			var location models.LocationResponse
-			var event models.EventResponse
-
-			if err := rows.Scan(
-				&ticket.ID, &ticket.ReservationID, &ticket.Price, &ticket.Type, &ticket.Status,
-				&event.ID, &event.Name, &event.Date,
-				&location.Country, &location.Address, &location.Stadium,
-			); err != nil {
-				writeErrorResponse(
-					w,
-					http.StatusInternalServerError,
-					"Failed to parse the tickets.",
-				)
-				return
-			}
-
-			// append the structs to the response
-			event.Location = location
-			ticket.Event = event
-
-			tickets = append(tickets, ticket)
-		}
-
-		if len(tickets) == 0 {
-			writeErrorResponse(w, http.StatusNotFound, "No tickets found for the user.")
-			return
-		}
 
 		tickets_respone := models.UserTicketsResponse{UserID: userID, Tickets: tickets}
-		writeJSONResponse(w, http.StatusOK, tickets_respone)
+		writeListResponse(w, tickets_respone, tickets)
 	}
 }
 
@@ -604,18 +765,26 @@ func GetUserReservationsTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 //	@Tags			reservations
 //	@ID				api.getReservationTicketsByID
 //	@Produce		json
-//	@Param			id	path		int									true	"Reservation ID"
-//	@Success		200	{object}	models.ReservationTicketsResponse	"List of tickets for the reservation"
-//	@Failure		400	{object}	models.ErrorResponse				"Bad Request"
-//	@Failure		404	{object}	models.ErrorResponse				"Not Found"
-//	@Failure		500	{object}	models.ErrorResponse				"Internal Server Error"
+//	@Param			id					path		int									true	"Reservation ID"
+//	@Param			include_cancelled	query		bool								false	"Include cancelled tickets"
+//	@Success		200					{object}	models.ReservationTicketsResponse	"List of tickets for the reservation"
+//	@Failure		400					{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		404					{object}	models.ErrorResponse				"Not Found"
+//	@Failure		500					{object}	models.ErrorResponse				"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/reservations/{id}/tickets [get]
 func GetReservationTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		reservationId, err := parseReservationIdFromURL(r)
+		reservationId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		includeCancelled, err := parseIncludeCancelled(r)
 		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
 		}
 
 		tx, err := pool.Begin(r.Context())
@@ -635,6 +804,10 @@ func GetReservationTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			"SELECT user_id FROM Reservations WHERE id = $1",
 			reservationId,
 		).Scan(&userId); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+				return
+			}
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
@@ -644,7 +817,7 @@ func GetReservationTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		}
 
 		// only available for admins and owners
-		if !isAdmin(r) && !isOwner(r, userId) {
+		if !isStaffOrAdmin(r) && !isOwner(r, userId) {
 			writeErrorResponse(
 				w,
 				http.StatusBadRequest,
@@ -655,24 +828,16 @@ func GetReservationTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 
 		// query to fetch tickets for the given reservation ID
 		query := `
-			SELECT t.id, t.price, tt.name, ts.name
+			SELECT t.id, t.price, tt.name, ts.name, t.section
 			FROM tickets t
 			JOIN reservations r ON t.reservation_id = r.id
 			JOIN ticket_types tt ON t.type_id = tt.id
 			JOIN ticket_statuses ts ON t.status_id = ts.id
-			WHERE r.id = $1
+			WHERE r.id = $1 AND ($2 OR ts.name <> 'CANCELLED')
 		`
 
-		rows, err := tx.Query(r.Context(), query, reservationId)
+		rows, err := tx.Query(r.Context(), query, reservationId, includeCancelled)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				writeErrorResponse(
-					w,
-					http.StatusNotFound,
-					"No tickets found for this reservation.",
-				)
-				return
-			}
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets.")
 			return
 		}
@@ -682,7 +847,9 @@ func GetReservationTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		tickets := []models.TicketResponse{}
 		for rows.Next() {
 			var ticket models.TicketResponse
-			if err := rows.Scan(&ticket.ID, &ticket.Price, &ticket.Type, &ticket.Status); err != nil {
+			if err := rows.Scan(
+				&ticket.ID, &ticket.Price, &ticket.Type, &ticket.Status, &ticket.Section,
+			); err != nil {
 				writeErrorResponse(
 					w,
 					http.StatusInternalServerError,
@@ -690,6 +857,7 @@ func GetReservationTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 				)
 				return
 			}
+			ticket.Currency = currency
 			tickets = append(tickets, ticket)
 		}
 
@@ -703,34 +871,36 @@ func GetReservationTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
-		// if no tickets found, return 404
-		if len(tickets) == 0 {
-			writeErrorResponse(w, http.StatusNotFound, "No tickets found for this reservation.")
-			return
-		}
-
 		// reseponse with ids attached
 		reservation_tickets := models.ReservationTicketsResponse{
 			ReservationID: reservationId,
 			UserID:        userId,
 			Tickets:       tickets,
 		}
-		writeJSONResponse(w, http.StatusOK, reservation_tickets)
+		writeListResponse(w, reservation_tickets, tickets)
 	}
 }
 
 // CreateReservationHandler creates a single reservation in the database along with its tickets.
 //
 //	@Summary		Create a reservation (owner/admin only).
-//	@Description	Parse provided payload and create reservation and tickets within the database.
+//	@Description	Parse provided payload and create reservation and tickets within the database. Each ticket entry may specify a quantity (defaults to 1).
 //	@Tags			reservations
 //	@ID				api.createReservation
 //	@Produce		json
-//	@Param			body	body		models.CreateReservationPayload		true	"Payload to create a reservation"
-//	@Success		200		{object}	models.SuccessResponseCreateUUID	"Reservation created successfully"
-//	@Failure		403		{object}	models.ErrorResponse				"Forbidden"
-//	@Failure		404		{object}	models.ErrorResponse				"Not Found"
-//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
+//	@Param			body				body		models.CreateReservationPayload		true	"Payload to create a reservation"
+//	@Param			dry_run				query		bool								false	"Validate and price the reservation without creating it"
+//	@Param			allow_duplicate		query		bool								false	"Create the reservation even if the user already holds one for the event"
+//	@Param			ignore_sale_start	query		bool								false	"Reserve before sales of the event start (admin only)"
+//	@Param			ignore_cutoff		query		bool								false	"Reserve after online sales of the event closed (admin only)"
+//	@Success		200					{object}	models.ReservationQuoteResponse		"Price preview (dry run)"
+//	@Success		201					{object}	models.CreateReservationResponse	"Reservation created successfully"
+//	@Failure		400					{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		403					{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		404					{object}	models.ErrorResponse				"Not Found"
+//	@Failure		409					{object}	models.DuplicateReservationResponse	"Conflict"
+//	@Failure		415					{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		500					{object}	models.ErrorResponse				"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/reservations [put]
 func CreateReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
@@ -755,193 +925,938 @@ func CreateReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
-		// decode the request body
-		var resPayload models.CreateReservationPayload
-		if err := json.NewDecoder(r.Body).Decode(&resPayload); err != nil {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON input")
+		createReservation(w, r, pool, userId)
+	}
+}
+
+// CreateReservationForUserHandler creates a reservation on behalf of another user.
+//
+//	@Summary		Create a reservation for another user (admin/staff only).
+//	@Description	Create a reservation owned by the given active user, e.g. for a walk-up customer at the box office, recording the staff member who made it. Accepts the same payload and options as creating a reservation for oneself.
+//	@Tags			reservations
+//	@ID				api.createReservationForUser
+//	@Accept			json
+//	@Produce		json
+//	@Param			userId				path		string								true	"ID of the user owning the reservation"
+//	@Param			body				body		models.CreateReservationPayload		true	"Payload to create a reservation"
+//	@Param			dry_run				query		bool								false	"Validate and price the reservation without creating it"
+//	@Param			allow_duplicate		query		bool								false	"Create the reservation even if the user already holds one for the event"
+//	@Param			ignore_sale_start	query		bool								false	"Reserve before sales of the event start (admin only)"
+//	@Param			ignore_cutoff		query		bool								false	"Reserve after online sales of the event closed (admin only)"
+//	@Success		200					{object}	models.ReservationQuoteResponse		"Price preview (dry run)"
+//	@Success		201					{object}	models.CreateReservationResponse	"Reservation created successfully"
+//	@Failure		400					{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		403					{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		404					{object}	models.ErrorResponse				"Not Found"
+//	@Failure		409					{object}	models.DuplicateReservationResponse	"Conflict"
+//	@Failure		415					{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		500					{object}	models.ErrorResponse				"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/for/{userId} [put]
+func CreateReservationForUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isStaffOrAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to reserve on behalf of other users.",
+			)
 			return
 		}
 
-		// validate the request
-		if err := validateReservationRequest(resPayload); err != nil {
+		userId, err := parsePathID(r, "userId")
+		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
+		if _, err := uuid.Parse(userId); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid user ID.")
+			return
+		}
 
-		// ensure atomicity during the process
-		tx, err := pool.Begin(r.Context())
-		if err != nil {
+		var isActive bool
+		query := `SELECT is_active FROM Users WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), query, userId).Scan(&isActive); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "User not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
+			return
+		}
+		if !isActive {
 			writeErrorResponse(
 				w,
-				http.StatusInternalServerError,
-				"Failed to start transaction.",
+				http.StatusConflict,
+				"Reservations cannot be made for inactive users.",
 			)
 			return
 		}
-		defer tx.Rollback(r.Context())
 
-		// fetch reservation details, initial status will be pending
-		// after creating tickets, will change to confirmed
-		var req models.ReservationRequest
-		basePrice, availableTickets, statusID, err := fetchReservationDetails(
-			r, tx, "PENDING", resPayload.EventID,
-		)
+		createReservation(w, r, pool, userId)
+	}
+}
+
+// Create a reservation from the request payload, owned by the given user, along with its
+// tickets. The user making the request is recorded as its creator.
+func createReservation(w http.ResponseWriter, r *http.Request, pool *pgxpool.Pool, userId string) {
+	var err error
+
+	// dry run validates and prices the reservation, without persisting it
+	dryRun := false
+	if param := r.URL.Query().Get("dry_run"); param != "" {
+		dryRun, err = strconv.ParseBool(param)
 		if err != nil {
-			writeErrorResponse(
-				w,
-				http.StatusInternalServerError,
-				"Failed to fetch reservation details.",
-			)
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid value for dry_run.")
 			return
 		}
+	}
 
-		// assign fetched values to the request struct
-		req.UserID = userId
-		req.EventID = resPayload.EventID
-		req.TotalTickets = len(resPayload.Tickets)
-		req.StatusID = statusID
+	// second reservation for the same event is most likely a mistake
+	allowDuplicate := false
+	if param := r.URL.Query().Get("allow_duplicate"); param != "" {
+		allowDuplicate, err = strconv.ParseBool(param)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid value for allow_duplicate.")
+			return
+		}
+	}
 
-		// check if there is enough tickets available
-		if availableTickets < req.TotalTickets {
+	// admins may reserve before sales start, e.g. to test the event
+	ignoreSaleStart := false
+	if param := r.URL.Query().Get("ignore_sale_start"); param != "" {
+		ignoreSaleStart, err = strconv.ParseBool(param)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid value for ignore_sale_start.")
+			return
+		}
+		if ignoreSaleStart && !isAdmin(r) {
 			writeErrorResponse(
 				w,
-				http.StatusBadRequest,
-				"Not enough tickets to create a reservation.",
+				http.StatusForbidden,
+				"Insufficient permissions to reserve before sales start.",
 			)
 			return
 		}
+	}
 
-		err = setAvailableTickets(r.Context(), tx, req.EventID, req.TotalTickets)
+	// admins may reserve after online sales closed, e.g. at the box office
+	ignoreCutoff := false
+	if param := r.URL.Query().Get("ignore_cutoff"); param != "" {
+		ignoreCutoff, err = strconv.ParseBool(param)
 		if err != nil {
-			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid value for ignore_cutoff.")
+			return
 		}
-
-		// insert a reservation
-		var reservationId string
-		reservationQuery := `
-			INSERT INTO Reservations (user_id, event_id, total_tickets, status_id)
-			VALUES ($1, $2, $3, $4)
-			RETURNING id
-		`
-		if err = tx.QueryRow(r.Context(),
-			reservationQuery,
-			req.UserID,
-			req.EventID,
-			req.TotalTickets,
-			req.StatusID,
-		).Scan(&reservationId); err != nil {
+		if ignoreCutoff && !isAdmin(r) {
 			writeErrorResponse(
 				w,
-				http.StatusInternalServerError,
-				"Failed to create a reservation.",
+				http.StatusForbidden,
+				"Insufficient permissions to reserve after online sales close.",
 			)
 			return
 		}
+	}
 
-		// insert the reserved tickets
-		ticketQuery := `
-			INSERT INTO Tickets (reservation_id, price, type_id, status_id)
-			VALUES ($1, $2, $3, $4)
-		`
-		for _, ticket := range resPayload.Tickets {
-			// initial state for tickets is RESERVED, later turns to SOLD
-			discount, typeId, statusId, err := fetchTicketDetails(
-				r.Context(), tx, "RESERVED", ticket.Type,
-			)
-			if err != nil {
-				writeErrorResponse(
-					w,
-					http.StatusInternalServerError,
-					"Failed to fetch ticket details.",
-				)
-				return
-			}
+	// decode the request body
+	var resPayload models.CreateReservationPayload
+	if status, err := decodeJSONBody(r, &resPayload); err != nil {
+		writeErrorResponse(w, status, err.Error())
+		return
+	}
 
-			// execute the insert query
-			if _, err = tx.Exec(
-				r.Context(),
-				ticketQuery,
-				reservationId,
-				basePrice*(1-discount),
-				typeId,
-				statusId,
-			); err != nil {
-				writeErrorResponse(w, http.StatusInternalServerError, "Failed to create tickets.")
-				return
-			}
-		}
+	// validate the request
+	count, err := validateReservationRequest(resPayload)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// the one making the reservation is recorded apart from its owner
+	actorId, err := getActingUserIdFromContext(r.Context())
+	if err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch the user identifier.",
+		)
+		return
+	}
+
+	// reject unknown ticket types upfront, pointing at the offending entries
+	unknown, err := findUnknownTicketTypes(r.Context(), pool, resPayload.Tickets)
+	if err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to validate ticket types.",
+		)
+		return
+	}
+	if len(unknown) > 0 {
+		writeErrorResponse(
+			w,
+			http.StatusBadRequest,
+			fmt.Sprintf("Unknown ticket types: %s.", strings.Join(unknown, ", ")),
+		)
+		return
+	}
+
+	// ensure atomicity during the process
+	tx, err := pool.Begin(r.Context())
+	if err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to start transaction.",
+		)
+		return
+	}
+	defer tx.Rollback(r.Context())
+
+	// bulk purchases are limited, unless the event allows more; checked
+	// before anything is done per ticket
+	limit, err := fetchReservationLimit(r.Context(), tx, resPayload.EventID)
+	if err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch reservation limit.",
+		)
+		return
+	}
+	if err := checkReservationSize(count, limit); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// one entry per ticket, with quantities expanded
+	tickets := expandTickets(resPayload.Tickets)
+
+	// fetch reservation details, initial status will be pending
+	// after creating tickets, will change to confirmed
+	var req models.ReservationRequest
+	basePrice, availableTickets, statusID, err := fetchReservationDetails(
+		r, tx, "PENDING", resPayload.EventID,
+	)
+	if err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch reservation details.",
+		)
+		return
+	}
 
-		// confirms the reservations and 'sells' the tickets
-		err = confirmReservation(r.Context(), tx, reservationId)
+	// private events are booked by staff on behalf of the invited
+	if !isStaffOrAdmin(r) {
+		visibility, err := fetchEventVisibility(r.Context(), tx, resPayload.EventID)
 		if err != nil {
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
-				"Failed to confirm reservation.",
+				"Failed to fetch the event visibility.",
 			)
+			return
 		}
-
-		// commit the transaction
-		if err := tx.Commit(r.Context()); err != nil {
+		if visibility == "PRIVATE" {
 			writeErrorResponse(
 				w,
-				http.StatusInternalServerError,
-				"Failed to commit transaction.",
+				http.StatusForbidden,
+				"Event is private; reservations are made by the organizers.",
 			)
 			return
 		}
+	}
 
-		// respond with the reservation ID
-		writeJSONResponse(
+	if !ignoreSaleStart {
+		saleStartsAt, err := fetchSaleStart(r.Context(), tx, resPayload.EventID)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the sale start.",
+			)
+			return
+		}
+		if !*isOnSale(saleStartsAt) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				fmt.Sprintf(
+					"Sales have not started; they open at %s.",
+					saleStartsAt.UTC().Format(time.RFC3339),
+				),
+			)
+			return
+		}
+	}
+
+	if !ignoreCutoff {
+		date, cutoffHours, err := fetchSalesCutoff(r.Context(), tx, resPayload.EventID)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the sales cutoff.",
+			)
+			return
+		}
+		if !*areSalesOpen(date, cutoffHours) {
+			writeErrorResponse(w, http.StatusForbidden, "Online sales have closed.")
+			return
+		}
+	}
+
+	if !allowDuplicate {
+		existingId, err := fetchOpenReservationId(
+			r.Context(), tx, userId, resPayload.EventID,
+		)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch existing reservations.",
+			)
+			return
+		}
+		if existingId != "" {
+			writeJSONResponse(w, http.StatusConflict, models.DuplicateReservationResponse{
+				Message:       "Active reservation for the event already exists.",
+				ReservationID: existingId,
+			})
+			return
+		}
+	}
+
+	// some events allow only a few reservations per person
+	userLimit, userCount, err := fetchReservationsPerUser(
+		r.Context(), tx, userId, resPayload.EventID,
+	)
+	if err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch reservation limit.",
+		)
+		return
+	}
+	if userLimit > 0 && userCount >= userLimit {
+		writeErrorResponse(
+			w,
+			http.StatusConflict,
+			fmt.Sprintf("Event allows at most %d reservations per user.", userLimit),
+		)
+		return
+	}
+
+	// assign fetched values to the request struct
+	req.UserID = userId
+	req.CreatedBy = actorId
+	req.EventID = resPayload.EventID
+	req.TotalTickets = len(tickets)
+	req.StatusID = statusID
+
+	// section preferences must name sections of the event
+	unknownSections, err := findUnknownSections(r.Context(), tx, req.EventID, resPayload.Tickets)
+	if err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to validate sections.",
+		)
+		return
+	}
+	if len(unknownSections) > 0 {
+		writeErrorResponse(
+			w,
+			http.StatusBadRequest,
+			fmt.Sprintf("Unknown sections: %s.", strings.Join(unknownSections, ", ")),
+		)
+		return
+	}
+
+	// check if there is enough tickets available
+	if availableTickets < req.TotalTickets {
+		writeErrorResponse(
+			w,
+			http.StatusBadRequest,
+			"Not enough tickets to create a reservation.",
+		)
+		return
+	}
+
+	// price the tickets upfront, so the dry run reports the same prices
+	// initial state for tickets is RESERVED, later turns to SOLD
+	type pricedTicket struct {
+		typeId   int
+		statusId int
+		price    float64
+		section  *string
+	}
+	pricedTickets := make([]pricedTicket, 0, len(tickets))
+	quote := models.ReservationQuoteResponse{
+		EventID:  req.EventID,
+		Currency: currency,
+		Tickets:  []models.TicketQuoteResponse{},
+	}
+	for _, ticket := range tickets {
+		discount, typeId, statusId, err := fetchTicketDetails(
+			r.Context(), tx, "RESERVED", ticket.Type,
+		)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch ticket details.",
+			)
+			return
+		}
+
+		// per-event price, if set, otherwise discounted base price
+		price, err := fetchTicketPrice(
+			r.Context(), tx, req.EventID, typeId, basePrice, discount,
+		)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch ticket price.",
+			)
+			return
+		}
+
+		pricedTickets = append(
+			pricedTickets,
+			pricedTicket{typeId, statusId, price, ticket.Section},
+		)
+		quote.Tickets = append(
+			quote.Tickets,
+			models.TicketQuoteResponse{Type: ticket.Type, Price: models.Money(price)},
+		)
+		quote.Total += models.Money(price)
+	}
+
+	// nothing was written yet, the deferred rollback leaves no trace
+	if dryRun {
+		writeJSONResponse(w, http.StatusOK, quote)
+		return
+	}
+
+	err = setAvailableTickets(r.Context(), tx, req.EventID, req.TotalTickets)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+	}
+
+	// insert a reservation
+	var reservationId string
+	var createdAt time.Time
+	reservationQuery := `
+		INSERT INTO Reservations (user_id, event_id, total_tickets, status_id, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	if err = tx.QueryRow(r.Context(),
+		reservationQuery,
+		req.UserID,
+		req.EventID,
+		req.TotalTickets,
+		req.StatusID,
+		req.CreatedBy,
+	).Scan(&reservationId, &createdAt); err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to create a reservation.",
+		)
+		return
+	}
+
+	// insert the reserved tickets
+	ticketQuery := `
+		INSERT INTO Tickets (reservation_id, price, type_id, status_id, section)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	for _, ticket := range pricedTickets {
+		// execute the insert query
+		if _, err = tx.Exec(
+			r.Context(),
+			ticketQuery,
+			reservationId,
+			ticket.price,
+			ticket.typeId,
+			ticket.statusId,
+			ticket.section,
+		); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to create tickets.")
+			return
+		}
+	}
+
+	// confirms the reservations and 'sells' the tickets
+	err = confirmReservation(r.Context(), tx, reservationId)
+	if err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to confirm reservation.",
+		)
+	}
+
+	// commit the transaction
+	if err := tx.Commit(r.Context()); err != nil {
+		writeErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			"Failed to commit transaction.",
+		)
+		return
+	}
+
+	// respond with the reservation ID, confirmed reservations are not held
+	writeJSONResponse(
+		w,
+		http.StatusCreated,
+		models.CreateReservationResponse{
+			Message:       "Reservation created successfully.",
+			UUID:          reservationId,
+			Status:        "CONFIRMED",
+			HoldExpiresAt: holdExpiresAt("CONFIRMED", createdAt),
+		},
+	)
+}
+
+// CancelReservationHandler updates the status of the reservation and its tickets to CANCELLED.
+//
+//	@Summary		Cancel a reservation (owner/admin only).
+//	@Description	Set statuses of reservation and its tickets to cancelled, returning the seats of reserved and sold tickets to the event. Paid tickets are refunded in full, unless an admin provides a smaller refund_amount, e.g. to deduct a cancellation fee.
+//	@Tags			reservations
+//	@ID				api.cancelReservation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Reservation ID"
+//	@Param			body	body		models.CancelReservationRequest		false	"Partial refund (admin only)"
+//	@Success		200		{object}	models.CancelReservationResponse	"Reservation canceled successfully"
+//	@Failure		400		{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse				"Not Found"
+//	@Failure		409		{object}	models.ErrorResponse				"Conflict"
+//	@Failure		415		{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/{id}/cancel [post]
+func CancelReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// get the user id
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the user identifier.",
+			)
+			return
+		}
+
+		// permissions
+		if !isAdmin(r) && !isOwner(r, userId) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		reservationId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// the body is optional, refunds default to the full paid amount
+		var payload models.CancelReservationRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil && err != errEmptyBody {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		if (payload.RefundAmount != nil || payload.RefundReason != nil) && !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to set the refund.",
+			)
+			return
+		}
+		if payload.RefundAmount != nil && *payload.RefundAmount < 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "Refund amount cannot be negative.")
+			return
+		}
+
+		// start a transaction
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		// lock the reservation, so concurrent cancellations do not refund twice
+		var locked string
+		lockQuery := `SELECT id FROM reservations WHERE id = $1 FOR UPDATE`
+		if err := tx.QueryRow(r.Context(), lockQuery, reservationId).Scan(&locked); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+				return
+			}
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the reservation.",
+			)
+			return
+		}
+
+		// paid amount has to be known before the sold tickets are cancelled
+		paid, err := fetchRefundableAmount(r.Context(), tx, reservationId)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		refundAmount := paid
+		if payload.RefundAmount != nil {
+			refundAmount = *payload.RefundAmount
+		}
+		if refundAmount > paid {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				fmt.Sprintf("Refund amount exceeds the paid amount of %.2f.", paid),
+			)
+			return
+		}
+
+		// a reservation cancelled meanwhile is not cancelled (and refunded) again
+		if err := updateReservationStatus(
+			r.Context(), tx, reservationId, "CANCELLED", "PENDING", "CONFIRMED",
+		); err != nil {
+			if err == errReservationStateChanged {
+				writeErrorResponse(w, http.StatusConflict, err.Error())
+				return
+			}
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to cancel the reservation.",
+			)
+			return
+		}
+
+		if err := releaseReservationSeats(r.Context(), tx, reservationId); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if err := updateTicketsStatus(r.Context(), tx, reservationId, "CANCELLED"); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to cancel the tickets.",
+			)
+			return
+		}
+
+		// unpaid reservations have nothing to refund
+		response := models.CancelReservationResponse{
+			Message: "Reservation canceled successfully.",
+		}
+		if paid > 0 {
+			refund, err := insertRefund(
+				r.Context(), tx, reservationId, refundAmount, payload.RefundReason, userId,
+			)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			response.Refund = &refund
+		}
+
+		if err = tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to commit the transaction.",
+			)
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// GetCancelPreviewHandler computes the effects of cancelling a reservation.
+//
+//	@Summary		Preview cancelling a reservation (owner/admin only).
+//	@Description	Retrieve what cancelling the reservation would do, without changing anything: the number of tickets cancelled, the seats returned to the event and the refund of the paid amount. Admins may still refund less when cancelling.
+//	@Tags			reservations
+//	@ID				api.getCancelPreview
+//	@Produce		json
+//	@Param			id	path		string							true	"Reservation ID"
+//	@Success		200	{object}	models.CancelPreviewResponse	"Effects of the cancellation"
+//	@Failure		400	{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403	{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse			"Not Found"
+//	@Failure		409	{object}	models.ErrorResponse			"Conflict"
+//	@Failure		500	{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/{id}/cancel-preview [get]
+func GetCancelPreviewHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reservationId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// reads only, the transaction is never committed
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		var ownerId, status string
+		response := models.CancelPreviewResponse{Currency: currency}
+		query := `
+			SELECT r.id, r.user_id, r.event_id, rs.name
+			FROM reservations r
+			JOIN reservation_statuses rs ON r.status_id = rs.id
+			WHERE r.id = $1
+		`
+		if err := tx.QueryRow(r.Context(), query, reservationId).Scan(
+			&response.ReservationID, &ownerId, &response.EventID, &status,
+		); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+				return
+			}
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the reservation.",
+			)
+			return
+		}
+
+		if !isAdmin(r) && !isOwner(r, ownerId) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+		if status == "CANCELLED" {
+			writeErrorResponse(w, http.StatusConflict, "Reservation is already cancelled.")
+			return
+		}
+
+		// the same tickets the cancellation would touch
+		query = `
+			SELECT
+				COUNT(*) FILTER (WHERE ts.name <> 'CANCELLED'),
+				COUNT(*) FILTER (WHERE ts.name IN ('RESERVED', 'SOLD'))
+			FROM tickets t
+			JOIN ticket_statuses ts ON t.status_id = ts.id
+			WHERE t.reservation_id = $1
+		`
+		if err := tx.QueryRow(r.Context(), query, reservationId).Scan(
+			&response.TicketsCancelled, &response.SeatsReleased,
+		); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets.")
+			return
+		}
+
+		paid, err := fetchRefundableAmount(r.Context(), tx, reservationId)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.RefundAmount = models.Money(paid)
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// ConfirmReservationHandler confirms a pending reservation manually.
+//
+//	@Summary		Confirm a pending reservation (admin only).
+//	@Description	Set the reservation to confirmed and its tickets to sold, e.g. when the payment notification was lost. The admin confirming it is recorded.
+//	@Tags			reservations
+//	@ID				api.confirmReservation
+//	@Produce		json
+//	@Param			id	path		string					true	"Reservation ID"
+//	@Success		200	{object}	models.SuccessResponse	"Reservation confirmed successfully"
+//	@Failure		403	{object}	models.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse	"Not Found"
+//	@Failure		409	{object}	models.ErrorResponse	"Conflict"
+//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/{id}/confirm [post]
+func ConfirmReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		adminId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		reservationId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		// lock the reservation, so the hold sweeper cannot cancel it meanwhile
+		var status string
+		query := `
+			SELECT rs.name
+			FROM reservations r
+			JOIN reservation_statuses rs ON r.status_id = rs.id
+			WHERE r.id = $1
+			FOR UPDATE OF r
+		`
+		if err := tx.QueryRow(r.Context(), query, reservationId).Scan(&status); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+				return
+			}
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the reservation.",
+			)
+			return
+		}
+		if status != "PENDING" {
+			writeErrorResponse(
+				w,
+				http.StatusConflict,
+				fmt.Sprintf("Only pending reservations can be confirmed; status is %s.", status),
+			)
+			return
+		}
+
+		if err := confirmReservation(r.Context(), tx, reservationId); err != nil {
+			if errors.Is(err, errReservationStateChanged) {
+				writeErrorResponse(w, http.StatusConflict, errReservationStateChanged.Error())
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		recordQuery := `
+			UPDATE reservations
+			SET confirmed_by = $2, confirmed_at = NOW()
+			WHERE id = $1
+		`
+		if _, err := tx.Exec(r.Context(), recordQuery, reservationId, adminId); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to record the confirmation.",
+			)
+			return
+		}
+
+		if err = tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to commit the transaction.",
+			)
+			return
+		}
+
+		writeJSONResponse(
 			w,
-			http.StatusCreated,
-			models.SuccessResponseCreateUUID{
-				Message: "Reservation created successfully.",
-				UUID:    reservationId,
-			},
+			http.StatusOK,
+			models.SuccessResponse{Message: "Reservation confirmed successfully."},
 		)
 	}
 }
 
-// CancelReservationHandler updates the status of the reservation and its tickets to CANCELLED.
+// ReissueReservationTicketsHandler replaces tickets of a reservation with a corrected set.
 //
-//	@Summary		Cancel a reservation (owner/admin only).
-//	@Description	Set statuses of reservation and its tickets to cancelled.
+//	@Summary		Reissue tickets of a reservation (admin only).
+//	@Description	Replace the tickets of a reservation with provided set, priced anew. Available tickets of the event are adjusted by the difference in count. Cancelled reservations and those with checked in tickets are refused.
 //	@Tags			reservations
-//	@ID				api.cancelReservation
+//	@ID				api.reissueReservationTickets
+//	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string					true	"Reservation ID"
-//	@Success		200	{object}	models.SuccessResponse	"Reservation canceled successfully"
-//	@Failure		403	{object}	models.ErrorResponse	"Forbidden"
-//	@Failure		404	{object}	models.ErrorResponse	"Not Found"
-//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Param			id		path		string								true	"Reservation ID"
+//	@Param			body	body		models.ReissueTicketsRequest		true	"Corrected tickets"
+//	@Success		200		{object}	models.ReservationTicketsResponse	"Reissued tickets"
+//	@Failure		400		{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse				"Not Found"
+//	@Failure		409		{object}	models.ErrorResponse				"Conflict"
+//	@Failure		415		{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
 //	@Security		BearerAuth
-//	@Router			/reservations/{id}/cancel [post]
-func CancelReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
+//	@Router			/reservations/{id}/reissue [post]
+func ReissueReservationTicketsHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// get the user id
-		userId, err := getUserIdFromContext(r.Context())
-		if err != nil {
+		if !isAdmin(r) {
 			writeErrorResponse(
 				w,
-				http.StatusInternalServerError,
-				"Failed to fetch the user identifier.",
+				http.StatusForbidden,
+				"Insufficient permissions to reissue tickets.",
 			)
 			return
 		}
 
-		// permissions
-		if !isAdmin(r) && !isOwner(r, userId) {
-			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+		reservationId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		reservationId, err := parseReservationIdFromURL(r)
+		var req models.ReissueTicketsRequest
+		if status, err := decodeJSONBody(r, &req); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		count, err := countTickets(req.Tickets)
 		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		unknown, err := findUnknownTicketTypes(r.Context(), pool, req.Tickets)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to validate ticket types.",
+			)
+			return
+		}
+		if len(unknown) > 0 {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				fmt.Sprintf("Unknown ticket types: %s.", strings.Join(unknown, ", ")),
+			)
+			return
 		}
 
-		// start a transaction
 		tx, err := pool.Begin(r.Context())
 		if err != nil {
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
@@ -949,25 +1864,184 @@ func CancelReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		}
 		defer tx.Rollback(r.Context())
 
-		if err := updateTicketsStatus(r.Context(), tx, reservationId, "CANCELLED"); err != nil {
+		// lock the reservation, so it is not cancelled in the meantime
+		var eventId, totalTickets int
+		var userId, status string
+		query := `
+			SELECT r.event_id, r.user_id, r.total_tickets, rs.name
+			FROM reservations r
+			JOIN reservation_statuses rs ON r.status_id = rs.id
+			WHERE r.id = $1
+			FOR UPDATE OF r
+		`
+		if err := tx.QueryRow(r.Context(), query, reservationId).
+			Scan(&eventId, &userId, &totalTickets, &status); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+				return
+			}
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
-				"Failed to cancel the tickets.",
+				"Failed to fetch the reservation.",
+			)
+			return
+		}
+		if status == "CANCELLED" {
+			writeErrorResponse(
+				w,
+				http.StatusConflict,
+				"Tickets of a cancelled reservation cannot be reissued.",
 			)
 			return
 		}
 
-		if err := updateReservationStatus(r.Context(), tx, reservationId, "CANCELLED"); err != nil {
+		limit, err := fetchReservationLimit(r.Context(), tx, eventId)
+		if err != nil {
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
-				"Failed to cancel the reservation.",
+				"Failed to fetch reservation limit.",
 			)
 			return
 		}
+		if err := checkReservationSize(count, limit); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		requested := expandTickets(req.Tickets)
 
-		if err = tx.Commit(r.Context()); err != nil {
+		// tickets already scanned at the gate must not disappear
+		var checkedIn bool
+		checkedInQuery := `
+			SELECT EXISTS(
+				SELECT 1
+				FROM tickets t
+				JOIN ticket_statuses ts ON t.status_id = ts.id
+				WHERE t.reservation_id = $1 AND ts.name = 'USED'
+			)
+		`
+		if err := tx.QueryRow(r.Context(), checkedInQuery, reservationId).
+			Scan(&checkedIn); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets.")
+			return
+		}
+		if checkedIn {
+			writeErrorResponse(
+				w,
+				http.StatusConflict,
+				"Reservation has checked in tickets and cannot be reissued.",
+			)
+			return
+		}
+
+		unknownSections, err := findUnknownSections(r.Context(), tx, eventId, req.Tickets)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to validate sections.",
+			)
+			return
+		}
+		if len(unknownSections) > 0 {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				fmt.Sprintf("Unknown sections: %s.", strings.Join(unknownSections, ", ")),
+			)
+			return
+		}
+
+		basePrice, availableTickets, _, err := fetchReservationDetails(r, tx, status, eventId)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch reservation details.",
+			)
+			return
+		}
+
+		// only additional tickets have to be available
+		delta := len(requested) - totalTickets
+		if delta > availableTickets {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				"Not enough tickets to reissue the reservation.",
+			)
+			return
+		}
+		if err := setAvailableTickets(r.Context(), tx, eventId, delta); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if _, err := tx.Exec(
+			r.Context(),
+			`DELETE FROM tickets WHERE reservation_id = $1`,
+			reservationId,
+		); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to remove old tickets.")
+			return
+		}
+
+		// new tickets follow the state of the reservation
+		ticketStatus := "RESERVED"
+		if status == "CONFIRMED" {
+			ticketStatus = "SOLD"
+		}
+		ticketQuery := `
+			INSERT INTO Tickets (reservation_id, price, type_id, status_id, section)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+		for _, ticket := range requested {
+			discount, typeId, statusId, err := fetchTicketDetails(
+				r.Context(), tx, ticketStatus, ticket.Type,
+			)
+			if err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to fetch ticket details.",
+				)
+				return
+			}
+
+			price, err := fetchTicketPrice(r.Context(), tx, eventId, typeId, basePrice, discount)
+			if err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to fetch ticket price.",
+				)
+				return
+			}
+
+			if _, err := tx.Exec(
+				r.Context(), ticketQuery, reservationId, price, typeId, statusId, ticket.Section,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to create tickets.")
+				return
+			}
+		}
+
+		if _, err := tx.Exec(
+			r.Context(),
+			`UPDATE reservations SET total_tickets = $1 WHERE id = $2`,
+			len(requested),
+			reservationId,
+		); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to update the reservation.",
+			)
+			return
+		}
+
+		if err := tx.Commit(r.Context()); err != nil {
 			writeErrorResponse(
 				w,
 				http.StatusInternalServerError,
@@ -976,10 +2050,75 @@ func CancelReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
+		tickets, err := fetchTickets(r.Context(), pool, reservationId, false)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets.")
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, models.ReservationTicketsResponse{
+			ReservationID: reservationId,
+			UserID:        userId,
+			Tickets:       tickets,
+		})
+	}
+}
+
+// UpdateReservationNotesHandler sets internal notes of a reservation.
+//
+//	@Summary		Update reservation notes (admin only).
+//	@Description	Set or clear (with null) the internal notes attached to a reservation.
+//	@Tags			reservations
+//	@ID				api.updateReservationNotes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Reservation ID"
+//	@Param			body	body		models.UpdateReservationNotesRequest	true	"Notes to set"
+//	@Success		200		{object}	models.SuccessResponse				"Notes updated successfully"
+//	@Failure		400		{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		415		{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		403		{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse				"Not Found"
+//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/{id}/notes [patch]
+func UpdateReservationNotesHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to update reservation notes.",
+			)
+			return
+		}
+
+		reservationId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req models.UpdateReservationNotesRequest
+		if status, err := decodeJSONBody(r, &req); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		query := `UPDATE Reservations SET notes = $1 WHERE id = $2`
+		tag, err := pool.Exec(r.Context(), query, req.Notes, reservationId)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to update notes.")
+			return
+		}
+		if tag.RowsAffected() == 0 {
+			writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+			return
+		}
+
 		writeJSONResponse(
 			w,
 			http.StatusOK,
-			models.SuccessResponse{Message: "Reservation canceled successfully."},
+			models.SuccessResponse{Message: "Reservation notes updated successfully."},
 		)
 	}
 }
@@ -1009,9 +2148,10 @@ func DeleteReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
-		reservationId, err := parseReservationIdFromURL(r)
+		reservationId, err := parsePathID(r, "id")
 		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
 		}
 
 		tx, err := pool.Begin(r.Context())
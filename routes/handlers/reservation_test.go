@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"event-reservation-api/models"
+)
+
+func TestGetReservationByIDOfAnotherUser(t *testing.T) {
+	pool := testPool(t)
+	userA, _ := createTestUser(t, pool, "REGISTERED")
+	userB, _ := createTestUser(t, pool, "REGISTERED")
+	eventID := createTestEvent(t, pool, createTestLocation(t, pool))
+	reservationID := createTestReservation(t, pool, userB, eventID, "CONFIRMED")
+	handler := GetReservationByIDHandler(pool)
+
+	get := func(userID, role string) int {
+		r := newTestRequest(http.MethodGet, "/api/reservations/"+reservationID, "")
+		r = withVars(withUser(r, userID, role), map[string]string{"id": reservationID})
+		return serve(handler, r).Code
+	}
+
+	if status := get(userA, "REGISTERED"); status != http.StatusForbidden {
+		t.Fatalf("status for another user = %d, want %d", status, http.StatusForbidden)
+	}
+	if status := get(userB, "REGISTERED"); status != http.StatusOK {
+		t.Fatalf("status for the owner = %d, want %d", status, http.StatusOK)
+	}
+}
+
+func TestUserListsAreEmptyRatherThanNotFound(t *testing.T) {
+	pool := testPool(t)
+	userID, _ := createTestUser(t, pool, "REGISTERED")
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		path    string
+		vars    map[string]string
+		key     string
+	}{
+		{
+			"current user reservations",
+			GetCurrentUserReservationsHandler(pool),
+			"/api/reservations/user",
+			nil,
+			"reservations",
+		},
+		{
+			"current user tickets",
+			GetCurrentUserReservationsTicketsHandler(pool),
+			"/api/reservations/user/tickets",
+			nil,
+			"tickets",
+		},
+		{
+			"reservations of a user",
+			GetUserReservationsHandler(pool),
+			"/api/reservations/user/" + userID,
+			map[string]string{"id": userID},
+			"reservations",
+		},
+		{
+			"tickets of a user",
+			GetUserReservationsTicketsHandler(pool),
+			"/api/reservations/user/" + userID + "/tickets",
+			map[string]string{"id": userID},
+			"tickets",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := withUser(newTestRequest(http.MethodGet, tt.path, ""), userID, "REGISTERED")
+			if tt.vars != nil {
+				r = withVars(r, tt.vars)
+			}
+			rec := serve(tt.handler, r)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			var body map[string]json.RawMessage
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode the body: %v", err)
+			}
+			if string(body[tt.key]) != "[]" {
+				t.Fatalf("%s = %s, want []", tt.key, body[tt.key])
+			}
+		})
+	}
+
+	// single resources are still not found
+	missing := uuid.NewString()
+	r := newTestRequest(http.MethodGet, "/api/reservations/"+missing, "")
+	r = withVars(withUser(r, userID, "REGISTERED"), map[string]string{"id": missing})
+	if status := serve(GetReservationByIDHandler(pool), r).Code; status != http.StatusNotFound {
+		t.Fatalf("status of a missing reservation = %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestCreateReservationDetectsDuplicates(t *testing.T) {
+	pool := testPool(t)
+	userID, _ := createTestUser(t, pool, "REGISTERED")
+	eventID := createTestEvent(t, pool, createTestLocation(t, pool))
+	existingID := createTestReservation(t, pool, userID, eventID, "PENDING")
+	handler := CreateReservationHandler(pool)
+
+	create := func(path string) (int, models.DuplicateReservationResponse) {
+		body := fmt.Sprintf(`{"event_id": %d, "tickets": [{"type": "STANDARD"}]}`, eventID)
+		r := withUser(newTestRequest(http.MethodPost, path, body), userID, "REGISTERED")
+		rec := serve(handler, r)
+		var res models.DuplicateReservationResponse
+		json.NewDecoder(rec.Body).Decode(&res)
+		return rec.Code, res
+	}
+
+	status, res := create("/api/reservations")
+	if status != http.StatusConflict {
+		t.Fatalf("status of a duplicate = %d, want %d", status, http.StatusConflict)
+	}
+	if res.ReservationID != existingID {
+		t.Fatalf("reservation_id = %q, want %q", res.ReservationID, existingID)
+	}
+
+	// deliberate second reservations are still possible
+	if status, _ := create("/api/reservations?allow_duplicate=true"); status != http.StatusCreated {
+		t.Fatalf("status with allow_duplicate = %d, want %d", status, http.StatusCreated)
+	}
+}
+
+func TestConcurrentDuplicateReservations(t *testing.T) {
+	pool := testPool(t)
+	userID, _ := createTestUser(t, pool, "REGISTERED")
+	eventID := createTestEvent(t, pool, createTestLocation(t, pool))
+	handler := CreateReservationHandler(pool)
+
+	// every request runs the duplicate check before any of them commits
+	const requests = 6
+	statuses := make([]int, requests)
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body := fmt.Sprintf(`{"event_id": %d, "tickets": [{"type": "STANDARD"}]}`, eventID)
+			r := withUser(newTestRequest(http.MethodPost, "/api/reservations", body), userID, "REGISTERED")
+			statuses[i] = serve(handler, r).Code
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, status := range statuses {
+		switch status {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+		default:
+			t.Fatalf("reservation got %d, want %d or %d", status, http.StatusCreated, http.StatusConflict)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("%d reservations were created, want 1", created)
+	}
+}
+
+func TestConcurrentReservationTransitions(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	adminID, _ := createTestUser(t, pool, "ADMIN")
+	userID, _ := createTestUser(t, pool, "REGISTERED")
+	eventID := createTestEvent(t, pool, createTestLocation(t, pool))
+
+	cancel := CancelReservationHandler(pool)
+	confirm := ConfirmReservationHandler(pool)
+	transition := func(handler http.Handler, action, reservationID string) int {
+		r := newTestRequest(http.MethodPost, "/api/reservations/"+reservationID+"/"+action, "")
+		r = withVars(withUser(r, adminID, "ADMIN"), map[string]string{"id": reservationID})
+		return serve(handler, r).Code
+	}
+	availableTickets := func() int {
+		var available int
+		query := `SELECT available_tickets FROM events WHERE id = $1`
+		if err := pool.QueryRow(ctx, query, eventID).Scan(&available); err != nil {
+			t.Fatalf("failed to fetch available tickets: %v", err)
+		}
+		return available
+	}
+
+	tests := []struct {
+		name   string
+		other  http.Handler
+		action string
+	}{
+		{"cancel and confirm", confirm, "confirm"},
+		{"two cancels", cancel, "cancel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 10 {
+				reservationID := createTestReservation(t, pool, userID, eventID, "PENDING")
+				before := availableTickets()
+
+				var cancelStatus, otherStatus int
+				var wg sync.WaitGroup
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					cancelStatus = transition(cancel, "cancel", reservationID)
+				}()
+				go func() {
+					defer wg.Done()
+					otherStatus = transition(tt.other, tt.action, reservationID)
+				}()
+				wg.Wait()
+
+				// the cancellation either wins, or follows the other transition
+				if cancelStatus != http.StatusOK && cancelStatus != http.StatusConflict {
+					t.Fatalf("cancel got %d", cancelStatus)
+				}
+				if otherStatus != http.StatusOK && otherStatus != http.StatusConflict {
+					t.Fatalf("%s got %d", tt.action, otherStatus)
+				}
+				if tt.action == "cancel" && cancelStatus == otherStatus {
+					t.Fatalf("both cancels got %d, want one %d and one %d",
+						cancelStatus, http.StatusOK, http.StatusConflict)
+				}
+
+				var status string
+				query := `
+					SELECT rs.name
+					FROM reservations r
+					JOIN reservation_statuses rs ON r.status_id = rs.id
+					WHERE r.id = $1
+				`
+				if err := pool.QueryRow(ctx, query, reservationID).Scan(&status); err != nil {
+					t.Fatalf("failed to fetch the reservation: %v", err)
+				}
+				if status != "CANCELLED" {
+					t.Fatalf("status = %s, want CANCELLED", status)
+				}
+
+				// seats of the single ticket are released exactly once
+				if after := availableTickets(); after != before+1 {
+					t.Fatalf("available tickets went from %d to %d, want %d",
+						before, after, before+1)
+				}
+			}
+		})
+	}
+}
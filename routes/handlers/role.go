@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// GetRolesHandler lists all roles.
+//
+//	@Summary		List all roles (admin only).
+//	@Description	Retrieve a list of roles with their descriptions, granted permissions and number of users.
+//	@Tags			roles
+//	@ID				api.getRoles
+//	@Produce		json
+//	@Success		200	{object}	models.RolesResponse	"List of roles"
+//	@Failure		403	{object}	models.ErrorResponse	"Forbidden"
+//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/roles [get]
+func GetRolesHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		// roles with their permissions and user counts
+		query := `
+			SELECT
+				r.id, r.name, COALESCE(r.description, ''),
+				COALESCE(
+					ARRAY_AGG(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL),
+					'{}'
+				),
+				(SELECT COUNT(*) FROM users u WHERE u.role_id = r.id)
+			FROM roles r
+			LEFT JOIN role_permissions rp ON rp.role_id = r.id
+			LEFT JOIN permissions p ON p.id = rp.permission_id
+			GROUP BY r.id
+			ORDER BY r.id ASC
+		`
+		rows, err := pool.Query(r.Context(), query)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch roles.")
+			return
+		}
+		defer rows.Close()
+
+		roles := []models.RoleResponse{}
+		for rows.Next() {
+			var role models.RoleResponse
+			if err := rows.Scan(
+				&role.ID,
+				&role.Name,
+				&role.Description,
+				&role.Permissions,
+				&role.UserCount,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse role data.")
+				return
+			}
+			roles = append(roles, role)
+		}
+		writeJSONResponse(w, http.StatusOK, models.RolesResponse{Roles: roles})
+	}
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// GetEventSectionsHandler lists the sections of an event.
+//
+//	@Summary		Get sections of an event.
+//	@Description	Retrieve the seating sections tickets of the event can be reserved in, ordered by name.
+//	@ID				api.getEventSections
+//	@Tags			events
+//	@Produce		json
+//	@Param			id	path		string							true	"Event ID"
+//	@Success		200	{object}	models.EventSectionsResponse	"Sections of the event"
+//	@Failure		404	{object}	models.ErrorResponse			"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse			"Internal Server Error"
+//	@Router			/events/{id}/sections [get]
+func GetEventSectionsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided in the URL.")
+			return
+		}
+
+		var response models.EventSectionsResponse
+		query := `SELECT id FROM Events WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), query, eventID).Scan(&response.EventID); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the event.")
+			return
+		}
+
+		query = `SELECT name FROM event_sections WHERE event_id = $1 ORDER BY name`
+		rows, err := pool.Query(r.Context(), query, response.EventID)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch sections.")
+			return
+		}
+		defer rows.Close()
+
+		response.Sections = []string{}
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse sections.")
+				return
+			}
+			response.Sections = append(response.Sections, name)
+		}
+		if err := rows.Err(); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch sections.")
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// SetEventSectionsHandler replaces the sections of an event.
+//
+//	@Summary		Set sections of an event (admin only).
+//	@Description	Replace the seating sections of the event with the given list. An empty list removes all sections, after which tickets can only be reserved without a section. Tickets already reserved keep their section.
+//	@ID				api.setEventSections
+//	@Tags			events
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Event ID"
+//	@Param			body	body		models.SetEventSectionsRequest	true	"Sections of the event"
+//	@Success		200		{object}	models.SuccessResponse			"Sections updated successfully"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse			"Not Found"
+//	@Failure		415		{object}	models.ErrorResponse			"Unsupported Media Type"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/events/{id}/sections [put]
+func SetEventSectionsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to set sections.",
+			)
+			return
+		}
+
+		eventID, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Event ID not provided in the URL.")
+			return
+		}
+
+		var payload models.SetEventSectionsRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		// duplicates are folded, as a section is identified by its name
+		sections := []string{}
+		seen := map[string]bool{}
+		for _, name := range payload.Sections {
+			name = strings.TrimSpace(name)
+			if name == "" || len(name) > 50 {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					"Invalid section name; must be 1 to 50 characters.",
+				)
+				return
+			}
+			if !seen[name] {
+				seen[name] = true
+				sections = append(sections, name)
+			}
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		var exists bool
+		existsQuery := `SELECT EXISTS(SELECT 1 FROM Events WHERE id = $1)`
+		if err := tx.QueryRow(r.Context(), existsQuery, eventID).Scan(&exists); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the event.")
+			return
+		}
+		if !exists {
+			writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+			return
+		}
+
+		query := `DELETE FROM event_sections WHERE event_id = $1`
+		if _, err := tx.Exec(r.Context(), query, eventID); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to set sections.")
+			return
+		}
+
+		query = `INSERT INTO event_sections (event_id, name) SELECT $1, unnest($2::text[])`
+		if _, err := tx.Exec(r.Context(), query, eventID, sections); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to set sections.")
+			return
+		}
+
+		if err = tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to commit transaction.")
+			return
+		}
+
+		writeJSONResponse(
+			w,
+			http.StatusOK,
+			models.SuccessResponse{Message: "Sections updated successfully."},
+		)
+	}
+}
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// CreateEventSeriesHandler generates a series of recurring events.
+//
+//	@Summary		Create a recurring event series (admin only).
+//	@Description	Create the base event and its recurrences in one transaction, linked by a series ID. The rule repeats the event every interval days, weeks or months, either count times or until the given date (inclusive). The number of generated events is capped.
+//	@ID				api.createEventSeries
+//	@Tags			events
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		models.CreateEventSeriesRequest		true	"Base event and recurrence rule"
+//	@Success		201		{object}	models.CreateEventSeriesResponse	"Event series created successfully"
+//	@Failure		400		{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		415		{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/events/series [post]
+func CreateEventSeriesHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to create an event series.",
+			)
+			return
+		}
+
+		var payload models.CreateEventSeriesRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		event := payload.Event
+		if !isValidCreateEventRequest(event) {
+			writeErrorResponse(w, http.StatusBadRequest, "Missing or invalid fields.")
+			return
+		}
+
+		start, err := parseEventDate(event.Date)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if !start.After(time.Now()) {
+			writeErrorResponse(w, http.StatusBadRequest, "Date must be in the future.")
+			return
+		}
+
+		// every event of the series goes on sale at the same moment
+		event.SaleStartsAt, err = normalizeSaleStart(event.SaleStartsAt, start.Format(time.RFC3339))
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		visibility, err := normalizeVisibility(event.Visibility)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		event.Visibility = &visibility
+
+		rule := payload.Recurrence
+		rule.Frequency = strings.ToUpper(rule.Frequency)
+		dates, err := generateSeriesDates(start, rule)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if rule.Interval == 0 {
+			rule.Interval = 1
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		locationID, err := getLocationID(
+			r, tx,
+			&event.Location.Address, &event.Location.Stadium,
+			&event.Location.Capacity,
+			&event.Location.Country,
+		)
+		if err != nil {
+			if err == errUnknownCountry {
+				writeErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		var seriesID int
+		query := `
+			INSERT INTO event_series (frequency, repeat_interval)
+			VALUES ($1, $2)
+			RETURNING id
+		`
+		if err := tx.QueryRow(
+			r.Context(), query, rule.Frequency, rule.Interval,
+		).Scan(&seriesID); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to create the series.")
+			return
+		}
+
+		eventIDs := make([]int, 0, len(dates))
+		for _, date := range dates {
+			eventID, err := insertEvent(
+				r.Context(), tx, event, date.Format(time.RFC3339), locationID, &seriesID,
+			)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			eventIDs = append(eventIDs, eventID)
+		}
+
+		if err = tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to commit the transaction.",
+			)
+			return
+		}
+
+		invalidateEventCaches()
+
+		writeJSONResponse(w, http.StatusCreated, models.CreateEventSeriesResponse{
+			Message:  "Event series created successfully.",
+			SeriesID: seriesID,
+			EventIDs: eventIDs,
+		})
+	}
+}
+
+// GetEventSeriesHandler returns the events of a series.
+//
+//	@Summary		Get an event series.
+//	@Description	Retrieve the recurrence of a series along with its events, ordered by date. Events removed from the series are not listed.
+//	@ID				api.getEventSeries
+//	@Tags			events
+//	@Produce		json
+//	@Param			seriesId	path		string						true	"Series ID"
+//	@Param			tz			query		string						false	"IANA timezone of returned dates, e.g. America/New_York (default UTC)"
+//	@Success		200			{object}	models.EventSeriesResponse	"Event series"
+//	@Failure		400			{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		404			{object}	models.ErrorResponse		"Not Found"
+//	@Failure		500			{object}	models.ErrorResponse		"Internal Server Error"
+//	@Router			/events/series/{seriesId} [get]
+func GetEventSeriesHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		seriesID, err := parsePathID(r, "seriesId")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		loc, err := parseTimezone(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var response models.EventSeriesResponse
+		query := `SELECT id, frequency, repeat_interval FROM event_series WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), query, seriesID).Scan(
+			&response.ID, &response.Frequency, &response.Interval,
+		); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event series not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the series.")
+			return
+		}
+
+		// series are capped on creation, so they are never paginated
+		events, err := fetchEvents(
+			r.Context(), pool,
+			"WHERE e.series_id = $1 AND e.visibility = 'PUBLIC'", "ORDER BY e.date, e.id",
+			[]interface{}{seriesID, nil, 0},
+		)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch events.")
+			return
+		}
+		response.Events = localizeEvents(events, loc)
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// Parse the date of an event in either YYYY-MM-DD HH:MM or RFC3339 format.
+func parseEventDate(value string) (time.Time, error) {
+	rfc3339Date, err := dateToRFC3339(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid date format; must be YYYY-MM-DD HH:MM or RFC3339.")
+	}
+	return time.Parse(time.RFC3339, rfc3339Date)
+}
+
+// Generate the dates of a series from the recurrence rule, starting with the
+// base date. Exactly one of count and until has to be set, and the series may
+// not exceed the configured number of events.
+func generateSeriesDates(
+	start time.Time,
+	rule models.EventRecurrenceRequest,
+) ([]time.Time, error) {
+	interval := rule.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	if interval < 0 {
+		return nil, fmt.Errorf("Invalid interval; must be a positive integer.")
+	}
+
+	var next func(i int) time.Time
+	switch rule.Frequency {
+	case "DAILY":
+		next = func(i int) time.Time { return start.AddDate(0, 0, i*interval) }
+	case "WEEKLY":
+		next = func(i int) time.Time { return start.AddDate(0, 0, 7*i*interval) }
+	case "MONTHLY":
+		next = func(i int) time.Time { return start.AddDate(0, i*interval, 0) }
+	default:
+		return nil, fmt.Errorf("Invalid frequency; must be daily, weekly or monthly.")
+	}
+
+	if (rule.Count == nil) == (rule.Until == nil) {
+		return nil, fmt.Errorf("Exactly one of count and until is required.")
+	}
+
+	dates := []time.Time{}
+	if rule.Count != nil {
+		if *rule.Count <= 0 {
+			return nil, fmt.Errorf("Invalid count; must be a positive integer.")
+		}
+		if *rule.Count > maxSeriesEvents {
+			return nil, fmt.Errorf("Series must not exceed %d events.", maxSeriesEvents)
+		}
+		for i := 0; i < *rule.Count; i++ {
+			dates = append(dates, next(i))
+		}
+		return dates, nil
+	}
+
+	until, err := parseEventDate(*rule.Until)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid until; must be YYYY-MM-DD HH:MM or RFC3339.")
+	}
+	if until.Before(start) {
+		return nil, fmt.Errorf("Invalid until; must not precede the event date.")
+	}
+	for i := 0; !next(i).After(until); i++ {
+		if len(dates) == maxSeriesEvents {
+			return nil, fmt.Errorf("Series must not exceed %d events.", maxSeriesEvents)
+		}
+		dates = append(dates, next(i))
+	}
+	return dates, nil
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/middlewares"
+	"event-reservation-api/models"
+)
+
+// GetSessionsHandler lists active sessions of the current user.
+//
+//	@Summary		List active sessions of the current user.
+//	@Description	Retrieve non-expired and non-revoked sessions, along with the time they were issued and the client they were issued to.
+//	@Tags			auth
+//	@ID				api.getSessions
+//	@Produce		json
+//	@Success		200	{object}	models.SessionsResponse	"List of sessions"
+//	@Failure		401	{object}	models.ErrorResponse	"Unauthorized"
+//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/auth/sessions [get]
+func GetSessionsHandler(
+	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		// mark the session the request was made with
+		currentToken, _ := middlewares.ExtractToken(r)
+		currentHash := middlewares.HashToken(currentToken)
+
+		query := `
+			SELECT s.id, s.token_hash, s.issued_at, s.expires_at, host(s.ip_address), s.user_agent
+			FROM user_sessions s
+			WHERE s.user_id = $1 AND s.expires_at >= $2
+			ORDER BY s.issued_at DESC, s.id
+		`
+		rows, err := pool.Query(r.Context(), query, userId, time.Now())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch sessions.")
+			return
+		}
+		defer rows.Close()
+
+		sessions := []models.SessionResponse{}
+		for rows.Next() {
+			var session models.SessionResponse
+			var tokenHash string
+			if err := rows.Scan(
+				&session.ID, &tokenHash, &session.IssuedAt, &session.ExpiresAt,
+				&session.IPAddress, &session.UserAgent,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse session.")
+				return
+			}
+
+			// revoked sessions are not active anymore
+			revoked, err := blacklist.IsHashBlacklisted(r.Context(), tokenHash)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch sessions.")
+				return
+			}
+			if revoked {
+				continue
+			}
+
+			session.Current = tokenHash == currentHash
+			sessions = append(sessions, session)
+		}
+		if err := rows.Err(); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch sessions.")
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, models.SessionsResponse{Sessions: sessions})
+	}
+}
+
+// DeleteSessionHandler revokes a session of the current user.
+//
+//	@Summary		Revoke a session of the current user.
+//	@Description	Blacklist the token of the session with provided ID, logging the client out.
+//	@Tags			auth
+//	@ID				api.deleteSession
+//	@Produce		json
+//	@Param			id	path		int						true	"Session ID"
+//	@Success		200	{object}	models.SuccessResponse	"Session revoked"
+//	@Failure		400	{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		401	{object}	models.ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	models.ErrorResponse	"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/auth/sessions/{id} [delete]
+func DeleteSessionHandler(
+	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		// only active sessions of the caller can be revoked
+		var tokenHash string
+		var expiresAt time.Time
+		query := `
+			SELECT s.token_hash, s.expires_at
+			FROM user_sessions s
+			WHERE s.id = $1 AND s.user_id = $2 AND s.expires_at >= $3
+		`
+		if err := pool.QueryRow(
+			r.Context(), query, sessionId, userId, time.Now(),
+		).Scan(&tokenHash, &expiresAt); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Session not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the session.")
+			return
+		}
+
+		revoked, err := blacklist.IsHashBlacklisted(r.Context(), tokenHash)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the session.")
+			return
+		}
+		if revoked {
+			writeErrorResponse(w, http.StatusNotFound, "Session not found.")
+			return
+		}
+
+		if err := blacklist.AddHash(r.Context(), tokenHash, expiresAt); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to invalidate the token.")
+			return
+		}
+
+		writeJSONResponse(
+			w,
+			http.StatusOK,
+			models.SuccessResponse{Message: "Session revoked successfully."},
+		)
+	}
+}
+
+// Blacklist tokens of all active sessions of the user.
+func revokeUserSessions(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
+	userID string,
+) error {
+	query := `
+		SELECT s.token_hash, s.expires_at
+		FROM user_sessions s
+		WHERE s.user_id = $1 AND s.expires_at >= $2
+	`
+	rows, err := pool.Query(ctx, query, userID, time.Now())
+	if err != nil {
+		return fmt.Errorf("Failed to fetch sessions.")
+	}
+	defer rows.Close()
+
+	type session struct {
+		tokenHash string
+		expiresAt time.Time
+	}
+	sessions := []session{}
+	for rows.Next() {
+		var s session
+		if err := rows.Scan(&s.tokenHash, &s.expiresAt); err != nil {
+			return fmt.Errorf("Failed to parse session.")
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("Failed to fetch sessions.")
+	}
+
+	for _, s := range sessions {
+		if err := blacklist.AddHash(ctx, s.tokenHash, s.expiresAt); err != nil {
+			return fmt.Errorf("Failed to invalidate the token.")
+		}
+	}
+	return nil
+}
+
+// Record a session for a newly issued token, along with the client details.
+// Only the hash of the token is stored, as with the blacklist.
+func createSession(
+	r *http.Request,
+	pool *pgxpool.Pool,
+	userID string,
+	token string,
+	exp int64,
+) error {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	query := `
+		INSERT INTO user_sessions (user_id, token_hash, expires_at, ip_address, user_agent)
+		VALUES ($1, $2, $3, NULLIF($4, '')::inet, NULLIF($5, ''))
+	`
+	_, err = pool.Exec(
+		r.Context(), query,
+		userID, middlewares.HashToken(token), time.Unix(exp, 0), ip, r.UserAgent(),
+	)
+	return err
+}
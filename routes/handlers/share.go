@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// GetReservationShareLinkHandler returns a shareable link to a reservation.
+//
+//	@Summary		Share a reservation (owner only).
+//	@Description	Retrieve a signed, time-limited token giving read-only access to a redacted view of the reservation, e.g. for a companion. Anyone holding the link can see the event, status and ticket types, along with the first name of the buyer.
+//	@Tags			reservations
+//	@ID				api.getReservationShareLink
+//	@Produce		json
+//	@Param			id	path		string							true	"Reservation ID"
+//	@Success		200	{object}	models.ShareReservationResponse	"Shareable link"
+//	@Failure		400	{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403	{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse			"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/reservations/{id}/share [get]
+func GetReservationShareLinkHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reservationId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var ownerId string
+		query := `SELECT user_id FROM reservations WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), query, reservationId).Scan(&ownerId); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Reservation not found.")
+				return
+			}
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the reservation.",
+			)
+			return
+		}
+
+		// sharing is up to the buyer alone, not to admins acting on their behalf
+		if !isOwner(r, ownerId) || isImpersonated(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		expiresAt := time.Now().Add(time.Duration(shareLinkHours) * time.Hour).Truncate(time.Second)
+		token := signShareToken(reservationId, expiresAt, jwtSecret)
+		writeJSONResponse(w, http.StatusOK, models.ShareReservationResponse{
+			Token:     token,
+			URL:       "/api/shared/reservation?token=" + url.QueryEscape(token),
+			ExpiresAt: expiresAt.UTC(),
+		})
+	}
+}
+
+// GetSharedReservationHandler returns the redacted view of a shared reservation.
+//
+//	@Summary		View a shared reservation.
+//	@Description	Retrieve the reservation the share token was signed for, without personal data beyond the first name of the buyer. Expired and invalid tokens are treated as missing.
+//	@Tags			reservations
+//	@ID				api.getSharedReservation
+//	@Produce		json
+//	@Param			token	query		string								true	"Share token"
+//	@Success		200		{object}	models.SharedReservationResponse	"Shared reservation"
+//	@Failure		400		{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		404		{object}	models.ErrorResponse				"Not Found"
+//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
+//	@Router			/shared/reservation [get]
+func GetSharedReservationHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			writeErrorResponse(w, http.StatusBadRequest, "Share token is required.")
+			return
+		}
+
+		reservationId, ok := verifyShareToken(token, jwtSecret, time.Now())
+		if !ok {
+			writeErrorResponse(w, http.StatusNotFound, "Shared reservation not found.")
+			return
+		}
+
+		var response models.SharedReservationResponse
+		var event models.EventResponse
+		var location models.LocationResponse
+		query := `
+			SELECT r.id, u.name, r.total_tickets, rs.name,
+				e.id, e.name, e.date, l.country, l.address, l.stadium
+			FROM reservations r
+			JOIN reservation_statuses rs ON r.status_id = rs.id
+			JOIN users u ON r.user_id = u.id
+			JOIN events e ON r.event_id = e.id
+			JOIN locations l ON e.location_id = l.id
+			WHERE r.id = $1
+		`
+		if err := pool.QueryRow(r.Context(), query, reservationId).Scan(
+			&response.ID, &response.BuyerName, &response.TotalTickets, &response.Status,
+			&event.ID, &event.Name, &event.Date,
+			&location.Country, &location.Address, &location.Stadium,
+		); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Shared reservation not found.")
+				return
+			}
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the reservation.",
+			)
+			return
+		}
+
+		// ticket IDs are left out, they are what gets the holder through the gate
+		tickets, err := fetchTickets(r.Context(), pool, reservationId, false)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets.")
+			return
+		}
+		response.Tickets = []models.SharedTicketResponse{}
+		for _, ticket := range tickets {
+			response.Tickets = append(
+				response.Tickets,
+				models.SharedTicketResponse{Type: ticket.Type, Status: ticket.Status},
+			)
+		}
+
+		event.Location = location
+		response.Event = event
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// Sign the reservation ID along with the expiry, producing a share token.
+func signShareToken(reservationID string, expiresAt time.Time, secret string) string {
+	payload := reservationID + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("share:" + payload))
+	return payload + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify the share token, returning the reservation ID it was signed for,
+// provided the token has not expired yet.
+func verifyShareToken(token, secret string, now time.Time) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+	expiresUnix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", false
+	}
+	expiresAt := time.Unix(expiresUnix, 0)
+	expected := signShareToken(parts[0], expiresAt, secret)
+	if !hmac.Equal([]byte(token), []byte(expected)) || !now.Before(expiresAt) {
+		return "", false
+	}
+	return parts[0], true
+}
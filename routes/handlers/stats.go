@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// GetAvailabilityStatsHandler reports seats available across upcoming events.
+//
+//	@Summary		Get seat availability.
+//	@Description	Retrieve the number of seats available across upcoming events, along with the number of such events. Cached briefly.
+//	@Tags			stats
+//	@ID				api.getAvailabilityStats
+//	@Produce		json
+//	@Success		200	{object}	models.AvailabilityStatsResponse	"Seat availability"
+//	@Failure		500	{object}	models.ErrorResponse				"Internal Server Error"
+//	@Router			/stats/availability [get]
+func GetAvailabilityStatsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		stats, err := availabilityCache.get(
+			r.Context(),
+			time.Duration(eventsCacheSeconds)*time.Second,
+			func(ctx context.Context) (models.AvailabilityStatsResponse, error) {
+				return fetchAvailabilityStats(ctx, pool)
+			},
+		)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch statistics.")
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, stats)
+	}
+}
+
+// Sum the available tickets of upcoming events. Aggregates without rows
+// still return one, so no events result in zeros.
+func fetchAvailabilityStats(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+) (models.AvailabilityStatsResponse, error) {
+	var stats models.AvailabilityStatsResponse
+	query := `
+		SELECT COALESCE(SUM(available_tickets), 0), COUNT(*)
+		FROM events
+		WHERE date > NOW()
+	`
+	err := pool.QueryRow(ctx, query).Scan(&stats.AvailableSeats, &stats.Events)
+	return stats, err
+}
+
+// Lengths of the buckets sales can be grouped into, named after date_trunc fields.
+var salesBuckets = map[string]time.Duration{
+	"day":  24 * time.Hour,
+	"week": 7 * 24 * time.Hour,
+}
+
+// Most buckets a single sales time series may span.
+const maxSalesBuckets = 366
+
+// Range of the sales time series, unless given.
+const defaultSalesRangeDays = 30
+
+// GetSalesTimeseriesHandler reports sales over time.
+//
+//	@Summary		Get sales time series (admin only).
+//	@Description	Retrieve the number of sold (including used) tickets and their revenue per day or week, grouped by the reservation creation time in UTC. Buckets without sales are reported with zeros, so the series is continuous. The range defaults to the last 30 days and may span at most 366 buckets.
+//	@Tags			stats
+//	@ID				api.getSalesTimeseries
+//	@Produce		json
+//	@Param			from	query		string							false	"Start of the range (YYYY-MM-DD HH:MM or RFC3339)"
+//	@Param			to		query		string							false	"End of the range, exclusive (YYYY-MM-DD HH:MM or RFC3339, default now)"
+//	@Param			bucket	query		string							false	"Bucket length"	Enums(day, week)	default(day)
+//	@Success		200		{object}	models.SalesTimeseriesResponse	"Sales per bucket"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/stats/sales-timeseries [get]
+func GetSalesTimeseriesHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		bucket := r.URL.Query().Get("bucket")
+		if bucket == "" {
+			bucket = "day"
+		}
+		step, ok := salesBuckets[bucket]
+		if !ok {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid bucket; must be day or week.")
+			return
+		}
+
+		to := time.Now().UTC()
+		if param := r.URL.Query().Get("to"); param != "" {
+			date, err := parseStatsDate(param, "to")
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			to = date
+		}
+		from := to.AddDate(0, 0, -defaultSalesRangeDays)
+		if param := r.URL.Query().Get("from"); param != "" {
+			date, err := parseStatsDate(param, "from")
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			from = date
+		}
+		if !from.Before(to) {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid range; from must precede to.")
+			return
+		}
+
+		// buckets are counted from the start of the first one, as date_trunc does
+		first := truncateToBucket(from, bucket)
+		if buckets := (to.Sub(first) + step - 1) / step; buckets > maxSalesBuckets {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				fmt.Sprintf("Range must not exceed %d buckets.", maxSalesBuckets),
+			)
+			return
+		}
+
+		// the series of bucket starts is joined with the sales, filling the gaps
+		query := `
+			WITH sales AS (
+				SELECT date_trunc($1, r.created_at) AS bucket,
+					COUNT(t.id) AS tickets_sold,
+					SUM(t.price) AS revenue
+				FROM tickets t
+				JOIN reservations r ON t.reservation_id = r.id
+				WHERE t.status_id IN (SELECT id FROM ticket_statuses WHERE name IN ('SOLD', 'USED'))
+					AND r.created_at >= $2::timestamp AND r.created_at < $3::timestamp
+				GROUP BY 1
+			)
+			SELECT b.start, COALESCE(s.tickets_sold, 0), COALESCE(s.revenue, 0)
+			FROM generate_series(
+				date_trunc($1, $2::timestamp),
+				$3::timestamp - INTERVAL '1 microsecond',
+				('1 ' || $1)::interval
+			) AS b(start)
+			LEFT JOIN sales s ON s.bucket = b.start
+			ORDER BY b.start
+		`
+		rows, err := pool.Query(r.Context(), query, bucket, from, to)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch statistics.")
+			return
+		}
+		defer rows.Close()
+
+		response := models.SalesTimeseriesResponse{
+			Bucket:   bucket,
+			From:     from,
+			To:       to,
+			Currency: currency,
+			Buckets:  []models.SalesBucketResponse{},
+		}
+		for rows.Next() {
+			var sales models.SalesBucketResponse
+			if err := rows.Scan(&sales.Start, &sales.TicketsSold, &sales.Revenue); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse statistics.")
+				return
+			}
+			response.Buckets = append(response.Buckets, sales)
+		}
+		if err := rows.Err(); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch statistics.")
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// Parse a date of the statistics range, in UTC.
+func parseStatsDate(value, name string) (time.Time, error) {
+	rfc3339Date, err := dateToRFC3339(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"Invalid %s date; must be YYYY-MM-DD HH:MM or RFC3339.",
+			name,
+		)
+	}
+	date, err := time.Parse(time.RFC3339, rfc3339Date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"Invalid %s date; must be YYYY-MM-DD HH:MM or RFC3339.",
+			name,
+		)
+	}
+	return date.UTC(), nil
+}
+
+// Truncate the time to the start of its bucket, weeks starting on Monday.
+func truncateToBucket(t time.Time, bucket string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if bucket == "week" {
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return day
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"event-reservation-api/models"
+)
+
+// Built-in template of the reservation confirmation message, used when
+// neither CONFIRMATION_TEMPLATE nor CONFIRMATION_TEMPLATE_PATH is set.
+const defaultConfirmationTemplate = `Hello {{.Buyer.Name}} {{.Buyer.Surname}},
+
+your reservation {{.ReservationID}} has been confirmed.
+
+Event: {{.Event.Name}}
+Date:  {{.Event.Date.Format "2006-01-02 15:04"}}
+Venue: {{.Event.Location.Stadium}}, {{.Event.Location.Address}}, {{.Event.Location.Country}}
+
+Tickets:
+{{range .Tickets}}- {{.Type}}: {{printf "%.2f" .Price}} {{$.Currency}}
+{{end}}
+Total: {{printf "%.2f" .Total}} {{.Currency}}
+`
+
+// Parsed template of the reservation confirmation message.
+var confirmationTemplate = template.Must(
+	template.New("confirmation").Parse(defaultConfirmationTemplate),
+)
+
+// Fields available to the reservation confirmation template.
+type ConfirmationTemplateData struct {
+	ReservationID string
+	Buyer         models.UserResponse
+	Event         models.EventResponse
+	Tickets       []models.TicketResponse
+	Total         models.Money
+	Currency      string
+}
+
+// Load the reservation confirmation template, either inline from
+// CONFIRMATION_TEMPLATE or from the file at CONFIRMATION_TEMPLATE_PATH.
+// The template is rendered against sample data, so mistakes surface at startup
+// rather than when the first confirmation is sent.
+func InitConfirmationTemplate() error {
+	source := os.Getenv("CONFIRMATION_TEMPLATE")
+	if path := os.Getenv("CONFIRMATION_TEMPLATE_PATH"); source == "" && path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read the confirmation template: %w", err)
+		}
+		source = string(content)
+	}
+	if source == "" {
+		return nil
+	}
+
+	parsed, err := template.New("confirmation").Parse(source)
+	if err != nil {
+		return fmt.Errorf("failed to parse the confirmation template: %w", err)
+	}
+	sample := ConfirmationTemplateData{Tickets: []models.TicketResponse{{}}, Currency: currency}
+	if err := parsed.Execute(&strings.Builder{}, sample); err != nil {
+		return fmt.Errorf("failed to render the confirmation template: %w", err)
+	}
+
+	confirmationTemplate = parsed
+	return nil
+}
+
+// Render the reservation confirmation message.
+func RenderConfirmation(data ConfirmationTemplateData) (string, error) {
+	var sb strings.Builder
+	if err := confirmationTemplate.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,647 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// Most tokens a single verification batch may carry.
+const maxVerifyBatchTokens = 100
+
+// CheckInTicketHandler marks a ticket as used at the gate.
+//
+//	@Summary		Check in a ticket (admin or staff).
+//	@Description	Transition a SOLD ticket to USED, recording who checked it in and when. Tickets in any other status are rejected, preventing re-entry.
+//	@Tags			tickets
+//	@ID				api.checkInTicket
+//	@Produce		json
+//	@Param			id	path		string							true	"Ticket ID"
+//	@Success		200	{object}	models.TicketCheckInResponse	"Checked in ticket"
+//	@Failure		400	{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403	{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse			"Not Found"
+//	@Failure		409	{object}	models.ErrorResponse			"Conflict"
+//	@Failure		500	{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/tickets/{id}/check-in [post]
+func CheckInTicketHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isStaffOrAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to check in a ticket.",
+			)
+			return
+		}
+
+		ticketId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, err := uuid.Parse(ticketId); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid ticket ID.")
+			return
+		}
+
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		// conditional update, so concurrent check-ins cannot both succeed
+		query := `
+			UPDATE tickets
+			SET status_id = (SELECT id FROM ticket_statuses WHERE name = 'USED'),
+				checked_in_at = NOW(),
+				checked_in_by = $2
+			WHERE id = $1
+				AND status_id = (SELECT id FROM ticket_statuses WHERE name = 'SOLD')
+			RETURNING id, checked_in_at, checked_in_by
+		`
+		ticket := models.TicketCheckInResponse{Status: "USED"}
+		err = pool.QueryRow(r.Context(), query, ticketId, userId).
+			Scan(&ticket.ID, &ticket.CheckedInAt, &ticket.CheckedInBy)
+		if err == nil {
+			writeJSONResponse(w, http.StatusOK, ticket)
+			return
+		}
+		if err != pgx.ErrNoRows {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to check in the ticket.")
+			return
+		}
+
+		// nothing updated, find out why
+		var status string
+		query = `
+			SELECT ts.name
+			FROM tickets t
+			JOIN ticket_statuses ts ON t.status_id = ts.id
+			WHERE t.id = $1
+		`
+		if err := pool.QueryRow(r.Context(), query, ticketId).Scan(&status); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Ticket not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the ticket.")
+			return
+		}
+		if status == "USED" {
+			writeErrorResponse(w, http.StatusConflict, "Ticket has already been checked in.")
+			return
+		}
+		writeErrorResponse(
+			w,
+			http.StatusConflict,
+			fmt.Sprintf("Ticket cannot be checked in; its status is %s.", status),
+		)
+	}
+}
+
+// Statuses an admin may move a ticket to from each status. Moves between the
+// cancelled and the active statuses release or take a seat of the event.
+var ticketStatusTransitions = map[string][]string{
+	"RESERVED":  {"SOLD", "CANCELLED"},
+	"SOLD":      {"USED", "CANCELLED"},
+	"USED":      {"SOLD"},
+	"CANCELLED": {"RESERVED", "SOLD"},
+}
+
+// UpdateTicketStatusHandler corrects the status of a single ticket.
+//
+//	@Summary		Update the status of a ticket (admin only).
+//	@Description	Set the status of the ticket, e.g. to restore a mistakenly cancelled ticket to SOLD. Cancelling a ticket returns its seat to the event and removes it from the reservation's total, restoring a cancelled one takes a seat again. Tickets of cancelled reservations cannot be restored (409). Moving a used ticket back to SOLD clears its check-in. Transitions going backwards, such as SOLD to RESERVED, are rejected with 422.
+//	@Tags			tickets
+//	@ID				api.updateTicketStatus
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Ticket ID"
+//	@Param			body	body		models.UpdateTicketStatusRequest	true	"Target status"
+//	@Success		200		{object}	models.TicketStatusResponse			"Updated ticket"
+//	@Failure		400		{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse				"Not Found"
+//	@Failure		409		{object}	models.ErrorResponse				"Conflict"
+//	@Failure		415		{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		422		{object}	models.ErrorResponse				"Unprocessable Entity"
+//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/tickets/{id}/status [patch]
+func UpdateTicketStatusHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		adminId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		ticketId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, err := uuid.Parse(ticketId); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid ticket ID.")
+			return
+		}
+
+		var payload models.UpdateTicketStatusRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		target := strings.ToUpper(strings.TrimSpace(payload.Status))
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		var targetId int
+		query := `SELECT id FROM ticket_statuses WHERE name = $1`
+		if err := tx.QueryRow(r.Context(), query, target).Scan(&targetId); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusBadRequest, "Invalid ticket status.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the status.")
+			return
+		}
+
+		// lock the ticket and its reservation, so concurrent updates and
+		// cancellations see the status they end up in
+		response := models.TicketStatusResponse{Status: target}
+		var reservationId, reservationStatus string
+		var eventId int
+		query = `
+			SELECT t.id, ts.name, r.id, rs.name, r.event_id
+			FROM tickets t
+			JOIN ticket_statuses ts ON t.status_id = ts.id
+			JOIN reservations r ON t.reservation_id = r.id
+			JOIN reservation_statuses rs ON r.status_id = rs.id
+			WHERE t.id = $1
+			FOR UPDATE OF t, r
+		`
+		if err := tx.QueryRow(r.Context(), query, ticketId).Scan(
+			&response.ID, &response.PreviousStatus, &reservationId, &reservationStatus, &eventId,
+		); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Ticket not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the ticket.")
+			return
+		}
+
+		if !slices.Contains(ticketStatusTransitions[response.PreviousStatus], target) {
+			writeErrorResponse(
+				w,
+				http.StatusUnprocessableEntity,
+				fmt.Sprintf(
+					"Ticket cannot be moved from %s to %s.",
+					response.PreviousStatus, target,
+				),
+			)
+			return
+		}
+
+		// tickets of a cancelled (and possibly refunded) reservation stay cancelled
+		if reservationStatus == "CANCELLED" && target != "CANCELLED" {
+			writeErrorResponse(
+				w,
+				http.StatusConflict,
+				"Reservation is cancelled; its tickets cannot be restored.",
+			)
+			return
+		}
+
+		// a cancelled ticket gives its seat back, a restored one takes it again
+		seats := 0
+		if target == "CANCELLED" {
+			seats = 1
+		} else if response.PreviousStatus == "CANCELLED" {
+			seats = -1
+		}
+		if seats != 0 {
+			query = `
+				UPDATE events
+				SET available_tickets = available_tickets + $2
+				WHERE id = $1 AND available_tickets + $2 >= 0
+			`
+			tag, err := tx.Exec(r.Context(), query, eventId, seats)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to update seats.")
+				return
+			}
+			if tag.RowsAffected() == 0 {
+				writeErrorResponse(
+					w,
+					http.StatusConflict,
+					"No seats left to restore the ticket.",
+				)
+				return
+			}
+
+			// the reservation holds as many tickets as it takes seats
+			query = `UPDATE reservations SET total_tickets = total_tickets - $2 WHERE id = $1`
+			if _, err := tx.Exec(r.Context(), query, reservationId, seats); err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to update the reservation.",
+				)
+				return
+			}
+		}
+
+		// check-in is only kept while the ticket is used
+		query = `
+			UPDATE tickets
+			SET status_id = $2,
+				checked_in_at = CASE WHEN $3 = 'USED' THEN NOW() ELSE NULL END,
+				checked_in_by = CASE WHEN $3 = 'USED' THEN $4::uuid ELSE NULL END
+			WHERE id = $1
+		`
+		if _, err := tx.Exec(
+			r.Context(), query, ticketId, targetId, target, adminId,
+		); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to update the ticket.")
+			return
+		}
+
+		if err := tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to commit transaction.")
+			return
+		}
+
+		invalidateEventCaches()
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// GetTicketTokenHandler returns the signed token of a ticket.
+//
+//	@Summary		Get the token of a ticket (owner, admin or staff).
+//	@Description	Retrieve the signed token identifying the ticket, meant to be encoded in its QR code and verified at the gate.
+//	@Tags			tickets
+//	@ID				api.getTicketToken
+//	@Produce		json
+//	@Param			id	path		string						true	"Ticket ID"
+//	@Success		200	{object}	models.TicketTokenResponse	"Token of the ticket"
+//	@Failure		400	{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		403	{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/tickets/{id}/token [get]
+func GetTicketTokenHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ticketId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, err := uuid.Parse(ticketId); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid ticket ID.")
+			return
+		}
+
+		var ownerId string
+		query := `
+			SELECT r.user_id
+			FROM tickets t
+			JOIN reservations r ON t.reservation_id = r.id
+			WHERE t.id = $1
+		`
+		if err := pool.QueryRow(r.Context(), query, ticketId).Scan(&ownerId); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Ticket not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the ticket.")
+			return
+		}
+
+		if !isStaffOrAdmin(r) && !isOwner(r, ownerId) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, models.TicketTokenResponse{
+			TicketID: ticketId,
+			Token:    signTicketToken(ticketId, jwtSecret),
+		})
+	}
+}
+
+// GetTicketReservationHandler returns the reservation a ticket belongs to.
+//
+//	@Summary		Get the reservation of a ticket (owner, admin or staff).
+//	@Description	Retrieve the reservation the ticket belongs to, including its details and tickets, e.g. for gate staff after scanning a ticket.
+//	@Tags			tickets
+//	@ID				api.getTicketReservation
+//	@Produce		json
+//	@Param			id					path		string						true	"Ticket ID"
+//	@Param			include_cancelled	query		bool						false	"Include cancelled tickets"
+//	@Success		200					{object}	models.ReservationResponse	"Reservation of the ticket"
+//	@Failure		400					{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		403					{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		404					{object}	models.ErrorResponse		"Not Found"
+//	@Failure		500					{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/tickets/{id}/reservation [get]
+func GetTicketReservationHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ticketId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, err := uuid.Parse(ticketId); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid ticket ID.")
+			return
+		}
+
+		var reservationId string
+		query := `SELECT reservation_id FROM tickets WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), query, ticketId).Scan(&reservationId); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Ticket not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the ticket.")
+			return
+		}
+
+		// ownership is enforced against the user of the reservation
+		writeReservation(w, r, pool, reservationId)
+	}
+}
+
+// GetTicketPricingHandler returns the pricing breakdown of a ticket.
+//
+//	@Summary		Get the pricing of a ticket (owner or admin).
+//	@Description	Retrieve how the price of the ticket came about: the base price of the event, the discount of the ticket type and the price set for the type on the event, which takes precedence over the discount. The breakdown reflects the current prices, while price is what the ticket was charged at.
+//	@Tags			tickets
+//	@ID				api.getTicketPricing
+//	@Produce		json
+//	@Param			id	path		string							true	"Ticket ID"
+//	@Success		200	{object}	models.TicketPricingResponse	"Pricing of the ticket"
+//	@Failure		400	{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403	{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404	{object}	models.ErrorResponse			"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/tickets/{id}/pricing [get]
+func GetTicketPricingHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ticketId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, err := uuid.Parse(ticketId); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid ticket ID.")
+			return
+		}
+
+		var ownerId string
+		response := models.TicketPricingResponse{TicketID: ticketId, Currency: currency}
+		query := `
+			SELECT r.user_id, e.id, tt.name, e.price, tt.discount, etp.price, t.price
+			FROM tickets t
+			JOIN ticket_types tt ON t.type_id = tt.id
+			JOIN reservations r ON t.reservation_id = r.id
+			JOIN events e ON r.event_id = e.id
+			LEFT JOIN event_ticket_prices etp
+				ON etp.event_id = e.id AND etp.type_id = t.type_id
+			WHERE t.id = $1
+		`
+		if err := pool.QueryRow(r.Context(), query, ticketId).Scan(
+			&ownerId, &response.EventID, &response.Type, &response.BasePrice,
+			&response.Discount, &response.EventTypePrice, &response.Price,
+		); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Ticket not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the ticket.")
+			return
+		}
+
+		if !isAdmin(r) && !isOwner(r, ownerId) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// VerifyTicketsBatchHandler verifies multiple ticket tokens at once.
+//
+//	@Summary		Verify ticket tokens in a batch (admin or staff).
+//	@Description	Verify tokens scanned at the gate, reporting for each one whether it admits entry, along with the ticket and its status. Only SOLD tickets are valid; tickets are not checked in. Malformed or forged tokens are rejected without a lookup. The number of tokens per batch is capped.
+//	@Tags			tickets
+//	@ID				api.verifyTicketsBatch
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		models.VerifyTicketsRequest			true	"Scanned ticket tokens"
+//	@Success		200		{object}	models.TicketVerificationsResponse	"Outcome per token, in the same order"
+//	@Failure		400		{object}	models.ErrorResponse				"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse				"Forbidden"
+//	@Failure		415		{object}	models.ErrorResponse				"Unsupported Media Type"
+//	@Failure		500		{object}	models.ErrorResponse				"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/tickets/verify-batch [post]
+func VerifyTicketsBatchHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isStaffOrAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to verify tickets.",
+			)
+			return
+		}
+
+		var payload models.VerifyTicketsRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		if len(payload.Tokens) == 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "No tokens provided.")
+			return
+		}
+		if len(payload.Tokens) > maxVerifyBatchTokens {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				fmt.Sprintf("At most %d tokens can be verified at once.", maxVerifyBatchTokens),
+			)
+			return
+		}
+
+		// signatures are checked upfront, only genuine tickets are looked up
+		results := make([]models.TicketVerificationResponse, len(payload.Tokens))
+		ticketIds := []string{}
+		for i, token := range payload.Tokens {
+			results[i].Token = token
+			ticketId, ok := verifyTicketToken(token, jwtSecret)
+			if !ok {
+				results[i].Reason = "Invalid token."
+				continue
+			}
+			results[i].TicketID = ticketId
+			ticketIds = append(ticketIds, ticketId)
+		}
+
+		type ticketState struct {
+			eventId int
+			status  string
+		}
+		states := map[string]ticketState{}
+		if len(ticketIds) > 0 {
+			query := `
+				SELECT t.id, r.event_id, ts.name
+				FROM tickets t
+				JOIN reservations r ON t.reservation_id = r.id
+				JOIN ticket_statuses ts ON t.status_id = ts.id
+				WHERE t.id = ANY($1::uuid[])
+			`
+			rows, err := pool.Query(r.Context(), query, ticketIds)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets.")
+				return
+			}
+			defer rows.Close()
+
+			for rows.Next() {
+				var id string
+				var state ticketState
+				if err := rows.Scan(&id, &state.eventId, &state.status); err != nil {
+					writeErrorResponse(
+						w,
+						http.StatusInternalServerError,
+						"Failed to parse tickets.",
+					)
+					return
+				}
+				states[id] = state
+			}
+			if err := rows.Err(); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets.")
+				return
+			}
+		}
+
+		for i := range results {
+			if results[i].TicketID == "" {
+				continue
+			}
+			state, ok := states[results[i].TicketID]
+			if !ok {
+				results[i].Reason = "Ticket not found."
+				continue
+			}
+			results[i].EventID = state.eventId
+			results[i].Status = state.status
+			switch state.status {
+			case "SOLD":
+				results[i].Valid = true
+			case "USED":
+				results[i].Reason = "Ticket has already been checked in."
+			default:
+				results[i].Reason = fmt.Sprintf("Ticket status is %s.", state.status)
+			}
+		}
+
+		writeJSONResponse(
+			w,
+			http.StatusOK,
+			models.TicketVerificationsResponse{Results: results},
+		)
+	}
+}
+
+// Sign the ticket ID, producing the token encoded in its QR code.
+func signTicketToken(ticketID, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("ticket:" + ticketID))
+	return ticketID + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify the ticket token, returning the ticket ID it was signed for.
+func verifyTicketToken(token, secret string) (string, bool) {
+	ticketID, _, found := strings.Cut(token, ".")
+	if !found {
+		return "", false
+	}
+	if _, err := uuid.Parse(ticketID); err != nil {
+		return "", false
+	}
+	expected := signTicketToken(ticketID, secret)
+	return ticketID, hmac.Equal([]byte(token), []byte(expected))
+}
+
+// GetTicketStatusesHandler lists all ticket statuses.
+//
+//	@Summary		List ticket statuses.
+//	@Description	Retrieve the statuses tickets can be in, for rendering filters and labels.
+//	@Tags			tickets
+//	@ID				api.getTicketStatuses
+//	@Produce		json
+//	@Success		200	{object}	models.StatusesResponse	"List of ticket statuses"
+//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Router			/ticket-statuses [get]
+func GetTicketStatusesHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rows, err := pool.Query(
+			r.Context(),
+			`SELECT id, name FROM ticket_statuses ORDER BY id ASC`,
+		)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch ticket statuses.")
+			return
+		}
+		defer rows.Close()
+
+		statuses := []models.StatusResponse{}
+		for rows.Next() {
+			var status models.StatusResponse
+			if err := rows.Scan(&status.ID, &status.Name); err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to parse ticket statuses.",
+				)
+				return
+			}
+			statuses = append(statuses, status)
+		}
+		writeJSONResponse(w, http.StatusOK, models.StatusesResponse{Statuses: statuses})
+	}
+}
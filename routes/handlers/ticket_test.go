@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateTicketStatusOfPastEvent(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	adminID, _ := createTestUser(t, pool, "ADMIN")
+	userID, _ := createTestUser(t, pool, "REGISTERED")
+	eventID := createPastTestEvent(t, pool, createTestLocation(t, pool))
+	reservationID := createTestReservation(t, pool, userID, eventID, "CONFIRMED")
+
+	var ticketID string
+	query := `SELECT id FROM tickets WHERE reservation_id = $1`
+	if err := pool.QueryRow(ctx, query, reservationID).Scan(&ticketID); err != nil {
+		t.Fatalf("failed to fetch the ticket: %v", err)
+	}
+	seats := func() (int, int) {
+		var available, total int
+		query := `
+			SELECT e.available_tickets, r.total_tickets
+			FROM events e, reservations r
+			WHERE e.id = $1 AND r.id = $2
+		`
+		if err := pool.QueryRow(ctx, query, eventID, reservationID).
+			Scan(&available, &total); err != nil {
+			t.Fatalf("failed to fetch the seats: %v", err)
+		}
+		return available, total
+	}
+
+	// cancelling returns the seat, restoring takes it again, past event or not
+	handler := UpdateTicketStatusHandler(pool)
+	before, _ := seats()
+	for _, step := range []struct {
+		status    string
+		available int
+		total     int
+	}{
+		{"CANCELLED", before + 1, 0},
+		{"SOLD", before, 1},
+	} {
+		body := fmt.Sprintf(`{"status": %q}`, step.status)
+		r := newTestRequest(http.MethodPatch, "/api/tickets/"+ticketID+"/status", body)
+		r = withVars(withUser(r, adminID, "ADMIN"), map[string]string{"id": ticketID})
+		rec := serve(handler, r)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status to %s = %d, want %d: %s",
+				step.status, rec.Code, http.StatusOK, rec.Body)
+		}
+		if available, total := seats(); available != step.available || total != step.total {
+			t.Fatalf("seats after %s = %d available, %d reserved, want %d, %d",
+				step.status, available, total, step.available, step.total)
+		}
+	}
+}
+
+func TestRestoringTicketOfCancelledReservation(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	adminID, _ := createTestUser(t, pool, "ADMIN")
+	userID, _ := createTestUser(t, pool, "REGISTERED")
+	eventID := createTestEvent(t, pool, createTestLocation(t, pool))
+	reservationID := createTestReservation(t, pool, userID, eventID, "CANCELLED")
+
+	var ticketID string
+	query := `
+		UPDATE tickets
+		SET status_id = (SELECT id FROM ticket_statuses WHERE name = 'CANCELLED')
+		WHERE reservation_id = $1
+		RETURNING id
+	`
+	if err := pool.QueryRow(ctx, query, reservationID).Scan(&ticketID); err != nil {
+		t.Fatalf("failed to cancel the ticket: %v", err)
+	}
+
+	r := newTestRequest(http.MethodPatch, "/api/tickets/"+ticketID+"/status", `{"status": "SOLD"}`)
+	r = withVars(withUser(r, adminID, "ADMIN"), map[string]string{"id": ticketID})
+	if rec := serve(UpdateTicketStatusHandler(pool), r); rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusConflict, rec.Body)
+	}
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// GetTicketTypePreviewHandler computes the price of a ticket type for an event.
+//
+//	@Summary		Preview the discount of a ticket type (admin only).
+//	@Description	Compute the price of a ticket type against the base price of an event, as the discounted base price, along with the savings. Per-event price overrides are not applied.
+//	@Tags			ticket-types
+//	@ID				api.getTicketTypePreview
+//	@Produce		json
+//	@Param			id			path		int								true	"Ticket type ID"
+//	@Param			event_id	query		int								true	"Event ID"
+//	@Success		200			{object}	models.TicketTypePreviewResponse	"Price preview"
+//	@Failure		400			{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403			{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404			{object}	models.ErrorResponse			"Not Found"
+//	@Failure		500			{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/ticket-types/{id}/preview [get]
+func GetTicketTypePreviewHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		typeId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		eventId, err := strconv.Atoi(r.URL.Query().Get("event_id"))
+		if err != nil || eventId <= 0 {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				"Invalid event_id; must be a positive integer.",
+			)
+			return
+		}
+
+		preview := models.TicketTypePreviewResponse{EventID: eventId, Currency: currency}
+		typeQuery := `SELECT id, name, discount FROM ticket_types WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), typeQuery, typeId).
+			Scan(&preview.TypeID, &preview.Type, &preview.Discount); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Ticket type not found.")
+				return
+			}
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the ticket type.",
+			)
+			return
+		}
+
+		// rounded the same way as the listed ticket prices
+		eventQuery := `
+			SELECT price, ROUND(price * (1 - $2), 2)
+			FROM events
+			WHERE id = $1
+		`
+		if err := pool.QueryRow(r.Context(), eventQuery, eventId, preview.Discount).
+			Scan(&preview.BasePrice, &preview.Price); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "Event not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the event.")
+			return
+		}
+		preview.Savings = preview.BasePrice - preview.Price
+
+		writeJSONResponse(w, http.StatusOK, preview)
+	}
+}
+
+// GetTicketTypesStatsHandler reports sales grouped by ticket type.
+//
+//	@Summary		Get sales statistics per ticket type (admin only).
+//	@Description	Retrieve each ticket type with the number of sold (including used) tickets, their revenue and average price. Types without sales are reported with zeros.
+//	@Tags			ticket-types
+//	@ID				api.getTicketTypesStats
+//	@Produce		json
+//	@Param			from		query		string							false	"Only reservations created at or after (YYYY-MM-DD HH:MM or RFC3339)"
+//	@Param			to			query		string							false	"Only reservations created before (YYYY-MM-DD HH:MM or RFC3339)"
+//	@Param			event_id	query		int								false	"Only tickets of the event"
+//	@Success		200			{object}	models.TicketTypesStatsResponse	"Statistics per ticket type"
+//	@Failure		400			{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403			{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		500			{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/ticket-types/stats [get]
+func GetTicketTypesStatsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		// optional date range, applied to the reservation creation time
+		conditions, args, err := appendDateRange(r, "r.created_at", nil, nil)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if param := r.URL.Query().Get("event_id"); param != "" {
+			eventId, err := strconv.Atoi(param)
+			if err != nil || eventId <= 0 {
+				writeErrorResponse(
+					w,
+					http.StatusBadRequest,
+					"Invalid event_id; must be a positive integer.",
+				)
+				return
+			}
+			args = append(args, eventId)
+			conditions = append(conditions, fmt.Sprintf("r.event_id = $%d", len(args)))
+		}
+		reservationFilter := ""
+		if len(conditions) > 0 {
+			reservationFilter = " AND " + strings.Join(conditions, " AND ")
+		}
+
+		// the left join keeps the types without any sales
+		query := `
+			SELECT
+				tt.id, tt.name,
+				COUNT(t.id),
+				COALESCE(SUM(t.price), 0),
+				COALESCE(AVG(t.price), 0)
+			FROM ticket_types tt
+			LEFT JOIN (
+				tickets t
+				JOIN reservations r ON t.reservation_id = r.id` + reservationFilter + `
+			) ON t.type_id = tt.id
+				AND t.status_id IN (SELECT id FROM ticket_statuses WHERE name IN ('SOLD', 'USED'))
+			GROUP BY tt.id
+			ORDER BY tt.id ASC
+		`
+		rows, err := pool.Query(r.Context(), query, args...)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch statistics.")
+			return
+		}
+		defer rows.Close()
+
+		stats := []models.TicketTypeStatsResponse{}
+		for rows.Next() {
+			var stat models.TicketTypeStatsResponse
+			if err := rows.Scan(
+				&stat.TypeID,
+				&stat.Type,
+				&stat.TicketsSold,
+				&stat.Revenue,
+				&stat.AveragePrice,
+			); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse statistics.")
+				return
+			}
+			stats = append(stats, stat)
+		}
+		writeJSONResponse(
+			w,
+			http.StatusOK,
+			models.TicketTypesStatsResponse{Currency: currency, Types: stats},
+		)
+	}
+}
@@ -0,0 +1,516 @@
+package handlers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/middlewares"
+	"event-reservation-api/models"
+)
+
+const (
+	// Issuer shown by authenticator apps next to the account.
+	totpIssuer = "Event Reservation API"
+
+	// Seconds a single TOTP code is valid for.
+	totpPeriod = 30
+
+	// Steps a code may lag behind or run ahead, to tolerate clock drift.
+	totpSkew = 1
+
+	// Minutes a login challenge can be answered with a code.
+	twoFactorChallengeMinutes = 5
+
+	// Wrong codes in a row, after which the second factor of the user is locked.
+	twoFactorMaxAttempts = 5
+
+	// Minutes the second factor stays locked after too many wrong codes.
+	twoFactorLockoutMinutes = 15
+)
+
+// EnrollTwoFactorHandler starts the enrollment of TOTP two-factor authentication.
+//
+//	@Summary		Enroll in two-factor authentication (admin only).
+//	@Description	Generate a new TOTP secret for the current user, returned along with an otpauth URI to be rendered as a QR code for authenticator apps. Two-factor authentication is enabled only once a code is confirmed; enrolling again replaces a secret not confirmed yet.
+//	@ID				api.enrollTwoFactor
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	models.TwoFactorEnrollResponse	"Secret to add to an authenticator app"
+//	@Failure		403	{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		409	{object}	models.ErrorResponse			"Conflict"
+//	@Failure		500	{object}	models.ErrorResponse			"Internal Server Error"
+//	@Failure		503	{object}	models.ErrorResponse			"Service Unavailable"
+//	@Security		BearerAuth
+//	@Router			/auth/2fa/enroll [post]
+func EnrollTwoFactorHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !twoFactorConfigured(w) {
+			return
+		}
+
+		// the second factor belongs to the admin alone, not to those acting as them
+		if !isAdmin(r) || isImpersonated(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Two-factor authentication is available to admins only.",
+			)
+			return
+		}
+
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		var username string
+		query := `SELECT username FROM users WHERE id = $1`
+		if err := pool.QueryRow(r.Context(), query, userId).Scan(&username); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
+			return
+		}
+
+		raw := make([]byte, 20)
+		if _, err := rand.Read(raw); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to generate the secret.")
+			return
+		}
+		secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw)
+
+		encrypted, err := encryptTOTPSecret(secret)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to store the secret.")
+			return
+		}
+
+		// an enabled second factor is never replaced silently
+		query = `
+			INSERT INTO user_totp (user_id, secret)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE
+			SET secret = EXCLUDED.secret, created_at = CURRENT_TIMESTAMP, last_used_step = NULL
+			WHERE NOT user_totp.enabled
+		`
+		tag, err := pool.Exec(r.Context(), query, userId, encrypted)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to store the secret.")
+			return
+		}
+		if tag.RowsAffected() == 0 {
+			writeErrorResponse(
+				w,
+				http.StatusConflict,
+				"Two-factor authentication is already enabled.",
+			)
+			return
+		}
+
+		label := url.PathEscape(totpIssuer + ":" + username)
+		params := url.Values{}
+		params.Set("secret", secret)
+		params.Set("issuer", totpIssuer)
+		params.Set("period", strconv.Itoa(totpPeriod))
+		writeJSONResponse(w, http.StatusOK, models.TwoFactorEnrollResponse{
+			Secret:     secret,
+			OTPAuthURL: "otpauth://totp/" + label + "?" + params.Encode(),
+		})
+	}
+}
+
+// ConfirmTwoFactorHandler enables two-factor authentication after enrollment.
+//
+//	@Summary		Confirm two-factor enrollment (admin only).
+//	@Description	Verify a code generated from the enrolled secret, enabling two-factor authentication. From then on, logging in requires a code as well.
+//	@ID				api.confirmTwoFactor
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		models.TwoFactorCodeRequest	true	"Code from the authenticator app"
+//	@Success		200		{object}	models.SuccessResponse		"Two-factor authentication enabled"
+//	@Failure		400		{object}	models.ErrorResponse		"Bad Request"
+//	@Failure		401		{object}	models.ErrorResponse		"Unauthorized"
+//	@Failure		403		{object}	models.ErrorResponse		"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse		"Not Found"
+//	@Failure		409		{object}	models.ErrorResponse		"Conflict"
+//	@Failure		415		{object}	models.ErrorResponse		"Unsupported Media Type"
+//	@Failure		500		{object}	models.ErrorResponse		"Internal Server Error"
+//	@Failure		503		{object}	models.ErrorResponse		"Service Unavailable"
+//	@Security		BearerAuth
+//	@Router			/auth/2fa/enroll/verify [post]
+func ConfirmTwoFactorHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !twoFactorConfigured(w) {
+			return
+		}
+
+		if !isAdmin(r) || isImpersonated(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Two-factor authentication is available to admins only.",
+			)
+			return
+		}
+
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		var payload models.TwoFactorCodeRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		var encrypted string
+		var enabled bool
+		query := `SELECT secret, enabled FROM user_totp WHERE user_id = $1`
+		if err := pool.QueryRow(r.Context(), query, userId).Scan(&encrypted, &enabled); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(
+					w,
+					http.StatusNotFound,
+					"Two-factor authentication is not enrolled.",
+				)
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the secret.")
+			return
+		}
+		if enabled {
+			writeErrorResponse(
+				w,
+				http.StatusConflict,
+				"Two-factor authentication is already enabled.",
+			)
+			return
+		}
+
+		step, status, err := checkTOTPCode(encrypted, payload.Code)
+		if err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		query = `
+			UPDATE user_totp
+			SET enabled = TRUE, last_used_step = $2
+			WHERE user_id = $1 AND NOT enabled
+		`
+		if _, err := pool.Exec(r.Context(), query, userId, step); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to enable two-factor authentication.",
+			)
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, models.SuccessResponse{
+			Message: "Two-factor authentication enabled successfully.",
+		})
+	}
+}
+
+// VerifyTwoFactorHandler completes a login challenged for a second factor.
+//
+//	@Summary		Complete a login with a two-factor code.
+//	@Description	Answer the challenge returned by the login with a code from the authenticator app, getting a JWT token. Each code is accepted once, and the challenge expires after a few minutes. After several invalid codes in a row, the second factor is locked for a while.
+//	@ID				api.verifyTwoFactor
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		models.TwoFactorVerifyRequest	true	"Challenge and code"
+//	@Success		200		{object}	models.LoginResponse			"Successfully logged in"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		401		{object}	models.ErrorResponse			"Unauthorized"
+//	@Failure		415		{object}	models.ErrorResponse			"Unsupported Media Type"
+//	@Failure		429		{object}	models.ErrorResponse			"Too Many Requests"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Failure		503		{object}	models.ErrorResponse			"Service Unavailable"
+//	@Router			/auth/2fa/verify [post]
+func VerifyTwoFactorHandler(pool *pgxpool.Pool, jwtSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !twoFactorConfigured(w) {
+			return
+		}
+
+		var payload models.TwoFactorVerifyRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		userID, ok := verifyTwoFactorChallenge(payload.Challenge, jwtSecret, time.Now())
+		if !ok {
+			writeErrorResponse(w, http.StatusUnauthorized, "Invalid or expired challenge.")
+			return
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		// the row is locked, so concurrent guesses are counted one by one
+		var username, role, encrypted string
+		var failedAttempts int
+		var lockedUntil *time.Time
+		query := `
+			SELECT u.username, r.name, t.secret, t.failed_attempts, t.locked_until
+			FROM users u
+			JOIN roles r ON u.role_id = r.id
+			JOIN user_totp t ON t.user_id = u.id
+			WHERE u.id = $1 AND t.enabled
+			FOR UPDATE OF t
+		`
+		if err := tx.QueryRow(r.Context(), query, userID).Scan(
+			&username, &role, &encrypted, &failedAttempts, &lockedUntil,
+		); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusUnauthorized, "Invalid or expired challenge.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
+			return
+		}
+
+		now := time.Now()
+		if lockedUntil != nil && now.Before(*lockedUntil) {
+			writeErrorResponse(
+				w,
+				http.StatusTooManyRequests,
+				"Too many invalid codes; try again later.",
+			)
+			return
+		}
+
+		step, status, err := checkTOTPCode(encrypted, payload.Code)
+		if err != nil {
+			if status == http.StatusUnauthorized {
+				// a few guesses are allowed, not enough to go through the codes
+				failedAttempts++
+				lockedUntil = nil
+				if failedAttempts >= twoFactorMaxAttempts {
+					until := now.Add(twoFactorLockoutMinutes * time.Minute)
+					lockedUntil = &until
+					failedAttempts = 0
+				}
+				query = `
+					UPDATE user_totp
+					SET failed_attempts = $2, locked_until = $3
+					WHERE user_id = $1
+				`
+				if _, err := tx.Exec(
+					r.Context(), query, userID, failedAttempts, lockedUntil,
+				); err != nil {
+					writeErrorResponse(
+						w,
+						http.StatusInternalServerError,
+						"Failed to verify the code.",
+					)
+					return
+				}
+				if err := tx.Commit(r.Context()); err != nil {
+					writeErrorResponse(
+						w,
+						http.StatusInternalServerError,
+						"Failed to verify the code.",
+					)
+					return
+				}
+			}
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		// a code is spent once used, so an observed one cannot be replayed
+		query = `
+			UPDATE user_totp
+			SET last_used_step = $2, failed_attempts = 0, locked_until = NULL
+			WHERE user_id = $1 AND (last_used_step IS NULL OR last_used_step < $2)
+		`
+		tag, err := tx.Exec(r.Context(), query, userID, step)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to verify the code.")
+			return
+		}
+		if tag.RowsAffected() == 0 {
+			writeErrorResponse(w, http.StatusUnauthorized, "Code has already been used.")
+			return
+		}
+		if err := tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to verify the code.")
+			return
+		}
+
+		tokenString, exp, err := middlewares.GenerateJWT(userID, role, jwtSecret)
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to generate access token.",
+			)
+			return
+		}
+
+		if err := createSession(r, pool, userID, tokenString, exp); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to create the session.")
+			return
+		}
+
+		writeTokenResponse(w, tokenString, exp, userID, username)
+	}
+}
+
+// Check if the encryption key of TOTP secrets is configured, responding with
+// 503 otherwise.
+func twoFactorConfigured(w http.ResponseWriter) bool {
+	if len(totpEncryptionKey) == 0 {
+		writeErrorResponse(
+			w,
+			http.StatusServiceUnavailable,
+			"Two-factor authentication is not configured.",
+		)
+		return false
+	}
+	return true
+}
+
+// Check the code against the encrypted secret, returning the time step it
+// was generated for, along with the status to respond with if it is invalid.
+func checkTOTPCode(encrypted, code string) (int64, int, error) {
+	code = strings.TrimSpace(code)
+	if len(code) != 6 {
+		return 0, http.StatusBadRequest, fmt.Errorf("Invalid code; must be 6 digits.")
+	}
+
+	secret, err := decryptTOTPSecret(encrypted)
+	if err != nil {
+		return 0, http.StatusInternalServerError, fmt.Errorf("Failed to read the secret.")
+	}
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return 0, http.StatusInternalServerError, fmt.Errorf("Failed to read the secret.")
+	}
+
+	step, ok := validateTOTP(key, code, time.Now())
+	if !ok {
+		return 0, http.StatusUnauthorized, fmt.Errorf("Invalid two-factor code.")
+	}
+	return step, 0, nil
+}
+
+// Generate the TOTP code of the key for the time step (RFC 6238, SHA-1, 6 digits).
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+// Validate the code against the steps around the current one, returning the
+// step it matched.
+func validateTOTP(key []byte, code string, now time.Time) (int64, bool) {
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if hmac.Equal([]byte(totpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// Encrypt the TOTP secret with AES-GCM, prefixing the nonce.
+func encryptTOTPSecret(secret string) (string, error) {
+	block, err := aes.NewCipher(totpEncryptionKey)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt the TOTP secret encrypted by encryptTOTPSecret.
+func decryptTOTPSecret(encrypted string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(totpEncryptionKey)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("encrypted secret is too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	secret, err := gcm.Open(nil, nonce, ciphertext, nil)
+	return string(secret), err
+}
+
+// Sign the user ID along with the expiry, producing a login challenge.
+func signTwoFactorChallenge(userID string, expiresAt time.Time, secret string) string {
+	payload := userID + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("2fa:" + payload))
+	return payload + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify the login challenge, returning the user ID it was signed for,
+// provided the challenge has not expired yet.
+func verifyTwoFactorChallenge(challenge, secret string, now time.Time) (string, bool) {
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+	expiresUnix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", false
+	}
+	expiresAt := time.Unix(expiresUnix, 0)
+	expected := signTwoFactorChallenge(parts[0], expiresAt, secret)
+	if !hmac.Equal([]byte(challenge), []byte(expected)) || !now.Before(expiresAt) {
+		return "", false
+	}
+	return parts[0], true
+}
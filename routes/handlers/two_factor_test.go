@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+)
+
+// SHA-1 key of the RFC 6238 test vectors.
+var rfc6238Key = []byte("12345678901234567890")
+
+// Test vectors of RFC 6238 (appendix B), truncated to the 6 digits used here.
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestTOTPCode(t *testing.T) {
+	for _, v := range rfc6238Vectors {
+		if got := totpCode(rfc6238Key, v.unix/totpPeriod); got != v.code {
+			t.Errorf("totpCode(T=%d) = %s, want %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	for _, v := range rfc6238Vectors {
+		now := time.Unix(v.unix, 0)
+		step, ok := validateTOTP(rfc6238Key, v.code, now)
+		if !ok || step != v.unix/totpPeriod {
+			t.Errorf("validateTOTP(T=%d) = %d, %t, want %d, true", v.unix, step, ok, v.unix/totpPeriod)
+		}
+
+		// codes of the neighbouring steps are tolerated, as clocks drift
+		drifted := now.Add(totpSkew * totpPeriod * time.Second)
+		if _, ok := validateTOTP(rfc6238Key, v.code, drifted); !ok {
+			t.Errorf("validateTOTP(T=%d) rejected a code of the previous step", v.unix)
+		}
+
+		// but not those further away
+		late := now.Add((totpSkew + 1) * totpPeriod * time.Second)
+		if _, ok := validateTOTP(rfc6238Key, v.code, late); ok {
+			t.Errorf("validateTOTP(T=%d) accepted an outdated code", v.unix)
+		}
+	}
+}
+
+func TestTOTPSecretEncryption(t *testing.T) {
+	previous := totpEncryptionKey
+	defer func() { totpEncryptionKey = previous }()
+
+	sum := sha256.Sum256([]byte("totp-key"))
+	totpEncryptionKey = sum[:]
+
+	encrypted, err := encryptTOTPSecret("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	if err != nil {
+		t.Fatalf("encryptTOTPSecret() error = %v", err)
+	}
+	secret, err := decryptTOTPSecret(encrypted)
+	if err != nil || secret != "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" {
+		t.Fatalf("decryptTOTPSecret() = %q, %v", secret, err)
+	}
+
+	// a different key cannot read the secret
+	sum = sha256.Sum256([]byte("other-key"))
+	totpEncryptionKey = sum[:]
+	if _, err := decryptTOTPSecret(encrypted); err == nil {
+		t.Fatal("decryptTOTPSecret() succeeded with a different key")
+	}
+}
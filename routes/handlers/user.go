@@ -1,39 +1,51 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 
+	"event-reservation-api/middlewares"
 	"event-reservation-api/models"
 )
 
 // GetUserHandler lists all users.
 //
-//	@Summary		List all users (admin only).
-//	@Description	Retrieve a list of all users, including their details and roles.
+//	@Summary		List all users (admin/staff only).
+//	@Description	Retrieve a list of all users, including their details and roles. Staff get the reduced view (models.UserSummariesResponse), without email and last login.
 //	@Tags			users
 //	@ID				api.getUsers
 //	@Produce		json
-//	@Success		200	{object}	models.UsersResponse	"List of users"
-//	@Failure		403	{object}	models.ErrorResponse	"Forbidden"
-//	@Failure		404	{object}	models.ErrorResponse	"Not Found"
-//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Param			limit	query		int						false	"Maximum number of users"
+//	@Param			offset	query		int						false	"Number of users to skip"
+//	@Success		200		{object}	models.UsersResponse	"List of users"
+//	@Failure		400		{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse	"Forbidden"
+//	@Failure		500		{object}	models.ErrorResponse	"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/users [get]
 func GetUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// in order to see users, one must be an admin
-		if !isAdmin(r) {
+		// in order to see users, one must be an admin or staff
+		if !isStaffOrAdmin(r) {
 			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
 			return
 		}
 
+		limit, offset, err := parsePagination(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		// fetch users and role names
 		query := `
 			SELECT u.id, u.name, u.surname, u.username, u.email,
@@ -42,13 +54,10 @@ func GetUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			FROM users u
 			JOIN roles r ON u.role_id = r.id
 			ORDER BY u.id ASC
+			LIMIT $1 OFFSET $2
 		`
-		rows, err := pool.Query(r.Context(), query)
+		rows, err := pool.Query(r.Context(), query, limit, offset)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				writeErrorResponse(w, http.StatusNotFound, "No users in the database.")
-				return
-			}
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch users.")
 			return
 		}
@@ -69,15 +78,31 @@ func GetUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 
 			users = append(users, user)
 		}
+
+		page := listPage{Limit: limit, Offset: offset, FromClause: "FROM users"}
+
+		// personal data is for admins only
+		if !isAdmin(r) {
+			summaries := make([]models.UserSummaryResponse, 0, len(users))
+			for _, user := range users {
+				summaries = append(summaries, summarizeUser(user))
+			}
+			writePageResponse(
+				w, r, pool,
+				models.UserSummariesResponse{Users: summaries}, summaries, page,
+			)
+			return
+		}
+
 		users_response := models.UsersResponse{Users: users}
-		writeJSONResponse(w, http.StatusOK, users_response)
+		writePageResponse(w, r, pool, users_response, users, page)
 	}
 }
 
 // GetUserByIDHandler returns a single user by ID.
 //
-//	@Summary		Get a user by ID (admin only).
-//	@Description	Retrieve a user, including its details and roles.
+//	@Summary		Get a user by ID (admin/staff only).
+//	@Description	Retrieve a user, including its details and roles. Staff get the reduced view (models.UserSummaryResponse), without email and last login.
 //	@Tags			users
 //	@ID				api.getUsersByID
 //	@Produce		json
@@ -90,34 +115,20 @@ func GetUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 //	@Router			/users/{id} [get]
 func GetUserByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if !isAdmin(r) {
+		if !isStaffOrAdmin(r) {
 			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
 			return
 		}
 
-		userId, err := parseUserIdFromURL(r)
+		userId, err := parsePathID(r, "id")
 		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, "User ID not provided in the URL.")
 			return
 		}
 
 		// find user and its details with the given ID
-		query := `
-			SELECT
-				u.id, u.name, u.surname, u.username, u.email,
-				u.last_login, u.created_at, u.is_active,
-				r.name
-			FROM users u
-			JOIN roles r ON u.role_id = r.id
-			WHERE u.id = $1
-		`
-		user := models.UserResponse{}
-		row := pool.QueryRow(r.Context(), query, userId)
-		if err := row.Scan(
-			&user.ID, &user.Name, &user.Surname, &user.Username, &user.Email,
-			&user.LastLogin, &user.CreatedAt,
-			&user.IsActive, &user.RoleName,
-		); err != nil {
+		user, err := fetchUser(r.Context(), pool, userId)
+		if err != nil {
 			if err == pgx.ErrNoRows {
 				writeErrorResponse(w, http.StatusNotFound, "User not found.")
 				return
@@ -127,11 +138,119 @@ func GetUserByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
+		// personal data is for admins only
+		if !isAdmin(r) {
+			writeJSONResponse(w, http.StatusOK, summarizeUser(user))
+			return
+		}
+
 		// return the user
 		writeJSONResponse(w, http.StatusOK, user)
 	}
 }
 
+// GetUserByUsernameHandler returns a single user by username.
+//
+//	@Summary		Get a user by username (admin only).
+//	@Description	Retrieve a user, including its details and roles, by the username. Usernames are matched exactly, as on login.
+//	@Tags			users
+//	@ID				api.getUserByUsername
+//	@Produce		json
+//	@Param			username	path		string					true	"Username"
+//	@Success		200			{object}	models.UserResponse		"User details"
+//	@Failure		400			{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		403			{object}	models.ErrorResponse	"Forbidden"
+//	@Failure		404			{object}	models.ErrorResponse	"Not Found"
+//	@Failure		500			{object}	models.ErrorResponse	"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/users/by-username/{username} [get]
+func GetUserByUsernameHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		username, err := parsePathID(r, "username")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// usernames are case-sensitive, both on login and in the unique constraint
+		user, err := fetchUserBy(r.Context(), pool, "u.username = $1", username)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "User not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse user data.")
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, user)
+	}
+}
+
+// ExportCurrentUserDataHandler returns all data stored about the current user.
+//
+//	@Summary		Export data of the currently logged in user.
+//	@Description	Download a single JSON document with the profile, reservations and tickets of the current user.
+//	@Tags			users
+//	@ID				api.exportCurrentUserData
+//	@Produce		json
+//	@Success		200	{object}	models.UserExportResponse	"User data export"
+//	@Failure		404	{object}	models.ErrorResponse		"Not Found"
+//	@Failure		500	{object}	models.ErrorResponse		"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/users/me/export [get]
+func ExportCurrentUserDataHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// the export is always about the owner of the token
+		userId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to fetch the user identifier.",
+			)
+			return
+		}
+
+		user, err := fetchUser(r.Context(), pool, userId)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "User not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse user data.")
+			return
+		}
+
+		reservations, err := fetchUserReservations(r.Context(), pool, userId, "all", true)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch reservations.")
+			return
+		}
+
+		tickets, err := fetchUserTickets(r.Context(), pool, userId)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch tickets.")
+			return
+		}
+
+		// serve as a downloadable file
+		filename := fmt.Sprintf("user-%s-export.json", userId)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		writeJSONResponse(w, http.StatusOK, models.UserExportResponse{
+			ExportedAt:   time.Now().UTC(),
+			User:         user,
+			Reservations: reservations,
+			Tickets:      tickets,
+		})
+	}
+}
+
 // CreateUserHandler creates a single user in the database.
 //
 //	@Summary		Create a new user (open to create a user with registered role).
@@ -142,6 +261,8 @@ func GetUserByIDHandler(pool *pgxpool.Pool) http.HandlerFunc {
 //	@Success		200	{object}	models.SuccessResponseCreateUUID	"User details"
 //	@Failure		403	{object}	models.ErrorResponse				"Forbidden"
 //	@Failure		404	{object}	models.ErrorResponse				"Not Found"
+//	@Failure		409	{object}	models.ErrorResponse				"Conflict"
+//	@Failure		415	{object}	models.ErrorResponse				"Unsupported Media Type"
 //	@Failure		500	{object}	models.ErrorResponse				"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/users [put]
@@ -149,17 +270,25 @@ func CreateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		isAdmin := isAdmin(r)
 
+		// invite-only deployments leave user creation to admins
+		if !isAdmin && !registrationOpen {
+			writeErrorResponse(w, http.StatusForbidden, "Registration is closed.")
+			return
+		}
+
 		// parse the json request
 		user := models.CreateUserRequest{}
-		if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON input.")
+		if status, err := decodeJSONBody(r, &user); err != nil {
+			writeErrorResponse(w, status, err.Error())
 			return
 		}
 
 		// check if permissions are sufficient and mandatory fields present
+		user.RoleName = normalizeRoleName(user.RoleName)
 		status, err := validateCreateUserPayload(isAdmin, user)
 		if status != 200 && err != nil {
 			writeErrorResponse(w, status, err.Error())
+			return
 		}
 
 		// check if the username is unique
@@ -180,6 +309,7 @@ func CreateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		roleId, err := fetchRoleId(r.Context(), pool, user.RoleName)
 		if err != nil {
 			writeErrorResponse(w, roleId, err.Error())
+			return
 		}
 
 		// insert a new user
@@ -200,6 +330,19 @@ func CreateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			passwordHash,
 			roleId,
 		).Scan(&userId); err != nil {
+			// the unique constraint settles concurrent registrations the pre-check let through
+			if constraint, ok := uniqueViolation(err); ok {
+				if constraint == "users_email_key" {
+					writeErrorResponse(w, http.StatusConflict, "Email is already registered.")
+					return
+				}
+				writeErrorResponse(
+					w,
+					http.StatusConflict,
+					fmt.Sprintf("Username '%s' is already taken.", user.Username),
+				)
+				return
+			}
 			writeErrorResponse(w, http.StatusInternalServerError, "Failed to create the user.")
 			return
 		}
@@ -215,22 +358,123 @@ func CreateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	}
 }
 
+// Most users accepted by a single bulk import, as every password is hashed
+// within the request.
+const maxBulkUsers = 100
+
+// BulkCreateUsersHandler creates multiple users in a single request.
+//
+//	@Summary		Import users in bulk (admin only).
+//	@Description	Create users from an array of payloads, validating each one separately. Returns the ID of every created user, or the reason it was rejected, by its index in the payload. With atomic set, a single failure rolls back the whole import.
+//	@Tags			users
+//	@ID				api.bulkCreateUsers
+//	@Accept			json
+//	@Produce		json
+//	@Param			atomic	query		bool							false	"Roll back the whole import if any user fails"
+//	@Param			users	body		[]models.CreateUserRequest		true	"Users to create"
+//	@Success		200		{object}	models.BulkCreateUsersResponse	"Result of every user"
+//	@Failure		400		{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		415		{object}	models.ErrorResponse			"Unsupported Media Type"
+//	@Failure		422		{object}	models.BulkCreateUsersResponse	"Atomic import rolled back"
+//	@Failure		500		{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/users/bulk [put]
+func BulkCreateUsersHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		isAdmin := isAdmin(r)
+		if !isAdmin {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		atomic := false
+		if param := r.URL.Query().Get("atomic"); param != "" {
+			value, err := strconv.ParseBool(param)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "Invalid value for atomic.")
+				return
+			}
+			atomic = value
+		}
+
+		users := []models.CreateUserRequest{}
+		if status, err := decodeJSONBody(r, &users); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		if len(users) == 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "No users provided.")
+			return
+		}
+		if len(users) > maxBulkUsers {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				fmt.Sprintf("At most %d users can be imported at once.", maxBulkUsers),
+			)
+			return
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		response := models.BulkCreateUsersResponse{
+			Results: make([]models.BulkUserResult, 0, len(users)),
+		}
+		for i, user := range users {
+			result := models.BulkUserResult{Index: i}
+			userId, err := createBulkUser(r.Context(), tx, pool, isAdmin, user)
+			if err != nil {
+				result.Error = err.Error()
+				response.Failed++
+			} else {
+				result.UUID = userId
+				response.Created++
+			}
+			response.Results = append(response.Results, result)
+		}
+
+		// nothing was stored, so the created IDs are void
+		if atomic && response.Failed > 0 {
+			for i := range response.Results {
+				response.Results[i].UUID = ""
+			}
+			response.Created = 0
+			writeJSONResponse(w, http.StatusUnprocessableEntity, response)
+			return
+		}
+
+		if err := tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to commit transaction.")
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
 // UpdateUserHandler updates a single user.
 //
 //	@Summary		Update user.
-//	@Description	Update user details (only owner/admin).
+//	@Description	Update user details (only owner/admin). The role is changed with POST /users/{id}/role, so the change is recorded in the role history.
 //	@Tags			users
 //	@ID				api.updateUser
 //	@Param			id	path		string					true	"User ID"
 //	@Success		200	{object}	models.SuccessResponse	"User updated successfully"
+//	@Failure		400	{object}	models.ErrorResponse	"Bad Request"
 //	@Failure		403	{object}	models.ErrorResponse	"Forbidden"
 //	@Failure		404	{object}	models.ErrorResponse	"Not Found"
+//	@Failure		415	{object}	models.ErrorResponse	"Unsupported Media Type"
 //	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/users/{id} [put]
 func UpdateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userId, err := parseUserIdFromURL(r)
+		userId, err := parsePathID(r, "id")
 		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, "User ID not provided in the URL.")
 			return
@@ -247,8 +491,18 @@ func UpdateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 
 		// decode the body and parse the request
 		req := models.UpdateUserRequest{}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload.")
+		if status, err := decodeJSONBody(r, &req); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		// role changes are recorded in the role history, so they have their own endpoint
+		if req.RoleName != nil {
+			writeErrorResponse(
+				w,
+				http.StatusBadRequest,
+				"Role cannot be changed here; use POST /users/{id}/role instead.",
+			)
 			return
 		}
 
@@ -263,6 +517,10 @@ func UpdateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 
 		// based on the present parameters, build update query
 		if req.Username != nil {
+			if !isAdmin(r) && isReservedUsername(*req.Username) {
+				writeErrorResponse(w, http.StatusBadRequest, "Username is reserved.")
+				return
+			}
 			if status, err := isDuplicateExcept(r.Context(), pool, *req.Username, userId); err != nil {
 				writeErrorResponse(w, status, err.Error())
 				return
@@ -294,7 +552,11 @@ func UpdateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			hashedStr := string(hashed)
 			req.Password = &hashedStr
 
-			query += fmt.Sprintf("password_hash = $%d, ", idx)
+			// calendar feed tokens issued before are revoked along with the password
+			query += fmt.Sprintf(
+				"password_hash = $%d, calendar_feed_version = calendar_feed_version + 1, ",
+				idx,
+			)
 			args = append(args, *req.Password)
 			idx++
 		}
@@ -303,17 +565,6 @@ func UpdateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			args = append(args, *req.Email)
 			idx++
 		}
-		if req.RoleName != nil {
-			roleId, err := fetchRoleId(r.Context(), pool, *req.RoleName)
-			if err != nil {
-				writeErrorResponse(w, roleId, err.Error())
-				return
-			}
-
-			query += fmt.Sprintf("role_id = $%d, ", idx)
-			args = append(args, roleId)
-			idx++
-		}
 		if req.IsActive != nil {
 			query += fmt.Sprintf("is_active = $%d, ", idx)
 			args = append(args, *req.IsActive)
@@ -342,21 +593,333 @@ func UpdateUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	}
 }
 
+// ChangeUserRoleHandler changes the role of a single user.
+//
+//	@Summary		Change the role of a user (admin only).
+//	@Description	Change the role of the user and record the change in the role history. With revoke_sessions=true active sessions of the user are revoked, so the new permissions take effect immediately.
+//	@Tags			users
+//	@ID				api.changeUserRole
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string							true	"User ID"
+//	@Param			revoke_sessions	query		bool							false	"Revoke active sessions of the user"
+//	@Param			role			body		models.ChangeUserRoleRequest	true	"New role"
+//	@Success		200				{object}	models.UserResponse				"Updated user"
+//	@Failure		400				{object}	models.ErrorResponse			"Bad Request"
+//	@Failure		403				{object}	models.ErrorResponse			"Forbidden"
+//	@Failure		404				{object}	models.ErrorResponse			"Not Found"
+//	@Failure		409				{object}	models.ErrorResponse			"Conflict"
+//	@Failure		415				{object}	models.ErrorResponse			"Unsupported Media Type"
+//	@Failure		500				{object}	models.ErrorResponse			"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/users/{id}/role [post]
+func ChangeUserRoleHandler(
+	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "User ID not provided in the URL.")
+			return
+		}
+
+		if !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to change the role.",
+			)
+			return
+		}
+
+		revokeSessions := false
+		if param := r.URL.Query().Get("revoke_sessions"); param != "" {
+			revokeSessions, err = strconv.ParseBool(param)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "Invalid value for revoke_sessions.")
+				return
+			}
+		}
+
+		req := models.ChangeUserRoleRequest{}
+		if status, err := decodeJSONBody(r, &req); err != nil {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+		req.RoleName = normalizeRoleName(req.RoleName)
+		if req.RoleName == "" {
+			writeErrorResponse(w, http.StatusBadRequest, "Role name is required.")
+			return
+		}
+
+		var roleId int
+		query := "SELECT id FROM roles WHERE name = $1"
+		if err := pool.QueryRow(
+			r.Context(), query, req.RoleName,
+		).Scan(&roleId); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusBadRequest, "Role does not exist.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch role ID.")
+			return
+		}
+
+		// under impersonation the admin behind the token is recorded
+		adminId, err := getActingUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		// lock the user, so concurrent changes are recorded in order
+		var oldRoleId int
+		query = "SELECT role_id FROM users WHERE id = $1 FOR UPDATE"
+		if err := tx.QueryRow(r.Context(), query, userId).Scan(&oldRoleId); err != nil {
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusNotFound, "User not found.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
+			return
+		}
+		if oldRoleId == roleId {
+			writeErrorResponse(w, http.StatusConflict, "User already has the selected role.")
+			return
+		}
+
+		query = "UPDATE users SET role_id = $1 WHERE id = $2"
+		if _, err := tx.Exec(r.Context(), query, roleId, userId); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to update user.")
+			return
+		}
+
+		query = `
+			INSERT INTO role_change_history (user_id, changed_by, old_role_id, new_role_id)
+			VALUES ($1, $2, $3, $4)
+		`
+		if _, err := tx.Exec(
+			r.Context(), query, userId, adminId, oldRoleId, roleId,
+		); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to record the role change.")
+			return
+		}
+
+		if err := tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to commit transaction.")
+			return
+		}
+
+		// tokens carry the role, so they have to go for the change to apply at once
+		if revokeSessions {
+			if err := revokeUserSessions(r.Context(), pool, blacklist, userId); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+
+		user, err := fetchUser(r.Context(), pool, userId)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to parse user data.")
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, user)
+	}
+}
+
+// CancelUserReservationsHandler cancels every active reservation of a user.
+//
+//	@Summary		Cancel all reservations of a user (admin only).
+//	@Description	Cancel all pending and confirmed reservations of the user in one transaction, e.g. when closing an account or handling a dispute. Their tickets are cancelled and the seats returned to the events; paid reservations are refunded in full.
+//	@Tags			users
+//	@ID				api.cancelUserReservations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string									true	"User ID"
+//	@Param			body	body		models.CancelUserReservationsRequest	false	"Reason of the refunds"
+//	@Success		200		{object}	models.CancelUserReservationsResponse	"Reservations cancelled"
+//	@Failure		400		{object}	models.ErrorResponse					"Bad Request"
+//	@Failure		403		{object}	models.ErrorResponse					"Forbidden"
+//	@Failure		404		{object}	models.ErrorResponse					"Not Found"
+//	@Failure		415		{object}	models.ErrorResponse					"Unsupported Media Type"
+//	@Failure		500		{object}	models.ErrorResponse					"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/users/{id}/cancel-reservations [post]
+func CancelUserReservationsHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(w, http.StatusForbidden, "Insufficient permissions.")
+			return
+		}
+
+		adminId, err := getUserIdFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+
+		userId, err := parsePathID(r, "id")
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "User ID not provided in the URL.")
+			return
+		}
+		if _, err := uuid.Parse(userId); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid user ID.")
+			return
+		}
+
+		// the body is optional, it only carries the reason of the refunds
+		var payload models.CancelUserReservationsRequest
+		if status, err := decodeJSONBody(r, &payload); err != nil && err != errEmptyBody {
+			writeErrorResponse(w, status, err.Error())
+			return
+		}
+
+		tx, err := pool.Begin(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		var exists bool
+		query := `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`
+		if err := tx.QueryRow(r.Context(), query, userId).Scan(&exists); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
+			return
+		}
+		if !exists {
+			writeErrorResponse(w, http.StatusNotFound, "User not found.")
+			return
+		}
+
+		// lock the reservations, so concurrent cancellations do not refund twice
+		query = `
+			SELECT r.id
+			FROM reservations r
+			JOIN reservation_statuses rs ON r.status_id = rs.id
+			WHERE r.user_id = $1 AND rs.name IN ('PENDING', 'CONFIRMED')
+			ORDER BY r.id
+			FOR UPDATE OF r
+		`
+		rows, err := tx.Query(r.Context(), query, userId)
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch reservations.")
+			return
+		}
+		reservationIds := []string{}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to parse reservations.",
+				)
+				return
+			}
+			reservationIds = append(reservationIds, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch reservations.")
+			return
+		}
+
+		response := models.CancelUserReservationsResponse{
+			Message: "Reservations cancelled successfully.",
+			Refunds: []models.RefundResponse{},
+		}
+		for _, reservationId := range reservationIds {
+			// paid amount has to be known before the sold tickets are cancelled
+			paid, err := fetchRefundableAmount(r.Context(), tx, reservationId)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			if err := updateReservationStatus(
+				r.Context(), tx, reservationId, "CANCELLED", "PENDING", "CONFIRMED",
+			); err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to cancel the reservation.",
+				)
+				return
+			}
+
+			if err := releaseReservationSeats(r.Context(), tx, reservationId); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			if err := updateTicketsStatus(r.Context(), tx, reservationId, "CANCELLED"); err != nil {
+				writeErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					"Failed to cancel the tickets.",
+				)
+				return
+			}
+
+			if paid > 0 {
+				refund, err := insertRefund(
+					r.Context(), tx, reservationId, paid, payload.RefundReason, adminId,
+				)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+					return
+				}
+				response.Refunds = append(response.Refunds, refund)
+			}
+			response.Cancelled++
+		}
+
+		if err = tx.Commit(r.Context()); err != nil {
+			writeErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				"Failed to commit the transaction.",
+			)
+			return
+		}
+
+		invalidateEventCaches()
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
 // DeleteUserHandler deletes specified user
 //
 //	@Summary		Delete user (admin/owner only).
-//	@Description	Deletes user from the database.
+//	@Description	Deletes user from the database. With anonymize=true (admin only) personal data is scrubbed instead, keeping the reservations intact, and the sessions of the user are revoked.
 //	@Tags			users
-//	@Param			id	path		string					true	"User ID"
-//	@Success		200	{object}	models.SuccessResponse	"User details"
-//	@Failure		403	{object}	models.ErrorResponse	"Forbidden"
-//	@Failure		404	{object}	models.ErrorResponse	"Not Found"
-//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Param			id			path		string					true	"User ID"
+//	@Param			anonymize	query		bool					false	"Anonymize instead of deleting (admin only)"
+//	@Success		200			{object}	models.SuccessResponse	"User details"
+//	@Failure		400			{object}	models.ErrorResponse	"Bad Request"
+//	@Failure		403			{object}	models.ErrorResponse	"Forbidden"
+//	@Failure		404			{object}	models.ErrorResponse	"Not Found"
+//	@Failure		500			{object}	models.ErrorResponse	"Internal Server Error"
 //	@Security		BearerAuth
 //	@Router			/users/{id} [delete]
-func DeleteUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
+func DeleteUserHandler(
+	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
+) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userId, err := parseUserIdFromURL(r)
+		userId, err := parsePathID(r, "id")
 		if err != nil {
 			writeErrorResponse(w, http.StatusBadRequest, "User ID not provided in the URL.")
 			return
@@ -372,6 +935,27 @@ func DeleteUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
+		// anonymization keeps the row (and reservations) for accounting
+		if param := r.URL.Query().Get("anonymize"); param != "" {
+			anonymize, err := strconv.ParseBool(param)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "Invalid value for anonymize.")
+				return
+			}
+			if anonymize {
+				if !isAdmin(r) {
+					writeErrorResponse(
+						w,
+						http.StatusForbidden,
+						"Insufficient permissions to anonymize selected user.",
+					)
+					return
+				}
+				anonymizeUser(w, r, pool, blacklist, userId)
+				return
+			}
+		}
+
 		// delete the user
 		query := `DELETE FROM users WHERE id = $1`
 		if _, err = pool.Exec(
@@ -390,3 +974,175 @@ func DeleteUserHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		)
 	}
 }
+
+// Scrub personal data of the user, replacing it with a tombstone.
+// The user is deactivated and logged out, with the sessions (and the
+// addresses and user agents recorded with them) removed.
+func anonymizeUser(
+	w http.ResponseWriter,
+	r *http.Request,
+	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
+	userId string,
+) {
+	// nothing checks is_active on authenticated requests, so tokens are revoked
+	if err := revokeUserSessions(r.Context(), pool, blacklist, userId); err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	tx, err := pool.Begin(r.Context())
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to start transaction.")
+		return
+	}
+	defer tx.Rollback(r.Context())
+
+	query := `
+		UPDATE users
+		SET name = 'Deleted',
+			surname = 'User',
+			username = 'deleted-' || id::text,
+			email = 'deleted-' || id::text || '@deleted.invalid',
+			password_hash = '',
+			is_active = FALSE,
+			calendar_feed_version = calendar_feed_version + 1
+		WHERE id = $1
+	`
+	tag, err := tx.Exec(r.Context(), query, userId)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to anonymize user.")
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		writeErrorResponse(w, http.StatusNotFound, "User not found.")
+		return
+	}
+
+	query = `DELETE FROM user_sessions WHERE user_id = $1`
+	if _, err := tx.Exec(r.Context(), query, userId); err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to remove sessions.")
+		return
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to commit transaction.")
+		return
+	}
+
+	writeJSONResponse(
+		w,
+		http.StatusOK,
+		models.SuccessResponse{Message: "User anonymized successfully"},
+	)
+}
+
+// Reduce the user to the view without personal data.
+func summarizeUser(user models.UserResponse) models.UserSummaryResponse {
+	return models.UserSummaryResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Surname:   user.Surname,
+		Username:  user.Username,
+		CreatedAt: user.CreatedAt,
+		RoleName:  user.RoleName,
+		IsActive:  user.IsActive,
+	}
+}
+
+// Fetch a single user along with the name of its role.
+func fetchUser(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	userId string,
+) (models.UserResponse, error) {
+	return fetchUserBy(ctx, pool, "u.id = $1", userId)
+}
+
+// Fetch a single user matching the condition, along with the name of its role.
+func fetchUserBy(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	condition string,
+	arg interface{},
+) (models.UserResponse, error) {
+	query := `
+		SELECT
+			u.id, u.name, u.surname, u.username, u.email,
+			u.last_login, u.created_at, u.is_active,
+			r.name
+		FROM users u
+		JOIN roles r ON u.role_id = r.id
+		WHERE ` + condition
+	user := models.UserResponse{}
+	err := pool.QueryRow(ctx, query, arg).Scan(
+		&user.ID, &user.Name, &user.Surname, &user.Username, &user.Email,
+		&user.LastLogin, &user.CreatedAt,
+		&user.IsActive, &user.RoleName,
+	)
+	return user, err
+}
+
+// Create a single user of a bulk import. Each user is inserted under its own
+// savepoint, so a rejected one does not abort the rest of the transaction.
+// Duplicates within the batch are caught by the unique constraints, as the
+// earlier inserts are visible within the transaction.
+func createBulkUser(
+	ctx context.Context,
+	tx pgx.Tx,
+	pool *pgxpool.Pool,
+	isAdmin bool,
+	user models.CreateUserRequest,
+) (string, error) {
+	user.RoleName = normalizeRoleName(user.RoleName)
+	if _, err := validateCreateUserPayload(isAdmin, user); err != nil {
+		return "", err
+	}
+
+	roleId, err := fetchRoleId(ctx, pool, user.RoleName)
+	if err != nil {
+		return "", fmt.Errorf("Role '%s' does not exist.", user.RoleName)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("Failed to hash the password.")
+	}
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("Failed to create the user.")
+	}
+	defer savepoint.Rollback(ctx)
+
+	var userId string
+	query := `
+		INSERT INTO users
+			(name, surname, username, email, is_active, password_hash, role_id, last_login)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING id
+	`
+	if err := savepoint.QueryRow(
+		ctx, query,
+		user.Name,
+		user.Surname,
+		user.Username,
+		user.Email,
+		user.IsActive,
+		passwordHash,
+		roleId,
+	).Scan(&userId); err != nil {
+		if constraint, ok := uniqueViolation(err); ok {
+			if constraint == "users_email_key" {
+				return "", fmt.Errorf("Email is already registered.")
+			}
+			return "", fmt.Errorf("Username '%s' is already taken.", user.Username)
+		}
+		return "", fmt.Errorf("Failed to create the user.")
+	}
+
+	if err := savepoint.Commit(ctx); err != nil {
+		return "", fmt.Errorf("Failed to create the user.")
+	}
+	return userId, nil
+}
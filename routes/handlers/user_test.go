@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"event-reservation-api/models"
+)
+
+func TestConcurrentDuplicateRegistrations(t *testing.T) {
+	pool := testPool(t)
+	handler := CreateUserHandler(pool)
+
+	username := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM users WHERE username = $1`, username)
+	})
+
+	// every request passes the pre-check before any of them inserts
+	const registrations = 8
+	statuses := make([]int, registrations)
+	var wg sync.WaitGroup
+	for i := range registrations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body := fmt.Sprintf(
+				`{"name": "Test", "surname": "User", "username": %q, "email": "%s_%d@example.com",
+				"password": %q, "role_name": "REGISTERED", "is_active": true}`,
+				username, username, i, testPassword,
+			)
+			statuses[i] = serve(handler, newTestRequest(http.MethodPut, "/api/users", body)).Code
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, status := range statuses {
+		switch status {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+		default:
+			t.Fatalf("registration got %d, want %d or %d", status, http.StatusCreated, http.StatusConflict)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("%d registrations succeeded, want 1", created)
+	}
+}
+
+func TestRegisteringReservedUsername(t *testing.T) {
+	// rejected before the database is touched
+	handler := CreateUserHandler(nil)
+	body := fmt.Sprintf(
+		`{"name": "Test", "surname": "User", "username": "Admin", "email": "admin@example.com",
+		"password": %q, "role_name": "REGISTERED", "is_active": true}`,
+		testPassword,
+	)
+
+	for _, role := range []string{"", "REGISTERED"} {
+		r := newTestRequest(http.MethodPut, "/api/users", body)
+		if role != "" {
+			r = withUser(r, uuid.NewString(), role)
+		}
+		rec := serve(handler, r)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status for role %q = %d, want %d", role, rec.Code, http.StatusBadRequest)
+		}
+		if !strings.Contains(rec.Body.String(), "Username is reserved.") {
+			t.Fatalf("body for role %q = %s, want the reserved username message", role, rec.Body)
+		}
+	}
+
+	// admins may still create the reserved ones
+	user := models.CreateUserRequest{Username: "admin", Password: testPassword, RoleName: "ADMIN"}
+	if status, err := validateCreateUserPayload(true, user); err != nil {
+		t.Fatalf("validateCreateUserPayload() for an admin = %d, %v", status, err)
+	}
+}
+
+func TestRegisteringPrivilegedRoleInLowercase(t *testing.T) {
+	// rejected before the database is touched
+	handler := CreateUserHandler(nil)
+
+	for _, role := range []string{"staff", " Admin "} {
+		body := fmt.Sprintf(
+			`{"name": "Test", "surname": "User", "username": "test_user", "email": "test@example.com",
+			"password": %q, "role_name": %q, "is_active": true}`,
+			testPassword, role,
+		)
+		r := withUser(newTestRequest(http.MethodPut, "/api/users", body), uuid.NewString(), "REGISTERED")
+		if rec := serve(handler, r); rec.Code != http.StatusForbidden {
+			t.Fatalf("status for role %q = %d, want %d", role, rec.Code, http.StatusForbidden)
+		}
+	}
+}
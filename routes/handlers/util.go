@@ -2,47 +2,46 @@ package handlers
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"mime"
 	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"event-reservation-api/middlewares"
 	"event-reservation-api/models"
 )
 
-func parseIdFromURL(r *http.Request, message string) (string, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["id"]
-	if !ok {
-		return "", fmt.Errorf(message)
-	}
-	return id, nil
+// Error returned when a path parameter is missing from the URL.
+type missingPathParamError struct {
+	name string
 }
 
-// Parse reservation ID from the URL.
-func parseReservationIdFromURL(r *http.Request) (string, error) {
-	vars := mux.Vars(r)
-	userId, ok := vars["id"]
-	if !ok {
-		return "", fmt.Errorf("Reservation ID not provided in the URL.")
-	}
-	return userId, nil
+func (e *missingPathParamError) Error() string {
+	return fmt.Sprintf("Parameter '%s' not provided in the URL.", e.name)
 }
 
-// Parse user ID from the URL.
-func parseUserIdFromURL(r *http.Request) (string, error) {
-	vars := mux.Vars(r)
-	userId, ok := vars["id"]
-	if !ok {
-		return "", fmt.Errorf("User ID not provided in the URL.")
+// Parse the path parameter with given name from the URL.
+func parsePathID(r *http.Request, name string) (string, error) {
+	id, ok := mux.Vars(r)[name]
+	if !ok || id == "" {
+		return "", &missingPathParamError{name: name}
 	}
-	return userId, nil
+	return id, nil
 }
 
 // Confirm the status of the reservation.
@@ -52,22 +51,23 @@ func confirmReservation(
 	tx pgx.Tx,
 	reservationId string,
 ) error {
-	if err := updateTicketsStatus(
+	if err := updateReservationStatus(
 		ctx,
 		tx,
 		reservationId,
-		"SOLD",
+		"CONFIRMED",
+		"PENDING",
 	); err != nil {
-		return fmt.Errorf("Failed to update ticket status: %w", err)
+		return fmt.Errorf("Failed to update reservation status: %w", err)
 	}
 
-	if err := updateReservationStatus(
+	if err := updateTicketsStatus(
 		ctx,
 		tx,
 		reservationId,
-		"CONFIRMED",
+		"SOLD",
 	); err != nil {
-		return fmt.Errorf("Failed to update reservation status: %w", err)
+		return fmt.Errorf("Failed to update ticket status: %w", err)
 	}
 	return nil
 }
@@ -100,12 +100,40 @@ func updateTicketsStatus(
 	return nil
 }
 
-// Update the status of a reservation.
+// Return the seats of tickets the reservation still holds to its event, before
+// the tickets are cancelled.
+func releaseReservationSeats(ctx context.Context, tx pgx.Tx, resId string) error {
+	query := `
+		UPDATE events e
+		SET available_tickets = e.available_tickets + held.tickets
+		FROM (
+			SELECT r.event_id, COUNT(t.id) AS tickets
+			FROM reservations r
+			JOIN tickets t ON t.reservation_id = r.id
+			JOIN ticket_statuses ts ON t.status_id = ts.id
+			WHERE r.id = $1 AND ts.name IN ('RESERVED', 'SOLD')
+			GROUP BY r.event_id
+		) held
+		WHERE e.id = held.event_id
+	`
+	if _, err := tx.Exec(ctx, query, resId); err != nil {
+		return fmt.Errorf("Failed to release seats.")
+	}
+	return nil
+}
+
+// Returned when the reservation is no longer in the status a transition expects,
+// e.g. because a concurrent request cancelled or confirmed it first.
+var errReservationStateChanged = errors.New("Reservation state changed.")
+
+// Update the status of a reservation, provided it is in one of the expected statuses.
+// Returns errReservationStateChanged if it is not.
 func updateReservationStatus(
 	ctx context.Context,
 	tx pgx.Tx,
 	resId string,
 	status string,
+	expected ...string,
 ) error {
 	query := `
 		UPDATE reservations
@@ -115,16 +143,25 @@ func updateReservationStatus(
 			WHERE name = $1
 			LIMIT 1
 		)
-		WHERE id = $2
+		WHERE id = $2 AND status_id IN (
+			SELECT id
+			FROM reservation_statuses
+			WHERE name = ANY($3)
+		)
 	`
-	if _, err := tx.Exec(
+	tag, err := tx.Exec(
 		ctx,
 		query,
 		status,
 		resId,
-	); err != nil {
+		expected,
+	)
+	if err != nil {
 		return fmt.Errorf("Failed to update reservation status.")
 	}
+	if tag.RowsAffected() == 0 {
+		return errReservationStateChanged
+	}
 	return nil
 }
 
@@ -179,6 +216,37 @@ func fetchTicketDetails(
 	return discount, typeId, statusId, nil
 }
 
+// Compute the price of a ticket of given type for an event.
+// Per-event price override takes precedence over the discounted base price.
+func fetchTicketPrice(
+	ctx context.Context,
+	tx pgx.Tx,
+	eventID int,
+	typeID int,
+	basePrice float64,
+	discount float64,
+) (float64, error) {
+	query := `
+		SELECT price
+		FROM event_ticket_prices
+		WHERE event_id = $1 AND type_id = $2
+	`
+	var price float64
+	err := tx.QueryRow(ctx, query, eventID, typeID).Scan(&price)
+	if err == pgx.ErrNoRows {
+		return basePrice * (1 - discount), nil
+	}
+	if err != nil {
+		return 0.0, fmt.Errorf("Failed to fetch ticket price: %w", err)
+	}
+	return price, nil
+}
+
+// Normalize the name of a ticket type, as stored in ticket_types.
+func normalizeTicketType(ticketType string) string {
+	return strings.ToUpper(strings.TrimSpace(ticketType))
+}
+
 // Fetch the details required for creating a reservation.
 // This involves base price, available tickets as well as the id of the
 // reservation status
@@ -210,29 +278,306 @@ func fetchReservationDetails(
 	return basePrice, availableTickets, statusID, nil
 }
 
-// Validate the reservation request payload.
-func validateReservationRequest(req models.CreateReservationPayload) error {
+// Visibilities of events. Public ones are listed, unlisted ones are reachable
+// by their ID or slug only and private ones are limited to admins, staff and
+// holders of their reservations.
+var eventVisibilities = map[string]bool{"PUBLIC": true, "UNLISTED": true, "PRIVATE": true}
+
+// Normalize the visibility of an event, defaulting to PUBLIC if not provided.
+func normalizeVisibility(visibility *string) (string, error) {
+	if visibility == nil {
+		return "PUBLIC", nil
+	}
+	value := strings.ToUpper(*visibility)
+	if !eventVisibilities[value] {
+		return "", fmt.Errorf("Invalid visibility; must be PUBLIC, UNLISTED or PRIVATE.")
+	}
+	return value, nil
+}
+
+// Check if the user is allowed to see the event. Private events are shown to
+// admins, staff and users holding a reservation for them.
+func canViewEvent(r *http.Request, pool *pgxpool.Pool, event models.EventResponse) (bool, error) {
+	if event.Visibility != "PRIVATE" || isStaffOrAdmin(r) {
+		return true, nil
+	}
+	userID, err := getUserIdFromContext(r.Context())
+	if err != nil {
+		return false, nil
+	}
+
+	var holds bool
+	query := `SELECT EXISTS(SELECT 1 FROM reservations WHERE event_id = $1 AND user_id = $2)`
+	err = pool.QueryRow(r.Context(), query, event.ID, userID).Scan(&holds)
+	return holds, err
+}
+
+// Fetch the date of the event along with the hours before it online sales close.
+func fetchSalesCutoff(ctx context.Context, tx pgx.Tx, eventID int) (time.Time, int, error) {
+	var date time.Time
+	var cutoffHours *int
+	query := `SELECT date, reservation_cutoff_hours FROM events WHERE id = $1`
+	if err := tx.QueryRow(ctx, query, eventID).Scan(&date, &cutoffHours); err != nil {
+		return time.Time{}, 0, err
+	}
+	if cutoffHours == nil {
+		return date, reservationCutoffHours, nil
+	}
+	return date, *cutoffHours, nil
+}
+
+// Fetch the moment tickets of the event go on sale, nil if they are on sale already.
+func fetchSaleStart(ctx context.Context, tx pgx.Tx, eventID int) (*time.Time, error) {
+	var saleStartsAt *time.Time
+	query := `SELECT sale_starts_at FROM events WHERE id = $1`
+	err := tx.QueryRow(ctx, query, eventID).Scan(&saleStartsAt)
+	return saleStartsAt, err
+}
+
+// Fetch the visibility of the event.
+func fetchEventVisibility(ctx context.Context, tx pgx.Tx, eventID int) (string, error) {
+	var visibility string
+	query := `SELECT visibility FROM events WHERE id = $1`
+	err := tx.QueryRow(ctx, query, eventID).Scan(&visibility)
+	return visibility, err
+}
+
+// Find a non-cancelled reservation of the user for the event.
+// Returns an empty identifier if there is none.
+func fetchOpenReservationId(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID string,
+	eventID int,
+) (string, error) {
+	// lock the event, so concurrent reservations wait for this one to commit
+	// instead of passing the check together
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM events WHERE id = $1 FOR UPDATE`, eventID); err != nil {
+		return "", err
+	}
+
+	query := `
+		SELECT r.id
+		FROM reservations r
+		JOIN reservation_statuses rs ON r.status_id = rs.id
+		WHERE r.user_id = $1 AND r.event_id = $2 AND rs.name <> 'CANCELLED'
+		ORDER BY r.created_at DESC
+		LIMIT 1
+	`
+	var reservationId string
+	err := tx.QueryRow(ctx, query, userID, eventID).Scan(&reservationId)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	return reservationId, err
+}
+
+// Fetch the most tickets a reservation for the event may hold.
+// Per-event limit takes precedence over the configured one.
+func fetchReservationLimit(ctx context.Context, tx pgx.Tx, eventID int) (int, error) {
+	query := `SELECT COALESCE(max_tickets_per_reservation, $2) FROM events WHERE id = $1`
+	var limit int
+	if err := tx.QueryRow(ctx, query, eventID, maxTicketsPerReservation).Scan(&limit); err != nil {
+		return 0, err
+	}
+	return limit, nil
+}
+
+// Fetch the cap on reservations per user for the event, along with the number
+// of non-cancelled reservations the user already holds for it. Zero cap means
+// unlimited. The event row is locked, so concurrent reservations of the same
+// user cannot both slip under the cap.
+func fetchReservationsPerUser(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID string,
+	eventID int,
+) (int, int, error) {
+	var limit int
+	query := `SELECT COALESCE(max_reservations_per_user, 0) FROM events WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRow(ctx, query, eventID).Scan(&limit); err != nil {
+		return 0, 0, err
+	}
+	if limit == 0 {
+		return 0, 0, nil
+	}
+
+	var count int
+	query = `
+		SELECT COUNT(*)
+		FROM reservations r
+		JOIN reservation_statuses rs ON r.status_id = rs.id
+		WHERE r.user_id = $1 AND r.event_id = $2 AND rs.name <> 'CANCELLED'
+	`
+	if err := tx.QueryRow(ctx, query, userID, eventID).Scan(&count); err != nil {
+		return 0, 0, err
+	}
+	return limit, count, nil
+}
+
+// Validate the reservation request payload, returning the number of tickets requested.
+func validateReservationRequest(req models.CreateReservationPayload) (int, error) {
 	if req.EventID <= 0 {
-		return fmt.Errorf("Invalid input: ensure all fields are non-negative")
+		return 0, fmt.Errorf("Invalid input: ensure all fields are non-negative")
+	}
+	return countTickets(req.Tickets)
+}
+
+// Count the tickets requested, quantities included, without expanding them,
+// so the size can be checked against the limit first.
+// Entries without quantity count as a single ticket.
+func countTickets(tickets []models.ReservationTicketRequest) (int, error) {
+	if len(tickets) == 0 {
+		return 0, fmt.Errorf("Invalid input: reservation must contain at least one ticket")
+	}
+	total := 0
+	for _, ticket := range tickets {
+		quantity := ticket.Quantity
+		if quantity < 0 {
+			return 0, fmt.Errorf("Invalid input: ticket quantity must be positive")
+		}
+		if quantity == 0 {
+			quantity = 1
+		}
+		if quantity > math.MaxInt-total {
+			return 0, fmt.Errorf("Invalid input: too many tickets requested")
+		}
+		total += quantity
+	}
+	return total, nil
+}
+
+// Check the number of tickets against the limit of a single reservation.
+func checkReservationSize(count, limit int) error {
+	if count > limit {
+		return fmt.Errorf("Reservation exceeds the limit of %d tickets.", limit)
 	}
 	return nil
 }
 
+// Expand ticket entries into a list of single tickets, keeping type and section.
+// Entries without quantity are treated as a single ticket.
+func expandTickets(tickets []models.ReservationTicketRequest) []models.ReservationTicketRequest {
+	expanded := []models.ReservationTicketRequest{}
+	for _, ticket := range tickets {
+		quantity := ticket.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		for i := 0; i < quantity; i++ {
+			expanded = append(expanded, models.ReservationTicketRequest{
+				Type:     ticket.Type,
+				Quantity: 1,
+				Section:  ticket.Section,
+			})
+		}
+	}
+	return expanded
+}
+
+// Find ticket entries with sections the event does not have.
+// Returns them as "[index] 'section'", index being the position within the payload.
+func findUnknownSections(
+	ctx context.Context,
+	tx pgx.Tx,
+	eventID int,
+	tickets []models.ReservationTicketRequest,
+) ([]string, error) {
+	names := []string{}
+	for _, ticket := range tickets {
+		if ticket.Section != nil {
+			names = append(names, *ticket.Section)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT name FROM event_sections WHERE event_id = $1 AND name = ANY($2)`
+	rows, err := tx.Query(ctx, query, eventID, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	known := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		known[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	unknown := []string{}
+	for i, ticket := range tickets {
+		if ticket.Section != nil && !known[*ticket.Section] {
+			unknown = append(unknown, fmt.Sprintf("[%d] '%s'", i, *ticket.Section))
+		}
+	}
+	return unknown, nil
+}
+
+// Find ticket entries with types not present in the database.
+// Returns them as "[index] 'type'", index being the position within the payload.
+func findUnknownTicketTypes(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	tickets []models.ReservationTicketRequest,
+) ([]string, error) {
+	names := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		names = append(names, ticket.Type)
+	}
+
+	query := `SELECT name FROM ticket_types WHERE name = ANY($1)`
+	rows, err := pool.Query(ctx, query, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	known := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		known[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	unknown := []string{}
+	for i, ticket := range tickets {
+		if !known[ticket.Type] {
+			unknown = append(unknown, fmt.Sprintf("[%d] '%s'", i, ticket.Type))
+		}
+	}
+	return unknown, nil
+}
+
 // Fetch tickets attributed to a reservation with provided ID.
+// Cancelled tickets are left out, unless includeCancelled is set.
 func fetchTickets(
 	ctx context.Context,
 	pool *pgxpool.Pool,
 	reservationID string,
+	includeCancelled bool,
 ) ([]models.TicketResponse, error) {
 	query := `
-		SELECT t.id, t.price, ts.name AS status, tt.name AS type
+		SELECT t.id, t.price, ts.name AS status, tt.name AS type, t.section
 		FROM Tickets t
 		JOIN ticket_statuses ts ON t.status_id = ts.id
 		JOIN ticket_types tt ON t.type_id = tt.id
-		WHERE t.reservation_id = $1
+		WHERE t.reservation_id = $1 AND ($2 OR ts.name <> 'CANCELLED')
 	`
 
-	rows, err := pool.Query(ctx, query, reservationID)
+	rows, err := pool.Query(ctx, query, reservationID, includeCancelled)
 	if err != nil {
 		return nil, err
 	}
@@ -242,16 +587,168 @@ func fetchTickets(
 	tickets := []models.TicketResponse{}
 	for rows.Next() {
 		var ticket models.TicketResponse
-		err := rows.Scan(&ticket.ID, &ticket.Price, &ticket.Status, &ticket.Type)
+		err := rows.Scan(&ticket.ID, &ticket.Price, &ticket.Status, &ticket.Type, &ticket.Section)
 		if err != nil {
 			return nil, err
 		}
+		ticket.Currency = currency
 		tickets = append(tickets, ticket)
 	}
 
 	return tickets, nil
 }
 
+// Conditions on the event date, matching values of the when parameter.
+var reservationWhenConditions = map[string]string{
+	"all":      "",
+	"past":     " AND e.date < $2",
+	"upcoming": " AND e.date >= $2",
+}
+
+// Parse the when query parameter, defaulting to all reservations.
+func parseReservationWhen(r *http.Request) (string, error) {
+	when := r.URL.Query().Get("when")
+	if when == "" {
+		return "all", nil
+	}
+	if _, ok := reservationWhenConditions[when]; !ok {
+		return "", fmt.Errorf("Invalid value for when, expected past, upcoming or all.")
+	}
+	return when, nil
+}
+
+// Fetch reservations of the user with provided ID, along with their tickets
+// unless includeTickets is false. The when argument narrows them down to past
+// or upcoming events.
+func fetchUserReservations(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	userID string,
+	when string,
+	includeTickets bool,
+) ([]models.ReservationResponse, error) {
+	query := `
+		SELECT r.id, u.username, r.created_at, r.total_tickets, rs.name,
+			e.id, e.name, e.date, l.country, l.address, l.stadium
+		FROM Reservations r
+		JOIN reservation_statuses rs ON r.status_id = rs.id
+		JOIN Users u ON r.user_id = u.id
+		JOIN Events e ON r.event_id = e.id
+		JOIN Locations l ON e.location_id = l.id
+		WHERE r.user_id = $1` + reservationWhenConditions[when] + `
+		ORDER BY e.date, r.id
+	`
+	args := []interface{}{userID}
+	if when != "all" {
+		args = append(args, time.Now())
+	}
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// build the list of reservations
+	reservations := []models.ReservationResponse{}
+	for rows.Next() {
+		var res models.ReservationResponse
+		var location models.LocationResponse
+		var event models.EventResponse
+
+		if err := rows.Scan(
+			&res.ID, &res.Username, &res.CreatedAt, &res.TotalTickets, &res.Status,
+			&event.ID, &event.Name, &event.Date,
+			&location.Country, &location.Address, &location.Stadium,
+		); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		event.Location = location
+		res.Event = event
+		reservations = append(reservations, res)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// attach the tickets, once the rows are released
+	if includeTickets {
+		if err := attachTickets(ctx, pool, reservations); err != nil {
+			return nil, err
+		}
+	}
+
+	return reservations, nil
+}
+
+// Attach the tickets, cancelled ones included, to each of the reservations.
+func attachTickets(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	reservations []models.ReservationResponse,
+) error {
+	for i := range reservations {
+		tickets, err := fetchTickets(ctx, pool, reservations[i].ID, true)
+		if err != nil {
+			return err
+		}
+		reservations[i].Tickets = tickets
+	}
+	return nil
+}
+
+// Fetch all tickets of the user with provided ID, along with their events.
+func fetchUserTickets(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	userID string,
+) ([]models.UserTicketResponse, error) {
+	query := `
+		SELECT
+			t.id, t.reservation_id, t.price,
+			tt.name, ts.name, t.section,
+			e.id, e.name, e.date,
+			l.country, l.address, l.stadium
+		FROM tickets t
+		JOIN ticket_types tt ON t.type_id = tt.id
+		JOIN ticket_statuses ts ON t.status_id = ts.id
+		JOIN reservations r ON t.reservation_id = r.id
+		JOIN events e ON r.event_id = e.id
+		JOIN locations l ON e.location_id = l.id
+		WHERE r.user_id = $1
+	`
+	rows, err := pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// build the list of tickets
+	tickets := []models.UserTicketResponse{}
+	for rows.Next() {
+		var ticket models.UserTicketResponse
+		var location models.LocationResponse
+		var event models.EventResponse
+
+		if err := rows.Scan(
+			&ticket.ID, &ticket.ReservationID, &ticket.Price, &ticket.Type, &ticket.Status,
+			&ticket.Section,
+			&event.ID, &event.Name, &event.Date,
+			&location.Country, &location.Address, &location.Stadium,
+		); err != nil {
+			return nil, err
+		}
+
+		event.Location = location
+		ticket.Event = event
+		ticket.Currency = currency
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, rows.Err()
+}
+
 // Build a WHERE clause for filtering locations.
 // If both provided: WHERE address = $1 AND stadium = $2; otherwise OR is used.
 func getWhereClause(address *string, stadium *string) (string, []interface{}) {
@@ -273,6 +770,543 @@ func getWhereClause(address *string, stadium *string) (string, []interface{}) {
 	return "WHERE " + strings.Join(conditions, " AND "), args
 }
 
+// Build a WHERE clause for filtering events, based on the query parameters.
+// Conditions are joined with AND, placeholders are numbered in order of appearance.
+func getEventsWhereClause(r *http.Request) (string, []interface{}, error) {
+	conditions, args, err := getEventFilters(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// unlisted and private events are never listed
+	conditions = append([]string{"e.visibility = 'PUBLIC'"}, conditions...)
+	return "WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+// Build the conditions of the event filters given in query parameters, shared
+// by the list and the export.
+func getEventFilters(r *http.Request) ([]string, []interface{}, error) {
+	conditions := []string{}
+	var args []interface{}
+
+	// filter by availability of tickets
+	if param := r.URL.Query().Get("available"); param != "" {
+		available, err := strconv.ParseBool(param)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid value for available.")
+		}
+		if available {
+			conditions = append(conditions, "e.available_tickets > 0")
+		} else {
+			conditions = append(conditions, "e.available_tickets = 0")
+		}
+	}
+
+	return conditions, args, nil
+}
+
+// Append conditions limiting the column to the range given by the from and to
+// query parameters; from is inclusive, to is exclusive.
+func appendDateRange(
+	r *http.Request,
+	column string,
+	conditions []string,
+	args []interface{},
+) ([]string, []interface{}, error) {
+	for _, param := range []struct {
+		name     string
+		operator string
+	}{{"from", ">="}, {"to", "<"}} {
+		value := r.URL.Query().Get(param.name)
+		if value == "" {
+			continue
+		}
+		date, err := dateToRFC3339(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"Invalid %s date; must be YYYY-MM-DD HH:MM or RFC3339.",
+				param.name,
+			)
+		}
+		args = append(args, date)
+		conditions = append(
+			conditions,
+			fmt.Sprintf("%s %s $%d", column, param.operator, len(args)),
+		)
+	}
+	return conditions, args, nil
+}
+
+// Columns the reservation list can be sorted by.
+var reservationSortColumns = map[string]string{
+	"created_at":    "r.created_at",
+	"total_tickets": "r.total_tickets",
+	"event_date":    "e.date",
+}
+
+// Parse the sort and order query parameters of the reservation list, returning
+// the column and direction. Newest reservations come first by default.
+func parseReservationsSort(r *http.Request) (string, string, error) {
+	column := reservationSortColumns["created_at"]
+	if param := r.URL.Query().Get("sort"); param != "" {
+		var ok bool
+		if column, ok = reservationSortColumns[param]; !ok {
+			return "", "", fmt.Errorf(
+				"Invalid sort; must be one of created_at, total_tickets, event_date.",
+			)
+		}
+	}
+
+	direction := "DESC"
+	switch strings.ToLower(r.URL.Query().Get("order")) {
+	case "":
+	case "asc":
+		direction = "ASC"
+	case "desc":
+		direction = "DESC"
+	default:
+		return "", "", fmt.Errorf("Invalid order; must be asc or desc.")
+	}
+	return column, direction, nil
+}
+
+// Build the ORDER BY clause of the reservation list from the sort and order
+// query parameters. Ties are broken by the ID in the same direction, so the
+// order is stable for cursors.
+func getReservationsOrderClause(r *http.Request) (string, error) {
+	column, direction, err := parseReservationsSort(r)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ORDER BY %s %s, r.id %s", column, direction, direction), nil
+}
+
+// Extend the WHERE clause of the reservation list with the keyset condition
+// of the after cursor, continuing right past the reservation it points at.
+// Cursors follow the (created_at, id) order, so other sorts are rejected.
+func getReservationsCursorClause(
+	r *http.Request,
+	whereClause string,
+	args []interface{},
+) (string, []interface{}, error) {
+	after := r.URL.Query().Get("after")
+	if after == "" {
+		return whereClause, args, nil
+	}
+
+	column, direction, err := parseReservationsSort(r)
+	if err != nil {
+		return "", nil, err
+	}
+	if column != reservationSortColumns["created_at"] {
+		return "", nil, fmt.Errorf("Cursor pagination requires sorting by created_at.")
+	}
+	if r.URL.Query().Get("offset") != "" {
+		return "", nil, fmt.Errorf("Cursor and offset cannot be combined.")
+	}
+
+	createdAt, id, err := decodeReservationCursor(after)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, createdAt, id)
+
+	comparison := "<"
+	if direction == "ASC" {
+		comparison = ">"
+	}
+	condition := fmt.Sprintf(
+		"(r.created_at, r.id) %s ($%d, $%d)", comparison, len(args)-1, len(args),
+	)
+	if whereClause == "" {
+		return "WHERE " + condition, args, nil
+	}
+	return whereClause + " AND " + condition, args, nil
+}
+
+// Encode the position of a reservation within the list as an opaque cursor.
+func encodeReservationCursor(createdAt time.Time, id string) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode the cursor of the reservation list into the creation time and ID.
+func decodeReservationCursor(cursor string) (time.Time, string, error) {
+	invalid := fmt.Errorf("Invalid cursor.")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", invalid
+	}
+	timestamp, id, found := strings.Cut(string(raw), "|")
+	if !found {
+		return time.Time{}, "", invalid
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}, "", invalid
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return time.Time{}, "", invalid
+	}
+	return createdAt, id, nil
+}
+
+// Check if a status with the given name exists in one of the status tables.
+func statusExists(ctx context.Context, pool *pgxpool.Pool, table, name string) (bool, error) {
+	var exists bool
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE name = $1)`, table)
+	if err := pool.QueryRow(ctx, query, name).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Build the WHERE clause for the reservation list, based on the status and date filters.
+// Returns the HTTP status code alongside an error, to distinguish invalid filters.
+func getReservationsWhereClause(
+	r *http.Request,
+	pool *pgxpool.Pool,
+) (string, []interface{}, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	// filter by the status of the reservation itself
+	if status := r.URL.Query().Get("status"); status != "" {
+		exists, err := statusExists(r.Context(), pool, "reservation_statuses", status)
+		if err != nil {
+			return "", nil, http.StatusInternalServerError, fmt.Errorf(
+				"Failed to validate the reservation status.",
+			)
+		}
+		if !exists {
+			return "", nil, http.StatusBadRequest, fmt.Errorf("Invalid reservation status.")
+		}
+		args = append(args, status)
+		conditions = append(conditions, fmt.Sprintf("rs.name = $%d", len(args)))
+	}
+
+	// filter reservations having at least one ticket in the given status
+	if status := r.URL.Query().Get("ticket_status"); status != "" {
+		exists, err := statusExists(r.Context(), pool, "ticket_statuses", status)
+		if err != nil {
+			return "", nil, http.StatusInternalServerError, fmt.Errorf(
+				"Failed to validate the ticket status.",
+			)
+		}
+		if !exists {
+			return "", nil, http.StatusBadRequest, fmt.Errorf("Invalid ticket status.")
+		}
+		args = append(args, status)
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+				SELECT 1
+				FROM tickets t
+				JOIN ticket_statuses ts ON t.status_id = ts.id
+				WHERE t.reservation_id = r.id AND ts.name = $%d
+			)`, len(args)))
+	}
+
+	// filter by the username of the buyer, * matches any characters
+	if username := r.URL.Query().Get("username"); username != "" {
+		if strings.Contains(username, "*") {
+			escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(username)
+			args = append(args, strings.ReplaceAll(escaped, "*", "%"))
+			conditions = append(conditions, fmt.Sprintf("u.username ILIKE $%d", len(args)))
+		} else {
+			args = append(args, username)
+			conditions = append(conditions, fmt.Sprintf("u.username = $%d", len(args)))
+		}
+	}
+
+	// filter by the time the reservation was created
+	conditions, args, err := appendDateRange(r, "r.created_at", conditions, args)
+	if err != nil {
+		return "", nil, http.StatusBadRequest, err
+	}
+
+	if len(conditions) == 0 {
+		return "", nil, http.StatusOK, nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args, http.StatusOK, nil
+}
+
+// Verify that the capacity of a location can be set to the given value.
+// When lowered, it must still fit available and sold tickets of every event at
+// the location. Returns 422 naming the blocking event, if it does not.
+func checkLocationCapacity(
+	r *http.Request,
+	tx pgx.Tx,
+	locationID string,
+	capacity int,
+) (int, error) {
+	var current int
+	query := `SELECT capacity FROM locations WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRow(r.Context(), query, locationID).Scan(&current); err != nil {
+		if err == pgx.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("Location not found.")
+		}
+		return http.StatusInternalServerError, fmt.Errorf("Failed to fetch the location.")
+	}
+	if capacity >= current {
+		return http.StatusOK, nil
+	}
+
+	// event requiring the most seats, counting tickets that were not cancelled
+	query = `
+		SELECT e.id, e.name, e.available_tickets + COUNT(t.id) AS seats
+		FROM events e
+		LEFT JOIN reservations res ON res.event_id = e.id
+		LEFT JOIN tickets t ON t.reservation_id = res.id
+			AND t.status_id <> (SELECT id FROM ticket_statuses WHERE name = 'CANCELLED')
+		WHERE e.location_id = $1
+		GROUP BY e.id
+		ORDER BY seats DESC, e.id
+		LIMIT 1
+	`
+	var eventID, seats int
+	var eventName string
+	err := tx.QueryRow(r.Context(), query, locationID).Scan(&eventID, &eventName, &seats)
+	if err == pgx.ErrNoRows {
+		return http.StatusOK, nil
+	}
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("Failed to verify the capacity.")
+	}
+	if seats > capacity {
+		return http.StatusUnprocessableEntity, fmt.Errorf(
+			"Capacity of %d is too low for event '%s' (ID %d), which requires %d seats.",
+			capacity, eventName, eventID, seats,
+		)
+	}
+	return http.StatusOK, nil
+}
+
+// Fields of an event changed by an update, nil if left as they are.
+type eventUpdateState struct {
+	Date             *time.Time
+	AvailableTickets *int
+	Price            *float64
+	LocationID       *int
+	SaleStartChanged bool
+	SaleStartsAt     *time.Time // nil removes the sale start, if changed
+}
+
+// Validate the state of the event after applying the update, collecting every
+// violation rather than stopping at the first one. The event stays locked until
+// the end of the transaction.
+func validateEventUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	eventID string,
+	proposed eventUpdateState,
+) ([]string, error) {
+	var date time.Time
+	var saleStartsAt *time.Time
+	var availableTickets, locationID int
+	var price float64
+	query := `
+		SELECT date, sale_starts_at, available_tickets, price, location_id
+		FROM events
+		WHERE id = $1
+		FOR UPDATE
+	`
+	if err := tx.QueryRow(ctx, query, eventID).
+		Scan(&date, &saleStartsAt, &availableTickets, &price, &locationID); err != nil {
+		return nil, err
+	}
+
+	// overlay the changes on the current state
+	if proposed.Date != nil {
+		date = *proposed.Date
+	}
+	if proposed.AvailableTickets != nil {
+		availableTickets = *proposed.AvailableTickets
+	}
+	if proposed.Price != nil {
+		price = *proposed.Price
+	}
+	if proposed.LocationID != nil {
+		locationID = *proposed.LocationID
+	}
+	if proposed.SaleStartChanged {
+		saleStartsAt = proposed.SaleStartsAt
+	}
+
+	// seats already taken by tickets that were not cancelled
+	var capacity, taken int
+	query = `
+		SELECT
+			l.capacity,
+			(
+				SELECT COUNT(t.id)
+				FROM tickets t
+				JOIN reservations res ON t.reservation_id = res.id
+				JOIN ticket_statuses ts ON t.status_id = ts.id
+				WHERE res.event_id = $2 AND ts.name <> 'CANCELLED'
+			)
+		FROM locations l
+		WHERE l.id = $1
+	`
+	if err := tx.QueryRow(ctx, query, locationID, eventID).Scan(&capacity, &taken); err != nil {
+		return nil, err
+	}
+
+	violations := []string{}
+	if !date.After(time.Now()) {
+		violations = append(violations, "Date must be in the future.")
+	}
+	if saleStartsAt != nil && !saleStartsAt.Before(date) {
+		violations = append(violations, "Sales must start before the event.")
+	}
+	if price < 0 {
+		violations = append(violations, "Price must not be negative.")
+	}
+	if availableTickets < 0 {
+		violations = append(violations, "Available tickets must not be negative.")
+	}
+	if availableTickets+taken > capacity {
+		violations = append(violations, fmt.Sprintf(
+			"Available tickets (%d) and tickets already reserved (%d) exceed the capacity of %d.",
+			availableTickets, taken, capacity,
+		))
+	}
+	return violations, nil
+}
+
+// Fetch a single event matching the condition, along with its location.
+func fetchEvent(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	condition string,
+	arg interface{},
+) (models.EventResponse, error) {
+	query := `
+		SELECT
+			e.id, e.name, COALESCE(e.slug, ''), e.date, e.price, e.available_tickets,
+			e.sale_starts_at, e.visibility, e.reservation_cutoff_hours,
+			l.id, l.stadium, l.address, l.country, l.capacity
+		FROM events e
+		JOIN locations l ON e.location_id = l.id
+		WHERE ` + condition
+
+	var event models.EventResponse
+	var cutoffHours *int
+	err := pool.QueryRow(ctx, query, arg).Scan(
+		&event.ID,
+		&event.Name,
+		&event.Slug,
+		&event.Date,
+		&event.Price,
+		&event.AvailableTickets,
+		&event.SaleStartsAt,
+		&event.Visibility,
+		&cutoffHours,
+		&event.Location.ID,
+		&event.Location.Stadium,
+		&event.Location.Address,
+		&event.Location.Country,
+		&event.Location.Capacity,
+	)
+	event.Currency = currency
+	event.OnSale = isOnSale(event.SaleStartsAt)
+	setSalesCutoff(&event, cutoffHours)
+	return event, err
+}
+
+// Check if tickets of an event with the given sale start can be reserved now.
+func isOnSale(saleStartsAt *time.Time) *bool {
+	onSale := saleStartsAt == nil || !saleStartsAt.After(time.Now())
+	return &onSale
+}
+
+// Set the cutoff of online sales of the event, falling back to the global one
+// if the event does not override it, and whether sales are still open.
+func setSalesCutoff(event *models.EventResponse, cutoffHours *int) {
+	hours := reservationCutoffHours
+	if cutoffHours != nil {
+		hours = *cutoffHours
+	}
+	event.ReservationCutoffHours = &hours
+	event.SalesOpen = areSalesOpen(event.Date, hours)
+}
+
+// Check if online sales of an event at the given date are still open, that is
+// the event is more than the cutoff hours away.
+func areSalesOpen(date time.Time, cutoffHours int) *bool {
+	open := time.Now().Before(date.Add(-time.Duration(cutoffHours) * time.Hour))
+	return &open
+}
+
+// Normalize the optional sale start of an event to RFC3339. Sales have to
+// start before the event itself, given in RFC3339 as well.
+func normalizeSaleStart(saleStartsAt *string, date string) (*string, error) {
+	if saleStartsAt == nil {
+		return nil, nil
+	}
+	rfc3339Date, err := dateToRFC3339(*saleStartsAt)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid sale_starts_at; must be YYYY-MM-DD HH:MM or RFC3339.")
+	}
+	start, _ := time.Parse(time.RFC3339, rfc3339Date)
+	eventDate, err := time.Parse(time.RFC3339, date)
+	if err == nil && !start.Before(eventDate) {
+		return nil, fmt.Errorf("Sales must start before the event.")
+	}
+	return &rfc3339Date, nil
+}
+
+// Characters not allowed within a slug.
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Derive the slug of an event from its name and date.
+func slugify(name string, date time.Time) string {
+	base := strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if base == "" {
+		base = "event"
+	}
+	return base + "-" + date.Format("2006-01-02")
+}
+
+// Generate a slug for the event with given ID and store it.
+// Collisions with other events are resolved with a numeric suffix.
+func setEventSlug(ctx context.Context, tx pgx.Tx, eventID int) error {
+	var name string
+	var date time.Time
+	query := `SELECT name, date FROM events WHERE id = $1`
+	if err := tx.QueryRow(ctx, query, eventID).Scan(&name, &date); err != nil {
+		return err
+	}
+	base := slugify(name, date)
+
+	// slugs already taken by other events
+	query = `SELECT slug FROM events WHERE (slug = $1 OR slug LIKE $1 || '-%') AND id <> $2`
+	rows, err := tx.Query(ctx, query, base, eventID)
+	if err != nil {
+		return err
+	}
+	taken := map[string]bool{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			rows.Close()
+			return err
+		}
+		taken[slug] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	slug := base
+	for i := 2; taken[slug]; i++ {
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE events SET slug = $1 WHERE id = $2`, slug, eventID)
+	return err
+}
+
 // Validate the location data.
 func validateAddressAndStadium(address *string, stadium *string) error {
 	if (address == nil || *address == "") && (stadium == nil || *stadium == "") {
@@ -281,6 +1315,18 @@ func validateAddressAndStadium(address *string, stadium *string) error {
 	return nil
 }
 
+// Returned when a country is neither an ISO 3166-1 code, name nor a known alias.
+var errUnknownCountry = errors.New("Unknown country; see /api/countries for accepted values.")
+
+// Normalize the country to its canonical name, see models.NormalizeCountry.
+func normalizeCountry(country string) (string, error) {
+	name, ok := models.NormalizeCountry(country)
+	if !ok {
+		return "", errUnknownCountry
+	}
+	return name, nil
+}
+
 // Insert a new location into the database.
 func insertLocation(
 	ctx context.Context,
@@ -290,6 +1336,15 @@ func insertLocation(
 	capacity *int,
 	country *string,
 ) (int, error) {
+	// country is optional here, but has to be recognized when provided
+	if country != nil && *country != "" {
+		name, err := normalizeCountry(*country)
+		if err != nil {
+			return -1, err
+		}
+		country = &name
+	}
+
 	var locationID int
 	query := `
 		INSERT INTO Locations (address, stadium, capacity, country)
@@ -306,6 +1361,48 @@ func insertLocation(
 	return locationID, nil
 }
 
+// Check if the create event payload has all required fields and valid limits.
+func isValidCreateEventRequest(event models.CreateEventRequest) bool {
+	return event.Name != "" && event.Date != "" && event.Location.Address != "" &&
+		event.AvailableTickets >= 0 &&
+		(event.MaxTicketsPerReservation == nil || *event.MaxTicketsPerReservation > 0) &&
+		(event.MaxReservationsPerUser == nil || *event.MaxReservationsPerUser > 0) &&
+		(event.ReservationCutoffHours == nil || *event.ReservationCutoffHours >= 0)
+}
+
+// Insert an event at the location and generate its slug, returning its ID.
+func insertEvent(
+	ctx context.Context,
+	tx pgx.Tx,
+	event models.CreateEventRequest,
+	date string,
+	locationID int,
+	seriesID *int,
+) (int, error) {
+	var eventID int
+	query := `
+		INSERT INTO Events
+			(name, date, price, available_tickets, max_tickets_per_reservation,
+			max_reservations_per_user, sale_starts_at, visibility, reservation_cutoff_hours,
+			location_id, series_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+	if err := tx.QueryRow(
+		ctx, query,
+		event.Name, date, event.Price, event.AvailableTickets,
+		event.MaxTicketsPerReservation, event.MaxReservationsPerUser, event.SaleStartsAt,
+		event.Visibility, event.ReservationCutoffHours, locationID, seriesID,
+	).Scan(&eventID); err != nil {
+		return 0, fmt.Errorf("Failed to create the event.")
+	}
+
+	if err := setEventSlug(ctx, tx, eventID); err != nil {
+		return 0, fmt.Errorf("Failed to generate the slug.")
+	}
+	return eventID, nil
+}
+
 // Check if a location exists within the database, if it does not, insert it and return its ID.
 func getLocationID(
 	r *http.Request, tx pgx.Tx,
@@ -372,6 +1469,49 @@ func writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
 	}
 }
 
+// Set the Last-Modified header and report whether the copy of the client,
+// per If-Modified-Since, is still current. In that case 304 is written
+// and the handler has nothing more to do.
+func checkNotModified(w http.ResponseWriter, r *http.Request, modified time.Time) bool {
+	// HTTP dates have second precision
+	modified = modified.UTC().Truncate(time.Second)
+	w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || modified.After(since) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// Error of requests missing the body altogether.
+var errEmptyBody = errors.New("Request body is required.")
+
+// Decode the JSON request body into dst.
+// Returns 400 if the body is missing, 415 if it is not declared as JSON and
+// 400 if it cannot be decoded.
+func decodeJSONBody(r *http.Request, dst interface{}) (int, error) {
+	// checked first, clients sending no body often omit the content type too
+	if r.Body == nil || r.ContentLength == 0 {
+		return http.StatusBadRequest, errEmptyBody
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return http.StatusUnsupportedMediaType, fmt.Errorf(
+			"Unsupported content type; must be application/json.",
+		)
+	}
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		// chunked bodies have unknown length, and turn out empty only here
+		if err == io.EOF {
+			return http.StatusBadRequest, errEmptyBody
+		}
+		return http.StatusBadRequest, fmt.Errorf("Invalid JSON input.")
+	}
+	return http.StatusOK, nil
+}
+
 // Write JSON error message to the response body.
 func writeErrorResponse(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
@@ -391,6 +1531,16 @@ func isAdmin(r *http.Request) bool {
 	return ok && role == "ADMIN"
 }
 
+// Verify if the request was made with an impersonation token.
+func isImpersonated(r *http.Request) bool {
+	claims, err := middlewares.GetClaimsFromContext(r.Context())
+	if err != nil {
+		return false
+	}
+	_, ok := claims["impersonated_by"].(string)
+	return ok
+}
+
 // Verify if currently logged in user has permissions of registered user or higher.
 func isOverUnregistered(r *http.Request) bool {
 	claims, err := middlewares.GetClaimsFromContext(r.Context())
@@ -398,7 +1548,17 @@ func isOverUnregistered(r *http.Request) bool {
 		return false
 	}
 	role, ok := claims["role"].(string)
-	return ok && (role == "REGISTERED" || role == "ADMIN")
+	return ok && (role == "REGISTERED" || role == "STAFF" || role == "ADMIN")
+}
+
+// Verify if currently logged in user is a staff member or an admin.
+func isStaffOrAdmin(r *http.Request) bool {
+	claims, err := middlewares.GetClaimsFromContext(r.Context())
+	if err != nil {
+		return false
+	}
+	role, ok := claims["role"].(string)
+	return ok && (role == "STAFF" || role == "ADMIN")
 }
 
 // Verify if currently logged in user is a registered user.
@@ -434,6 +1594,19 @@ func getUserIdFromContext(ctx context.Context) (string, error) {
 	return userId, nil
 }
 
+// Get UUID of the user acting on the request, which for impersonation tokens
+// is the admin behind them rather than the impersonated user.
+func getActingUserIdFromContext(ctx context.Context) (string, error) {
+	claims, err := middlewares.GetClaimsFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	if impersonator, ok := claims["impersonated_by"].(string); ok {
+		return impersonator, nil
+	}
+	return getUserIdFromContext(ctx)
+}
+
 // Fetch the role name associated with a given role ID.
 func fetchRole(ctx context.Context, pool *pgxpool.Pool, roleID int) (string, error) {
 	var roleName string
@@ -445,6 +1618,11 @@ func fetchRole(ctx context.Context, pool *pgxpool.Pool, roleID int) (string, err
 	return strings.ToUpper(roleName), nil
 }
 
+// Normalize the name of a role, as stored in roles.
+func normalizeRoleName(roleName string) string {
+	return strings.ToUpper(strings.TrimSpace(roleName))
+}
+
 // Fetch role ID associated with a given role name.
 // Returns 500 if the role ID cannot be fetched.
 func fetchRoleId(ctx context.Context, pool *pgxpool.Pool, roleName string) (int, error) {
@@ -464,9 +1642,11 @@ func validateCreateUserPayload(
 	isAdmin bool,
 	user models.CreateUserRequest,
 ) (int, error) {
-	// check if user has permissions to create an admin user
-	if user.RoleName == "ADMIN" && !isAdmin {
-		return http.StatusForbidden, fmt.Errorf("Insufficient permissions to create an admin user.")
+	// check if user has permissions to create an admin or staff user
+	if (user.RoleName == "ADMIN" || user.RoleName == "STAFF") && !isAdmin {
+		return http.StatusForbidden, fmt.Errorf(
+			"Insufficient permissions to create an admin or staff user.",
+		)
 	}
 
 	// validate input fields
@@ -474,6 +1654,11 @@ func validateCreateUserPayload(
 		return http.StatusBadRequest, fmt.Errorf("Missing required fields.")
 	}
 
+	// reserved usernames can be handed out by admins only
+	if !isAdmin && isReservedUsername(user.Username) {
+		return http.StatusBadRequest, fmt.Errorf("Username is reserved.")
+	}
+
 	return 200, nil
 }
 
@@ -505,6 +1690,15 @@ func isDuplicate(
 	return http.StatusInternalServerError, fmt.Errorf("Failed to check for duplicate username.")
 }
 
+// Check if the error is a unique constraint violation, returning the violated constraint.
+func uniqueViolation(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return pgErr.ConstraintName, true
+	}
+	return "", false
+}
+
 // Verify if the user can be updated with the username passed in the payload.
 // Returns status 200 if the username is not taken, 409 if it is. In case of other errors, 500.
 func isDuplicateExcept(
@@ -532,3 +1726,85 @@ func isDuplicateExcept(
 	}
 	return http.StatusInternalServerError, fmt.Errorf("Failed to check for duplicate username.")
 }
+
+// Top-level fields of an event, which can be selected with the fields parameter.
+var eventFields = []string{
+	"id", "name", "slug", "price", "currency", "available_tickets", "date",
+	"sale_starts_at", "on_sale", "visibility", "reservation_cutoff_hours", "sales_open",
+	"location",
+}
+
+// Parse the tz query parameter, an IANA timezone event dates are presented in.
+// Returns UTC if the parameter is not provided.
+func parseTimezone(r *http.Request) (*time.Location, error) {
+	param := r.URL.Query().Get("tz")
+	if param == "" {
+		return time.UTC, nil
+	}
+	// Local would leak the timezone of the server
+	loc, err := time.LoadLocation(param)
+	if err != nil || param == "Local" {
+		return nil, fmt.Errorf("Unknown timezone '%s'.", param)
+	}
+	return loc, nil
+}
+
+// Copy the events with dates converted to the timezone, leaving the originals
+// (possibly cached) untouched.
+func localizeEvents(events []models.EventResponse, loc *time.Location) []models.EventResponse {
+	localized := make([]models.EventResponse, len(events))
+	for i, event := range events {
+		event.Date = event.Date.In(loc)
+		if event.SaleStartsAt != nil {
+			saleStartsAt := event.SaleStartsAt.In(loc)
+			event.SaleStartsAt = &saleStartsAt
+		}
+		localized[i] = event
+	}
+	return localized
+}
+
+// Parse the comma-separated fields query parameter, validated against the
+// allowed fields. Returns nil if the parameter is not provided.
+func parseFields(r *http.Request, allowed []string) ([]string, error) {
+	param := r.URL.Query().Get("fields")
+	if param == "" {
+		return nil, nil
+	}
+
+	fields := []string{}
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if !slices.Contains(allowed, field) {
+			return nil, fmt.Errorf(
+				"Unknown field '%s'; allowed fields are %s.",
+				field,
+				strings.Join(allowed, ", "),
+			)
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// Reduce the response to the selected top-level fields, keyed by their JSON names.
+func selectFields(value interface{}, fields []string) (map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	all := map[string]json.RawMessage{}
+	if err := json.Unmarshal(encoded, &all); err != nil {
+		return nil, err
+	}
+
+	selected := map[string]json.RawMessage{}
+	for _, field := range fields {
+		if raw, ok := all[field]; ok {
+			selected[field] = raw
+		}
+	}
+	return selected, nil
+}
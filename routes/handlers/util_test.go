@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"event-reservation-api/models"
+)
+
+func TestCheckReservationSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		limit   int
+		wantErr bool
+	}{
+		{"below the limit", 19, 20, false},
+		{"at the limit", 20, 20, false},
+		{"above the limit", 21, 20, true},
+		{"raised limit of an event", 150, 200, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkReservationSize(tt.count, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkReservationSize(%d, %d) = %v, want error %v",
+					tt.count, tt.limit, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCountTickets(t *testing.T) {
+	tests := []struct {
+		name    string
+		tickets []models.ReservationTicketRequest
+		want    int
+		wantErr bool
+	}{
+		{"empty", []models.ReservationTicketRequest{}, 0, true},
+		{"without quantity", []models.ReservationTicketRequest{{Type: "STANDARD"}}, 1, false},
+		{
+			"with quantities",
+			[]models.ReservationTicketRequest{
+				{Type: "STANDARD", Quantity: 3},
+				{Type: "VIP"},
+			},
+			4,
+			false,
+		},
+		{
+			"negative quantity",
+			[]models.ReservationTicketRequest{{Type: "STANDARD", Quantity: -1}},
+			0,
+			true,
+		},
+		{
+			"overflowing quantities",
+			[]models.ReservationTicketRequest{
+				{Type: "STANDARD", Quantity: math.MaxInt},
+				{Type: "VIP", Quantity: 1},
+			},
+			0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := countTickets(tt.tickets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("countTickets() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("countTickets() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateReservationRequestRejectsHugeQuantity(t *testing.T) {
+	payload := models.CreateReservationPayload{
+		EventID: 1,
+		Tickets: []models.ReservationTicketRequest{{Type: "STANDARD", Quantity: 2000000000}},
+	}
+
+	// counted without expanding, so the size is checked before any allocation
+	count, err := validateReservationRequest(payload)
+	if err != nil {
+		t.Fatalf("validateReservationRequest() error = %v", err)
+	}
+	if err := checkReservationSize(count, maxTicketsPerReservation); err == nil {
+		t.Fatalf("reservation of %d tickets passed the limit of %d", count, maxTicketsPerReservation)
+	}
+}
+
+func TestDecodeJSONBodyEmpty(t *testing.T) {
+	// known to be empty, and chunked, turning out empty only once read
+	empty := httptest.NewRequest(http.MethodPost, "/api/login", nil)
+	chunked := httptest.NewRequest(http.MethodPost, "/api/login", io.NopCloser(strings.NewReader("")))
+	chunked.ContentLength = -1
+	chunked.Header.Set("Content-Type", "application/json")
+
+	for name, r := range map[string]*http.Request{"empty": empty, "chunked": chunked} {
+		t.Run(name, func(t *testing.T) {
+			var payload models.LoginRequest
+			status, err := decodeJSONBody(r, &payload)
+			if status != http.StatusBadRequest || err != errEmptyBody {
+				t.Fatalf("decodeJSONBody() = %d, %v, want %d, %v",
+					status, err, http.StatusBadRequest, errEmptyBody)
+			}
+		})
+	}
+}
+
+func TestHandlersRejectEmptyBody(t *testing.T) {
+	// the body is decoded before the database is touched
+	tests := []struct {
+		name    string
+		handler http.Handler
+		method  string
+		path    string
+	}{
+		{"login", LoginHandler(nil, "secret"), http.MethodPost, "/api/login"},
+		{"registration", CreateUserHandler(nil), http.MethodPut, "/api/users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.handler, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+			var body models.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode the body: %v", err)
+			}
+			if body.Message != errEmptyBody.Error() {
+				t.Fatalf("message = %q, want %q", body.Message, errEmptyBody.Error())
+			}
+		})
+	}
+}
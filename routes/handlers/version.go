@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/models"
+)
+
+// Time the database is given to report its version.
+const versionQueryTimeout = 2 * time.Second
+
+// Version of the running build, injected through ldflags in main.
+type BuildInfo struct {
+	Version string
+	Commit  string
+}
+
+// GetVersionHandler returns the version of the service and its database.
+//
+//	@Summary		Get the deployed versions (admin only).
+//	@Description	Retrieve the version and commit the service was built from, the Go version and the version of the connected PostgreSQL server. The database version is left out if the server does not answer in time.
+//	@Tags			admin
+//	@ID				api.getVersion
+//	@Produce		json
+//	@Success		200	{object}	models.VersionResponse	"Versions"
+//	@Failure		401	{object}	models.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	models.ErrorResponse	"Forbidden"
+//	@Security		BearerAuth
+//	@Router			/version [get]
+func GetVersionHandler(pool *pgxpool.Pool, build BuildInfo) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			writeErrorResponse(
+				w,
+				http.StatusForbidden,
+				"Insufficient permissions to view the version.",
+			)
+			return
+		}
+
+		response := models.VersionResponse{
+			Version:   build.Version,
+			Commit:    build.Commit,
+			GoVersion: runtime.Version(),
+		}
+
+		// an unresponsive database should not hold up the diagnostics
+		ctx, cancel := context.WithTimeout(r.Context(), versionQueryTimeout)
+		defer cancel()
+		if err := pool.QueryRow(ctx, "SELECT version()").Scan(&response.DatabaseVersion); err != nil {
+			log.Printf("Failed to fetch the database version: %v", err)
+		}
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"event-reservation-api/middlewares"
+	"event-reservation-api/models"
+)
+
+// WhoAmIHandler returns the identity of the current user.
+//
+//	@Summary		Identity of the current user.
+//	@Description	Retrieve the ID, username and role of the current user, along with the permissions granted to the role. Impersonation tokens also carry the ID of the admin.
+//	@Tags			auth
+//	@ID				api.whoami
+//	@Produce		json
+//	@Success		200	{object}	models.WhoAmIResponse	"Current user"
+//	@Failure		401	{object}	models.ErrorResponse	"Unauthorized"
+//	@Failure		500	{object}	models.ErrorResponse	"Internal Server Error"
+//	@Security		BearerAuth
+//	@Router			/auth/whoami [get]
+func WhoAmIHandler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, err := middlewares.GetClaimsFromContext(r.Context())
+		if err != nil {
+			writeErrorResponse(w, http.StatusUnauthorized, "You are not logged in.")
+			return
+		}
+
+		var response models.WhoAmIResponse
+		var ok bool
+		if response.ID, ok = claims["userID"].(string); !ok {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the user.")
+			return
+		}
+		if response.Role, ok = claims["role"].(string); !ok {
+			writeErrorResponse(w, http.StatusUnauthorized, "Failed to identify the role.")
+			return
+		}
+		response.ImpersonatedBy, _ = claims["impersonated_by"].(string)
+
+		// permissions follow the role in the token, as the rest of the API does
+		query := `
+			SELECT
+				u.username,
+				COALESCE(
+					(
+						SELECT ARRAY_AGG(p.name ORDER BY p.name)
+						FROM roles ro
+						JOIN role_permissions rp ON rp.role_id = ro.id
+						JOIN permissions p ON p.id = rp.permission_id
+						WHERE ro.name = $2
+					),
+					'{}'
+				)
+			FROM users u
+			WHERE u.id = $1
+		`
+		if err := pool.QueryRow(r.Context(), query, response.ID, response.Role).
+			Scan(&response.Username, &response.Permissions); err != nil {
+			// the token outlived its user
+			if err == pgx.ErrNoRows {
+				writeErrorResponse(w, http.StatusUnauthorized, "User no longer exists.")
+				return
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch the user.")
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
@@ -10,33 +10,98 @@ import (
 	"event-reservation-api/routes/handlers"
 )
 
-func SetupRoutes(pool *pgxpool.Pool, jwtSecret string) *mux.Router {
+func SetupRoutes(
+	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
+	jwtSecret string,
+	build handlers.BuildInfo,
+) *mux.Router {
 	r := mux.NewRouter()
 
+	// uniform JSON errors for unmatched requests
+	r.NotFoundHandler = handlers.NotFoundHandler()
+	r.MethodNotAllowedHandler = handlers.MethodNotAllowedHandler()
+
 	// Middlewares
 	authMiddleware := middlewares.RequireAuth(jwtSecret)
-	tokenValidationMiddleware := middlewares.TokenValidation(pool, jwtSecret)
+	tokenValidationMiddleware := middlewares.TokenValidation(blacklist, jwtSecret)
 
 	// Public routes
-	setupPublicRoutes(r, pool, jwtSecret)
+	setupPublicRoutes(r, pool, blacklist, jwtSecret)
 
 	// Protected routes
 	setupLocationRoutes(r, pool, authMiddleware, tokenValidationMiddleware)
-	setupReservationRoutes(r, pool, authMiddleware, tokenValidationMiddleware)
+	setupReservationRoutes(r, pool, jwtSecret, authMiddleware, tokenValidationMiddleware)
 	setupEventRoutes(r, pool, authMiddleware, tokenValidationMiddleware)
-	setupUserRoutes(r, pool, authMiddleware, tokenValidationMiddleware)
+	setupUserRoutes(r, pool, blacklist, authMiddleware, tokenValidationMiddleware)
+	setupRoleRoutes(r, pool, authMiddleware, tokenValidationMiddleware)
+	setupAuthRoutes(r, pool, blacklist, authMiddleware, tokenValidationMiddleware)
+	setupTicketRoutes(r, pool, jwtSecret, authMiddleware, tokenValidationMiddleware)
+	setupTicketTypeRoutes(r, pool, authMiddleware, tokenValidationMiddleware)
+	setupPromoCodeRoutes(r, pool, authMiddleware, tokenValidationMiddleware)
+	setupAdminRoutes(r, pool, jwtSecret, authMiddleware, tokenValidationMiddleware)
+
+	// batched reads, dispatched back through the router
+	r.Handle("/api/batch", authMiddleware(tokenValidationMiddleware(handlers.BatchHandler(r)))).
+		Methods(http.MethodPost)
+
+	r.Handle(
+		"/api/stats/sales-timeseries",
+		authMiddleware(tokenValidationMiddleware(handlers.GetSalesTimeseriesHandler(pool))),
+	).Methods(http.MethodGet)
+
+	// deployed versions, for incident response
+	r.Handle(
+		"/api/version",
+		authMiddleware(tokenValidationMiddleware(handlers.GetVersionHandler(pool, build))),
+	).Methods(http.MethodGet)
 
 	return r
 }
 
-func setupPublicRoutes(r *mux.Router, pool *pgxpool.Pool, jwtSecret string) {
+func setupPublicRoutes(
+	r *mux.Router,
+	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
+	jwtSecret string,
+) {
 	r.HandleFunc("/api/login", handlers.LoginHandler(pool, jwtSecret)).Methods(http.MethodPost)
-	r.HandleFunc("/api/logout", handlers.LogoutHandler(pool, jwtSecret)).Methods(http.MethodPost)
+	r.HandleFunc("/api/auth/2fa/verify", handlers.VerifyTwoFactorHandler(pool, jwtSecret)).
+		Methods(http.MethodPost)
+	r.HandleFunc("/api/logout", handlers.LogoutHandler(blacklist, jwtSecret)).Methods(http.MethodPost)
+
+	r.HandleFunc("/api/config", handlers.GetConfigHandler()).Methods(http.MethodGet)
+	r.HandleFunc("/api/ticket-statuses", handlers.GetTicketStatusesHandler(pool)).
+		Methods(http.MethodGet)
 
 	r.HandleFunc("/api/events", handlers.GetEventsHandler(pool)).Methods(http.MethodGet)
-	r.HandleFunc("/api/events/{id}", handlers.GetEventByIDHandler(pool)).Methods(http.MethodGet)
+	r.HandleFunc("/api/stats/availability", handlers.GetAvailabilityStatsHandler(pool)).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/events/recent", handlers.GetRecentEventsHandler(pool)).
+		Methods(http.MethodGet)
+	// private events are shown to those allowed to see them, if authenticated
+	optionalAuth := middlewares.OptionalAuth(blacklist, jwtSecret)
+	r.Handle("/api/events/{id:[0-9]+}", optionalAuth(handlers.GetEventByIDHandler(pool))).
+		Methods(http.MethodGet)
+	r.Handle("/api/events/slug/{slug}", optionalAuth(handlers.GetEventBySlugHandler(pool))).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/events/series/{seriesId:[0-9]+}", handlers.GetEventSeriesHandler(pool)).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/events/{id:[0-9]+}/similar", handlers.GetSimilarEventsHandler(pool)).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/events/{id:[0-9]+}/prices", handlers.GetEventTicketPricesHandler(pool)).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/events/{id:[0-9]+}/sections", handlers.GetEventSectionsHandler(pool)).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/calendar/{token}.ics", handlers.GetCalendarFeedHandler(pool, jwtSecret)).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/shared/reservation", handlers.GetSharedReservationHandler(pool, jwtSecret)).
+		Methods(http.MethodGet)
 	r.HandleFunc("/api/locations", handlers.GetLocationsHandler(pool)).Methods(http.MethodGet)
-	r.HandleFunc("/api/locations/{id}", handlers.GetLocationByIDHandler(pool)).
+	r.HandleFunc("/api/countries", handlers.GetCountriesHandler()).Methods(http.MethodGet)
+	r.HandleFunc("/api/locations/{id:[0-9]+}", handlers.GetLocationByIDHandler(pool)).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/locations/{id:[0-9]+}/events", handlers.GetLocationEventsHandler(pool)).
 		Methods(http.MethodGet)
 }
 
@@ -49,13 +114,18 @@ func setupLocationRoutes(
 	locRouter.Use(authMiddleware, tokenValidationMiddleware)
 
 	locRouter.HandleFunc("", handlers.CreateLocationHandler(pool)).Methods(http.MethodPut)
+	locRouter.HandleFunc("/stats", handlers.GetLocationsStatsHandler(pool)).Methods(http.MethodGet)
+	locRouter.HandleFunc("/merge", handlers.MergeLocationsHandler(pool)).Methods(http.MethodPost)
 	locRouter.HandleFunc("/{id}", handlers.UpdateLocationHandler(pool)).Methods(http.MethodPut)
+	locRouter.HandleFunc("/{id}/conflicts", handlers.GetLocationConflictsHandler(pool)).
+		Methods(http.MethodGet)
 	locRouter.HandleFunc("/{id}", handlers.DeleteLocationHandler(pool)).Methods(http.MethodDelete)
 }
 
 func setupReservationRoutes(
 	r *mux.Router,
 	pool *pgxpool.Pool,
+	jwtSecret string,
 	authMiddleware, tokenValidationMiddleware mux.MiddlewareFunc,
 ) {
 	resRouter := r.PathPrefix("/api/reservations").Subrouter()
@@ -63,11 +133,33 @@ func setupReservationRoutes(
 
 	resRouter.HandleFunc("", handlers.CreateReservationHandler(pool)).Methods(http.MethodPut)
 	resRouter.HandleFunc("", handlers.GetReservationHandler(pool)).Methods(http.MethodGet)
+	resRouter.HandleFunc("/for/{userId}", handlers.CreateReservationForUserHandler(pool)).
+		Methods(http.MethodPut)
+	resRouter.HandleFunc("/export", handlers.ExportReservationsHandler(pool)).
+		Methods(http.MethodGet)
+	resRouter.HandleFunc("/expiring", handlers.GetExpiringReservationsHandler(pool)).
+		Methods(http.MethodGet)
+	resRouter.HandleFunc(
+		"/created-by-me",
+		handlers.GetReservationsCreatedByCurrentUserHandler(pool),
+	).Methods(http.MethodGet)
 
 	resRouter.HandleFunc("/user", handlers.GetCurrentUserReservationsHandler(pool)).
 		Methods(http.MethodGet)
 	resRouter.HandleFunc("/user/tickets", handlers.GetCurrentUserReservationsTicketsHandler(pool)).
 		Methods(http.MethodGet)
+	resRouter.HandleFunc("/user/summary", handlers.GetCurrentUserReservationSummaryHandler(pool)).
+		Methods(http.MethodGet)
+	resRouter.HandleFunc("/user/calendar.ics", handlers.GetCurrentUserCalendarHandler(pool)).
+		Methods(http.MethodGet)
+	resRouter.HandleFunc(
+		"/user/calendar/token",
+		handlers.GetCalendarFeedTokenHandler(pool, jwtSecret),
+	).Methods(http.MethodGet)
+	resRouter.HandleFunc(
+		"/user/calendar/token",
+		handlers.RotateCalendarFeedTokenHandler(pool, jwtSecret),
+	).Methods(http.MethodPost)
 	resRouter.HandleFunc("/user/{id}/tickets", handlers.GetUserReservationsTicketsHandler(pool)).
 		Methods(http.MethodGet)
 	resRouter.HandleFunc("/user/{id}", handlers.GetUserReservationsHandler(pool)).
@@ -76,8 +168,24 @@ func setupReservationRoutes(
 	resRouter.HandleFunc("/{id}", handlers.GetReservationByIDHandler(pool)).Methods(http.MethodGet)
 	resRouter.HandleFunc("/{id}/cancel", handlers.CancelReservationHandler(pool)).
 		Methods(http.MethodPost)
+	resRouter.HandleFunc("/{id}/cancel-preview", handlers.GetCancelPreviewHandler(pool)).
+		Methods(http.MethodGet)
+	resRouter.HandleFunc("/{id}/payments", handlers.GetReservationPaymentsHandler(pool)).
+		Methods(http.MethodGet)
+	resRouter.HandleFunc("/{id}/confirm", handlers.ConfirmReservationHandler(pool)).
+		Methods(http.MethodPost)
 	resRouter.HandleFunc("/{id}/tickets", handlers.GetReservationTicketsHandler(pool)).
 		Methods(http.MethodGet)
+	resRouter.HandleFunc("/{id}/share", handlers.GetReservationShareLinkHandler(pool, jwtSecret)).
+		Methods(http.MethodGet)
+	resRouter.HandleFunc("/{id}/reissue", handlers.ReissueReservationTicketsHandler(pool)).
+		Methods(http.MethodPost)
+	resRouter.HandleFunc(
+		"/{id}/resend-confirmation",
+		handlers.ResendConfirmationHandler(pool),
+	).Methods(http.MethodPost)
+	resRouter.HandleFunc("/{id}/notes", handlers.UpdateReservationNotesHandler(pool)).
+		Methods(http.MethodPatch)
 	resRouter.HandleFunc("/{id}", handlers.DeleteReservationHandler(pool)).
 		Methods(http.MethodDelete)
 
@@ -94,21 +202,143 @@ func setupEventRoutes(
 	eventRouter.Use(authMiddleware, tokenValidationMiddleware)
 
 	eventRouter.HandleFunc("", handlers.CreateEventHandler(pool)).Methods(http.MethodPut)
+	eventRouter.HandleFunc("/export", handlers.ExportEventsHandler(pool)).
+		Methods(http.MethodGet)
+	eventRouter.HandleFunc("/series", handlers.CreateEventSeriesHandler(pool)).
+		Methods(http.MethodPost)
 	eventRouter.HandleFunc("/{id}", handlers.UpdateEventHandler(pool)).Methods(http.MethodPut)
 	eventRouter.HandleFunc("/{id}", handlers.DeleteEventHandler(pool)).Methods(http.MethodDelete)
+	eventRouter.HandleFunc("/{id}/prices", handlers.SetEventTicketPricesHandler(pool)).
+		Methods(http.MethodPut)
+	eventRouter.HandleFunc("/{id}/prices/{type}", handlers.DeleteEventTicketPriceHandler(pool)).
+		Methods(http.MethodDelete)
+	eventRouter.HandleFunc("/{id}/attendees", handlers.GetEventAttendeesHandler(pool)).
+		Methods(http.MethodGet)
+	eventRouter.HandleFunc("/{id}/sections", handlers.SetEventSectionsHandler(pool)).
+		Methods(http.MethodPut)
+	eventRouter.HandleFunc("/{id}/reprice", handlers.RepriceEventHandler(pool)).
+		Methods(http.MethodPost)
+	eventRouter.HandleFunc("/{id}/clone", handlers.CloneEventHandler(pool)).
+		Methods(http.MethodPost)
 }
 
 func setupUserRoutes(
 	r *mux.Router,
 	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
 	authMiddleware, tokenValidationMiddleware mux.MiddlewareFunc,
 ) {
 	userRouter := r.PathPrefix("/api/users").Subrouter()
 	userRouter.Use(authMiddleware, tokenValidationMiddleware)
 
 	userRouter.HandleFunc("", handlers.GetUserHandler(pool)).Methods(http.MethodGet)
+	userRouter.HandleFunc("/me/export", handlers.ExportCurrentUserDataHandler(pool)).
+		Methods(http.MethodGet)
+	userRouter.HandleFunc("/by-username/{username}", handlers.GetUserByUsernameHandler(pool)).
+		Methods(http.MethodGet)
 	userRouter.HandleFunc("/{id}", handlers.GetUserByIDHandler(pool)).Methods(http.MethodGet)
+	userRouter.HandleFunc("/bulk", handlers.BulkCreateUsersHandler(pool)).Methods(http.MethodPut)
 	userRouter.HandleFunc("/", handlers.CreateUserHandler(pool)).Methods(http.MethodPut)
-	userRouter.HandleFunc("/{id}", handlers.DeleteUserHandler(pool)).Methods(http.MethodDelete)
+	userRouter.HandleFunc("/{id}", handlers.DeleteUserHandler(pool, blacklist)).
+		Methods(http.MethodDelete)
 	userRouter.HandleFunc("/{id}", handlers.UpdateUserHandler(pool)).Methods(http.MethodPut)
+	userRouter.HandleFunc("/{id}/role", handlers.ChangeUserRoleHandler(pool, blacklist)).
+		Methods(http.MethodPost)
+	userRouter.HandleFunc(
+		"/{id}/cancel-reservations",
+		handlers.CancelUserReservationsHandler(pool),
+	).Methods(http.MethodPost)
+}
+
+func setupRoleRoutes(
+	r *mux.Router,
+	pool *pgxpool.Pool,
+	authMiddleware, tokenValidationMiddleware mux.MiddlewareFunc,
+) {
+	roleRouter := r.PathPrefix("/api/roles").Subrouter()
+	roleRouter.Use(authMiddleware, tokenValidationMiddleware)
+
+	roleRouter.HandleFunc("", handlers.GetRolesHandler(pool)).Methods(http.MethodGet)
+}
+
+func setupAuthRoutes(
+	r *mux.Router,
+	pool *pgxpool.Pool,
+	blacklist middlewares.TokenBlacklist,
+	authMiddleware, tokenValidationMiddleware mux.MiddlewareFunc,
+) {
+	authRouter := r.PathPrefix("/api/auth").Subrouter()
+	authRouter.Use(authMiddleware, tokenValidationMiddleware)
+
+	authRouter.HandleFunc("/whoami", handlers.WhoAmIHandler(pool)).Methods(http.MethodGet)
+	authRouter.HandleFunc("/sessions", handlers.GetSessionsHandler(pool, blacklist)).
+		Methods(http.MethodGet)
+	authRouter.HandleFunc("/sessions/{id:[0-9]+}", handlers.DeleteSessionHandler(pool, blacklist)).
+		Methods(http.MethodDelete)
+	authRouter.HandleFunc("/2fa/enroll", handlers.EnrollTwoFactorHandler(pool)).
+		Methods(http.MethodPost)
+	authRouter.HandleFunc("/2fa/enroll/verify", handlers.ConfirmTwoFactorHandler(pool)).
+		Methods(http.MethodPost)
+}
+
+func setupTicketRoutes(
+	r *mux.Router,
+	pool *pgxpool.Pool,
+	jwtSecret string,
+	authMiddleware, tokenValidationMiddleware mux.MiddlewareFunc,
+) {
+	ticketRouter := r.PathPrefix("/api/tickets").Subrouter()
+	ticketRouter.Use(authMiddleware, tokenValidationMiddleware)
+
+	ticketRouter.HandleFunc("/verify-batch", handlers.VerifyTicketsBatchHandler(pool, jwtSecret)).
+		Methods(http.MethodPost)
+	ticketRouter.HandleFunc("/{id}/check-in", handlers.CheckInTicketHandler(pool)).
+		Methods(http.MethodPost)
+	ticketRouter.HandleFunc("/{id}/status", handlers.UpdateTicketStatusHandler(pool)).
+		Methods(http.MethodPatch)
+	ticketRouter.HandleFunc("/{id}/token", handlers.GetTicketTokenHandler(pool, jwtSecret)).
+		Methods(http.MethodGet)
+	ticketRouter.HandleFunc("/{id}/reservation", handlers.GetTicketReservationHandler(pool)).
+		Methods(http.MethodGet)
+	ticketRouter.HandleFunc("/{id}/pricing", handlers.GetTicketPricingHandler(pool)).
+		Methods(http.MethodGet)
+}
+
+func setupTicketTypeRoutes(
+	r *mux.Router,
+	pool *pgxpool.Pool,
+	authMiddleware, tokenValidationMiddleware mux.MiddlewareFunc,
+) {
+	ticketTypeRouter := r.PathPrefix("/api/ticket-types").Subrouter()
+	ticketTypeRouter.Use(authMiddleware, tokenValidationMiddleware)
+
+	ticketTypeRouter.HandleFunc("/stats", handlers.GetTicketTypesStatsHandler(pool)).
+		Methods(http.MethodGet)
+	ticketTypeRouter.HandleFunc("/{id:[0-9]+}/preview", handlers.GetTicketTypePreviewHandler(pool)).
+		Methods(http.MethodGet)
+}
+
+func setupPromoCodeRoutes(
+	r *mux.Router,
+	pool *pgxpool.Pool,
+	authMiddleware, tokenValidationMiddleware mux.MiddlewareFunc,
+) {
+	promoCodeRouter := r.PathPrefix("/api/promo-codes").Subrouter()
+	promoCodeRouter.Use(authMiddleware, tokenValidationMiddleware)
+
+	promoCodeRouter.HandleFunc("/{code}/validate", handlers.ValidatePromoCodeHandler(pool)).
+		Methods(http.MethodGet)
+}
+
+func setupAdminRoutes(
+	r *mux.Router,
+	pool *pgxpool.Pool,
+	jwtSecret string,
+	authMiddleware, tokenValidationMiddleware mux.MiddlewareFunc,
+) {
+	adminRouter := r.PathPrefix("/api/admin").Subrouter()
+	adminRouter.Use(authMiddleware, tokenValidationMiddleware)
+
+	adminRouter.HandleFunc("/impersonate/{userId}", handlers.ImpersonateUserHandler(pool, jwtSecret)).
+		Methods(http.MethodPost)
 }
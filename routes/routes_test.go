@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"event-reservation-api/models"
+	"event-reservation-api/routes/handlers"
+)
+
+func TestUnmatchedRequests(t *testing.T) {
+	// unmatched requests never reach a handler, so no database is needed
+	r := SetupRoutes(nil, nil, "secret", handlers.BuildInfo{})
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		status  int
+		message string
+	}{
+		{"unknown path", http.MethodGet, "/api/unknown", http.StatusNotFound, "Resource not found."},
+		{
+			"wrong method",
+			http.MethodDelete,
+			"/api/login",
+			http.StatusMethodNotAllowed,
+			"Method not allowed.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Fatalf("Content-Type = %q, want application/json", ct)
+			}
+			var body models.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode the body: %v", err)
+			}
+			if body.Message != tt.message {
+				t.Fatalf("message = %q, want %q", body.Message, tt.message)
+			}
+		})
+	}
+}